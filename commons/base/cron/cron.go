@@ -12,25 +12,40 @@ import (
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
 	//siteInfoService service.SiteInfoCommonServicer
-	questionService *service.QuestionService
+	questionService          *service.QuestionService
+	dashboardService         service.DashboardService
+	questionLifecycleService *service.QuestionLifecycleService
+	revisionReviewService    *service.RevisionReviewService
+	questionViewService      *service.QuestionViewService
+	userDataService          *service.UserDataService
 }
 
 // NewScheduledTaskManager new scheduled task manager
 func NewScheduledTaskManager(
 	//siteInfoService service.SiteInfoCommonServicer,
 	questionService *service.QuestionService,
+	dashboardService service.DashboardService,
+	questionLifecycleService *service.QuestionLifecycleService,
+	revisionReviewService *service.RevisionReviewService,
+	questionViewService *service.QuestionViewService,
+	userDataService *service.UserDataService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
 		//siteInfoService: siteInfoService,
-		questionService: questionService,
+		questionService:          questionService,
+		dashboardService:         dashboardService,
+		questionLifecycleService: questionLifecycleService,
+		revisionReviewService:    revisionReviewService,
+		questionViewService:      questionViewService,
+		userDataService:          userDataService,
 	}
 	return manager
 }
 
 func (s *ScheduledTaskManager) Run() {
 	fmt.Println("start cron")
-	s.questionService.SitemapCron(context.Background())
 	c := cron.New()
+	s.questionService.SitemapCron(context.Background())
 	_, err := c.AddFunc("0 */1 * * *", func() {
 		ctx := context.Background()
 		fmt.Println("sitemap cron execution")
@@ -39,5 +54,50 @@ func (s *ScheduledTaskManager) Run() {
 	if err != nil {
 		log.Error(err)
 	}
+
+	_, err = c.AddFunc("10 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("dashboard statistics rollup cron execution")
+		s.dashboardService.RollupRecentStat(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("20 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("question lifecycle cron execution")
+		s.questionLifecycleService.LifecycleCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("30 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("revision review expire cron execution")
+		s.revisionReviewService.ExpireCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/5 * * * *", func() {
+		ctx := context.Background()
+		fmt.Println("question view flush cron execution")
+		s.questionViewService.FlushCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("40 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("user deletion cron execution")
+		s.userDataService.DeletionCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
 	c.Start()
 }
@@ -13,7 +13,9 @@ import (
 	"github.com/lawyer/controller"
 	"github.com/lawyer/pkg/converter"
 	"github.com/lawyer/pkg/day"
+	"github.com/lawyer/pkg/display"
 	"github.com/lawyer/pkg/htmltext"
+	"github.com/lawyer/pkg/uid"
 	"github.com/segmentfault/pacman/i18n"
 )
 
@@ -119,6 +121,8 @@ var funcMap = template.FuncMap{
 	"urlTitle": func(title string) string {
 		return htmltext.UrlTitle(title)
 	},
+	"questionURL": display.QuestionURL,
+	"deShortID":   uid.DeShortID,
 }
 
 func FormatLinkNofollow(html string) string {
@@ -0,0 +1,14 @@
+package server
+
+import (
+	"html/template"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/ui"
+)
+
+// LoadTemplate load the html templates of the server side rendered pages
+func LoadTemplate(r *gin.Engine) {
+	tpl := template.Must(template.New("").Funcs(funcMap).ParseFS(ui.Template, "template/*.html"))
+	r.SetHTMLTemplate(tpl)
+}
@@ -21,28 +21,33 @@ const (
 )
 
 const (
-	ActQuestionAsked     ActivityTypeKey = "question.asked"
-	ActQuestionClosed    ActivityTypeKey = "question.closed"
-	ActQuestionReopened  ActivityTypeKey = "question.reopened"
-	ActQuestionAnswered  ActivityTypeKey = "question.answered"
-	ActQuestionCommented ActivityTypeKey = "question.commented"
-	ActQuestionAccept    ActivityTypeKey = "question.accept"
-	ActQuestionUpvote    ActivityTypeKey = "question.upvote"
-	ActQuestionDownVote  ActivityTypeKey = "question.downvote"
-	ActQuestionEdited    ActivityTypeKey = "question.edited"
-	ActQuestionRollback  ActivityTypeKey = "question.rollback"
-	ActQuestionDeleted   ActivityTypeKey = "question.deleted"
-	ActQuestionUndeleted ActivityTypeKey = "question.undeleted"
-	ActQuestionPin       ActivityTypeKey = "question.pin"
-	ActQuestionUnPin     ActivityTypeKey = "question.unpin"
-	ActQuestionHide      ActivityTypeKey = "question.hide"
-	ActQuestionShow      ActivityTypeKey = "question.show"
+	ActQuestionAsked          ActivityTypeKey = "question.asked"
+	ActQuestionClosed         ActivityTypeKey = "question.closed"
+	ActQuestionReopened       ActivityTypeKey = "question.reopened"
+	ActQuestionAnswered       ActivityTypeKey = "question.answered"
+	ActQuestionCommented      ActivityTypeKey = "question.commented"
+	ActQuestionAccept         ActivityTypeKey = "question.accept"
+	ActQuestionUpvote         ActivityTypeKey = "question.upvote"
+	ActQuestionVotedUp        ActivityTypeKey = "question.voted_up"
+	ActQuestionAcceptReminded ActivityTypeKey = "question.accept_reminded"
+	ActQuestionBumped         ActivityTypeKey = "question.bumped"
+	ActQuestionDownVote       ActivityTypeKey = "question.downvote"
+	ActQuestionEdited         ActivityTypeKey = "question.edited"
+	ActQuestionRollback       ActivityTypeKey = "question.rollback"
+	ActQuestionDeleted        ActivityTypeKey = "question.deleted"
+	ActQuestionUndeleted      ActivityTypeKey = "question.undeleted"
+	ActQuestionPin            ActivityTypeKey = "question.pin"
+	ActQuestionUnPin          ActivityTypeKey = "question.unpin"
+	ActQuestionHide           ActivityTypeKey = "question.hide"
+	ActQuestionShow           ActivityTypeKey = "question.show"
 )
 
 const (
 	ActAnswerAnswered  ActivityTypeKey = "answer.answered"
 	ActAnswerCommented ActivityTypeKey = "answer.commented"
 	ActAnswerAccept    ActivityTypeKey = "answer.accept"
+	ActAnswerAccepted  ActivityTypeKey = "answer.accepted"
+	ActAnswerVotedUp   ActivityTypeKey = "answer.voted_up"
 	ActAnswerUpvote    ActivityTypeKey = "answer.upvote"
 	ActAnswerDownVote  ActivityTypeKey = "answer.downvote"
 	ActAnswerEdited    ActivityTypeKey = "answer.edited"
@@ -12,6 +12,7 @@ const (
 	AnswerAccepted    = "answer.accepted"
 	AnswerAccept      = "answer.accept"
 	CommentVoteUp     = "comment.vote_up"
+	RevisionReviewed  = "revision.reviewed"
 )
 
 var (
@@ -51,5 +52,6 @@ var (
 		AnswerAccepted:    "action_activity_type.accepted",
 		AnswerAccept:      "action_activity_type.accept",
 		CommentVoteUp:     "action_activity_type.upvote",
+		RevisionReviewed:  "action_activity_type.reviewed",
 	}
 )
@@ -17,6 +17,7 @@ const (
 	UserTokenMappingCacheKey                   = "lawyer:user-token:mapping:"
 	SiteInfoCacheKey                           = "lawyer:site-info:"
 	SiteInfoCacheTime                          = 1 * time.Hour
+	SiteInfoInvalidateChannel                  = "lawyer:site-info:invalidate"
 	ConfigID2KEYCacheKeyPrefix                 = "lawyer:config:id:"
 	ConfigKEY2ContentCacheKeyPrefix            = "lawyer:config:key:"
 	ConfigCacheTime                            = 24 * time.Hour
@@ -30,4 +31,24 @@ const (
 	NewQuestionNotificationLimitMax            = 50
 	RateLimitCacheKeyPrefix                    = "lawyer:rate-limit:"
 	RateLimitCacheTime                         = 5 * time.Minute
+	QuestionViewDedupCacheKeyPrefix            = "lawyer:question:view:dedup:"
+	QuestionViewDedupCacheTime                 = 30 * time.Minute
+	QuestionViewPendingCacheKey                = "lawyer:question:view:pending"
+	QuestionUniqueViewPendingCacheKey          = "lawyer:question:view:pending:unique"
+	QuestionDailyViewCacheKeyPrefix            = "lawyer:question:view:daily:"
+	QuestionDailyViewVisitorCacheKeyPrefix     = "lawyer:question:view:daily-visitor:"
+	QuestionDailyViewCacheTime                 = 3 * 24 * time.Hour
+	UserTwoFactorChallengeCacheKeyPrefix       = "lawyer:user:two-factor:challenge:"
+	UserTwoFactorChallengeCacheTime            = 5 * time.Minute
+	UserSessionCacheKeyPrefix                  = "lawyer:user:session:"
+	UserSessionListCacheKeyPrefix              = "lawyer:user:sessions:"
+	UserSessionCacheTime                       = 30 * 24 * time.Hour
+	UserMagicLinkCacheKeyPrefix                = "lawyer:user:magic-link:"
+	UserMagicLinkCacheTime                     = 10 * time.Minute
+	UserLoginExchangeCacheKeyPrefix            = "lawyer:user:login-exchange:"
+	UserLoginExchangeCacheTime                 = time.Minute
+	UserImportJobCacheKeyPrefix                = "lawyer:user:import:"
+	UserImportJobCacheTime                     = 24 * time.Hour
+	MetaLockCacheKeyPrefix                     = "lawyer:meta:lock:"
+	MetaLockCacheTime                          = time.Minute
 )
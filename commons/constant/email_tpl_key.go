@@ -24,4 +24,7 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeyMagicLinkTitle = "email_tpl.magic_link.title"
+	EmailTplKeyMagicLinkBody  = "email_tpl.magic_link.body"
 )
@@ -37,6 +37,18 @@ const (
 	NotificationYourCommentWasDeleted = "notification.action.your_comment_was_deleted"
 	// NotificationInvitedYouToAnswer invited you to answer
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
+	// NotificationEarnedBadge earned badge
+	NotificationEarnedBadge = "notification.action.earned_badge"
+	// NotificationRemindAcceptAnswer remind the asker to accept an answer
+	NotificationRemindAcceptAnswer = "notification.action.remind_accept_answer"
+	// NotificationUnansweredQuestion the question in the following tags is still unanswered
+	NotificationUnansweredQuestion = "notification.action.unanswered_question"
+	// NotificationYourPostWasRolledBack your question, answer or tag was rolled back to a previous revision
+	NotificationYourPostWasRolledBack = "notification.action.your_post_was_rolled_back"
+	// NotificationYourPostWasApproved your pending question or answer was approved
+	NotificationYourPostWasApproved = "notification.action.your_post_was_approved"
+	// NotificationYourPostWasRejected your pending question or answer was rejected
+	NotificationYourPostWasRejected = "notification.action.your_post_was_rejected"
 )
 
 type NotificationChannelKey string
@@ -72,5 +84,9 @@ var (
 		NotificationYourAnswerWasDeleted:   1,
 		NotificationYourCommentWasDeleted:  1,
 		NotificationInvitedYouToAnswer:     3,
+		NotificationEarnedBadge:            1,
+		NotificationRemindAcceptAnswer:     1,
+		NotificationUnansweredQuestion:     1,
+		NotificationYourPostWasRolledBack:  1,
 	}
 )
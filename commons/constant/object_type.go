@@ -8,6 +8,7 @@ const (
 	CollectionObjectType = "collection"
 	CommentObjectType    = "comment"
 	ReportObjectType     = "report"
+	BadgeObjectType      = "badge"
 )
 
 var (
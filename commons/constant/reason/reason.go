@@ -24,73 +24,119 @@ const (
 )
 
 const (
-	InternalErrMsg                   = "error.internal"
-	ParamErr                         = "error.param"
-	EmailOrPasswordWrong             = "error.object.email_or_password_incorrect"
-	CommentNotFound                  = "error.comment.not_found"
-	CommentCannotEditAfterDeadline   = "error.comment.cannot_edit_after_deadline"
-	QuestionNotFound                 = "error.question.not_found"
-	QuestionCannotDeleted            = "error.question.cannot_deleted"
-	QuestionCannotClose              = "error.question.cannot_close"
-	QuestionCannotUpdate             = "error.question.cannot_update"
-	QuestionAlreadyDeleted           = "error.question.already_deleted"
-	AnswerNotFound                   = "error.answer.not_found"
-	AnswerCannotDeleted              = "error.answer.cannot_deleted"
-	AnswerCannotUpdate               = "error.answer.cannot_update"
-	AnswerCannotAddByClosedQuestion  = "error.answer.question_closed_cannot_add"
-	AnswerRestrictAnswer             = "error.answer.restrict_answer"
-	CommentEditWithoutPermission     = "error.comment.edit_without_permission"
-	DisallowVote                     = "error.object.disallow_vote"
-	DisallowFollow                   = "error.object.disallow_follow"
-	DisallowVoteYourSelf             = "error.object.disallow_vote_your_self"
-	CaptchaVerificationFailed        = "error.object.captcha_verification_failed"
-	OldPasswordVerificationFailed    = "error.object.old_password_verification_failed"
-	NewPasswordSameAsPreviousSetting = "error.object.new_password_same_as_previous_setting"
-	UserNotFound                     = "error.user.not_found"
-	UsernameInvalid                  = "error.user.username_invalid"
-	UsernameDuplicate                = "error.user.username_duplicate"
-	UserSetAvatar                    = "error.user.set_avatar"
-	EmailDuplicate                   = "error.email.duplicate"
-	EmailVerifyURLExpired            = "error.email.verify_url_expired"
-	EmailNeedToBeVerified            = "error.email.need_to_be_verified"
-	EmailIllegalDomainError          = "error.email.illegal_email_domain_error"
-	UserSuspended                    = "error.user.suspended"
-	ObjectNotFound                   = "error.object.not_found"
-	TagNotFound                      = "error.tag.not_found"
-	TagNotContainSynonym             = "error.tag.not_contain_synonym_tags"
-	TagCannotUpdate                  = "error.tag.cannot_update"
-	TagIsUsedCannotDelete            = "error.tag.is_used_cannot_delete"
-	TagAlreadyExist                  = "error.tag.already_exist"
-	RankFailToMeetTheCondition       = "error.rank.fail_to_meet_the_condition"
-	VoteRankFailToMeetTheCondition   = "error.rank.vote_fail_to_meet_the_condition"
-	NoEnoughRankToOperate            = "error.rank.no_enough_rank_to_operate"
-	ThemeNotFound                    = "error.theme.not_found"
-	LangNotFound                     = "error.lang.not_found"
-	ReportHandleFailed               = "error.report.handle_failed"
-	ReportNotFound                   = "error.report.not_found"
-	ReadConfigFailed                 = "error.config.read_config_failed"
-	DatabaseConnectionFailed         = "error.database.connection_failed"
-	InstallCreateTableFailed         = "error.database.create_table_failed"
-	InstallConfigFailed              = "error.install.create_config_failed"
-	SiteInfoConfigNotFound           = "error.site_info.config_not_found"
-	UploadFileSourceUnsupported      = "error.upload.source_unsupported"
-	UploadFileUnsupportedFileFormat  = "error.upload.unsupported_file_format"
-	RecommendTagNotExist             = "error.tag.recommend_tag_not_found"
-	RecommendTagEnter                = "error.tag.recommend_tag_enter"
-	RevisionReviewUnderway           = "error.revision.review_underway"
-	RevisionNoPermission             = "error.revision.no_permission"
-	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
-	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
-	NotAllowedRegistration           = "error.user.not_allowed_registration"
-	NotAllowedLoginViaPassword       = "error.user.not_allowed_login_via_password"
-	SMTPConfigFromNameCannotBeEmail  = "error.smtp.config_from_name_cannot_be_email"
-	AdminCannotUpdateTheirPassword   = "error.admin.cannot_update_their_password"
-	AdminCannotModifySelfStatus      = "error.admin.cannot_modify_self_status"
-	UserAccessDenied                 = "error.user.access_denied"
-	UserPageAccessDenied             = "error.user.page_access_denied"
-	UserTokenInvalid                 = "error.user.token_invalid"
-	AddBulkUsersFormatError          = "error.user.add_bulk_users_format_error"
-	AddBulkUsersAmountError          = "error.user.add_bulk_users_amount_error"
+	InternalErrMsg                      = "error.internal"
+	ParamErr                            = "error.param"
+	EmailOrPasswordWrong                = "error.object.email_or_password_incorrect"
+	CommentNotFound                     = "error.comment.not_found"
+	CommentCannotEditAfterDeadline      = "error.comment.cannot_edit_after_deadline"
+	QuestionNotFound                    = "error.question.not_found"
+	QuestionCannotDeleted               = "error.question.cannot_deleted"
+	QuestionCannotClose                 = "error.question.cannot_close"
+	QuestionCannotUpdate                = "error.question.cannot_update"
+	QuestionAlreadyDeleted              = "error.question.already_deleted"
+	QuestionLifecycleCloseReasonInvalid = "error.question.lifecycle_close_reason_invalid"
+	QuestionViewDateRangeInvalid        = "error.question.view_date_range_invalid"
+	AnswerNotFound                      = "error.answer.not_found"
+	AnswerCannotDeleted                 = "error.answer.cannot_deleted"
+	AnswerCannotUpdate                  = "error.answer.cannot_update"
+	AnswerCannotAddByClosedQuestion     = "error.answer.question_closed_cannot_add"
+	AnswerRestrictAnswer                = "error.answer.restrict_answer"
+	AnswerDisclaimerNotAcknowledged     = "error.answer.disclaimer_not_acknowledged"
+	AnswerDisclaimerAckNotFound         = "error.answer.disclaimer_ack_not_found"
+	CommentEditWithoutPermission        = "error.comment.edit_without_permission"
+	DisallowVote                        = "error.object.disallow_vote"
+	DisallowFollow                      = "error.object.disallow_follow"
+	DisallowVoteYourSelf                = "error.object.disallow_vote_your_self"
+	CaptchaVerificationFailed           = "error.object.captcha_verification_failed"
+	OldPasswordVerificationFailed       = "error.object.old_password_verification_failed"
+	NewPasswordSameAsPreviousSetting    = "error.object.new_password_same_as_previous_setting"
+	UserNotFound                        = "error.user.not_found"
+	UsernameInvalid                     = "error.user.username_invalid"
+	UsernameDuplicate                   = "error.user.username_duplicate"
+	UserSetAvatar                       = "error.user.set_avatar"
+	EmailDuplicate                      = "error.email.duplicate"
+	EmailVerifyURLExpired               = "error.email.verify_url_expired"
+	EmailNeedToBeVerified               = "error.email.need_to_be_verified"
+	EmailIllegalDomainError             = "error.email.illegal_email_domain_error"
+	UserSuspended                       = "error.user.suspended"
+	ObjectNotFound                      = "error.object.not_found"
+	PostPendingReview                   = "error.object.pending_review"
+	PostNotPending                      = "error.object.not_pending"
+	SpamHoldScoreInvalid                = "error.object.spam_hold_score_invalid"
+	SpamScoreNotFound                   = "error.object.spam_score_not_found"
+	TagNotFound                         = "error.tag.not_found"
+	TagNotContainSynonym                = "error.tag.not_contain_synonym_tags"
+	TagCannotUpdate                     = "error.tag.cannot_update"
+	TagIsUsedCannotDelete               = "error.tag.is_used_cannot_delete"
+	TagAlreadyExist                     = "error.tag.already_exist"
+	RankFailToMeetTheCondition          = "error.rank.fail_to_meet_the_condition"
+	VoteRankFailToMeetTheCondition      = "error.rank.vote_fail_to_meet_the_condition"
+	NoEnoughRankToOperate               = "error.rank.no_enough_rank_to_operate"
+	RankManagedByPlugin                 = "error.rank.managed_by_plugin"
+	ReputationActivityTypeInvalid       = "error.rank.activity_type_invalid"
+	BadgeNotFound                       = "error.badge.not_found"
+	ThemeNotFound                       = "error.theme.not_found"
+	LangNotFound                        = "error.lang.not_found"
+	ReportHandleFailed                  = "error.report.handle_failed"
+	ReportNotFound                      = "error.report.not_found"
+	ReportNoPermission                  = "error.report.no_permission"
+	ReadConfigFailed                    = "error.config.read_config_failed"
+	DatabaseConnectionFailed            = "error.database.connection_failed"
+	InstallCreateTableFailed            = "error.database.create_table_failed"
+	InstallConfigFailed                 = "error.install.create_config_failed"
+	SiteInfoConfigNotFound              = "error.site_info.config_not_found"
+	SiteInfoVersionNotFound             = "error.site_info.version_not_found"
+	SiteInfoTimeZoneInvalid             = "error.site_info.time_zone_invalid"
+	UploadFileSourceUnsupported         = "error.upload.source_unsupported"
+	UploadFileUnsupportedFileFormat     = "error.upload.unsupported_file_format"
+	RecommendTagNotExist                = "error.tag.recommend_tag_not_found"
+	RecommendTagEnter                   = "error.tag.recommend_tag_enter"
+	RevisionReviewUnderway              = "error.revision.review_underway"
+	RevisionNoPermission                = "error.revision.no_permission"
+	RevisionNotFound                    = "error.revision.not_found"
+	RevisionNotSameObject               = "error.revision.not_same_object"
+	RevisionCannotRollback              = "error.revision.cannot_rollback"
+	RevisionAlreadyReviewed             = "error.revision.already_reviewed"
+	RevisionCannotReviewOwn             = "error.revision.cannot_review_own"
+	ResponseTemplateNotFound            = "error.response_template.not_found"
+	ResponseTemplateNoPermission        = "error.response_template.no_permission"
+	UserCannotUpdateYourRole            = "error.user.cannot_update_your_role"
+	TagCannotSetSynonymAsItself         = "error.tag.cannot_set_synonym_as_itself"
+	NotAllowedRegistration              = "error.user.not_allowed_registration"
+	NotAllowedLoginViaPassword          = "error.user.not_allowed_login_via_password"
+	SMTPConfigFromNameCannotBeEmail     = "error.smtp.config_from_name_cannot_be_email"
+	AdminCannotUpdateTheirPassword      = "error.admin.cannot_update_their_password"
+	AdminCannotModifySelfStatus         = "error.admin.cannot_modify_self_status"
+	UserAccessDenied                    = "error.user.access_denied"
+	UserPageAccessDenied                = "error.user.page_access_denied"
+	UserTokenInvalid                    = "error.user.token_invalid"
+	AddBulkUsersFormatError             = "error.user.add_bulk_users_format_error"
+	AddBulkUsersAmountError             = "error.user.add_bulk_users_amount_error"
+	RoleNotFound                        = "error.role.not_found"
+	RoleNameDuplicate                   = "error.role.name_duplicate"
+	RoleCannotUpdateBuiltIn             = "error.role.cannot_update_built_in"
+	RoleCannotBeScoped                  = "error.role.cannot_be_scoped"
+	RoleIsUsedCannotDelete              = "error.role.is_used_cannot_delete"
+	PowerNotFound                       = "error.power.not_found"
+	DashboardDateRangeInvalid           = "error.dashboard.date_range_invalid"
+	TwoFactorAlreadyEnabled             = "error.user.two_factor_already_enabled"
+	TwoFactorNotEnabled                 = "error.user.two_factor_not_enabled"
+	TwoFactorCodeWrong                  = "error.user.two_factor_code_wrong"
+	TwoFactorChallengeInvalid           = "error.user.two_factor_challenge_invalid"
+	TwoFactorRequired                   = "error.user.two_factor_required"
+	UserSessionNotFound                 = "error.user.session_not_found"
+	RefreshTokenInvalid                 = "error.user.refresh_token_invalid"
+	LoginExchangeCodeInvalid            = "error.user.login_exchange_code_invalid"
+	MagicLinkLoginDisabled              = "error.user.magic_link_login_disabled"
+	MagicLinkInvalid                    = "error.user.magic_link_invalid"
+	MagicLinkCodeWrong                  = "error.user.magic_link_code_wrong"
+	PasswordLoginDisabled               = "error.user.password_login_disabled"
+	UserPasswordWrong                   = "error.user.password_incorrect"
+	UserDeletionForbidden               = "error.user.deletion_forbidden"
+	UserDeletionNotFound                = "error.user.deletion_not_found"
+	UserImportFileInvalid               = "error.user.import_file_invalid"
+	UserImportTooManyUsers              = "error.user.import_too_many_users"
+	UserImportJobNotFound               = "error.user.import_job_not_found"
 )
 
 // user external login reasons
@@ -12,4 +12,9 @@ const (
 	SiteTypeTheme         = "theme"
 	SiteTypePrivileges    = "privileges"
 	SiteTypeUsers         = "users"
+	SiteTypeSMTP          = "smtp"
 )
+
+// SiteInfoConfigKeyPrefix the site settings of every type are stored as json in the config siteinfo.{type},
+// except the smtp settings which are part of the config email.config
+const SiteInfoConfigKeyPrefix = "siteinfo."
@@ -1,11 +1,17 @@
 package constant
 
+import "time"
+
 const (
 	UserNormal    = "normal"
 	UserSuspended = "suspended"
 	UserDeleted   = "deleted"
 	UserInactive  = "inactive"
 	TokenClaim    = "TokenClaim"
+	// AccessTokenHeader the header of the access token
+	AccessTokenHeader = "lawyer-token"
+	// UserDeletionGracePeriod the account is deleted after the grace period, the user can cancel the deletion before
+	UserDeletionGracePeriod = 14 * 24 * time.Hour
 )
 const (
 	EmailStatusAvailable    = 1
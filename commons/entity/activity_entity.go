@@ -22,6 +22,7 @@ type Activity struct {
 	Rank             int       `xorm:"not null default 0 INT(11) rank"`
 	HasRank          int       `xorm:"not null default 0 TINYINT(4) has_rank"`
 	RevisionID       int64     `xorm:"not null default 0 BIGINT(20) revision_id"`
+	RuleVersion      int       `xorm:"not null default 0 INT(11) rule_version"`
 }
 
 type ActivityRankSum struct {
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// AnswerDisclaimerConfigKey the config key of the answer disclaimer policy
+const AnswerDisclaimerConfigKey = "answer.disclaimer"
+
+// AnswerDisclaimerAck the disclaimer shown to the answerer when the answer is posted, kept for liability tracking
+type AnswerDisclaimerAck struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	AnswerID  string    `xorm:"not null default 0 UNIQUE BIGINT(20) answer_id"`
+	UserID    string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	// the version of the disclaimer policy when the answer is posted
+	PolicyVersion int `xorm:"not null default 0 INT(11) policy_version"`
+	// the tag of the disclaimer, 0 means the default disclaimer
+	TagID string `xorm:"not null default 0 BIGINT(20) tag_id"`
+	// the snapshot of the disclaimer content
+	Content      string `xorm:"not null MEDIUMTEXT content"`
+	Acknowledged bool   `xorm:"not null default false BOOL acknowledged"`
+}
+
+// TableName answer disclaimer ack table name
+func (AnswerDisclaimerAck) TableName() string {
+	return "answer_disclaimer_ack"
+}
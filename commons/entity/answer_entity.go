@@ -11,6 +11,9 @@ const (
 	AnswerStatusDeleted   = 10
 )
 
+// AnswerStatusPending the answer is waiting for the approval of the moderators
+const AnswerStatusPending = 11
+
 var AdminAnswerSearchStatus = map[string]int{
 	"available": AnswerStatusAvailable,
 	"deleted":   AnswerStatusDeleted,
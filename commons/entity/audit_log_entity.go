@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+const (
+	AuditActionQuestionPin          = "question.pin"
+	AuditActionQuestionUnPin        = "question.unpin"
+	AuditActionQuestionHide         = "question.hide"
+	AuditActionQuestionShow         = "question.show"
+	AuditActionQuestionUpdateStatus = "question.update_status"
+	AuditActionQuestionClose        = "question.close"
+	AuditActionQuestionReopen       = "question.reopen"
+	AuditActionQuestionDelete       = "question.delete"
+	AuditActionQuestionRecover      = "question.recover"
+	AuditActionAnswerUpdateStatus   = "answer.update_status"
+	AuditActionUserUpdateStatus     = "user.update_status"
+	AuditActionUserUpdateRole       = "user.update_role"
+	AuditActionUserUpdateRank       = "user.update_rank"
+	AuditActionReportHandle         = "report.handle"
+	AuditActionTagUpdateSynonym     = "tag.update_synonym"
+	AuditActionReputationPolicy     = "reputation.update_policy"
+	AuditActionQuestionLifecycle    = "question.update_lifecycle_policy"
+	AuditActionAnswerDisclaimer     = "answer.update_disclaimer_policy"
+	AuditActionRevisionReview       = "revision.update_review_policy"
+	AuditActionPreModeration        = "content.update_pre_moderation_policy"
+	AuditActionPendingPostReview    = "content.review_pending_post"
+	AuditActionSpamPolicy           = "content.update_spam_policy"
+	AuditActionSiteInfoUpdate       = "siteinfo.update"
+	AuditActionSiteInfoRollback     = "siteinfo.rollback"
+	AuditActionTwoFactorPolicy      = "user.update_two_factor_policy"
+	AuditActionTwoFactorReset       = "user.reset_two_factor"
+	AuditActionUserForceLogout      = "user.force_logout"
+	AuditActionUserDataDelete       = "user.delete_data"
+	AuditActionUserImport           = "user.import"
+)
+
+// AuditLog the record of the admin and moderator actions, it can only be appended
+type AuditLog struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created INDEX TIMESTAMP created_at"`
+	UserID     string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	Action     string    `xorm:"not null default '' INDEX VARCHAR(64) action"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(32) object_type"`
+	ObjectID   string    `xorm:"not null default 0 INDEX BIGINT(20) object_id"`
+	Before     string    `xorm:"TEXT before_snapshot"`
+	After      string    `xorm:"TEXT after_snapshot"`
+	IP         string    `xorm:"not null default '' VARCHAR(64) ip"`
+	TraceID    string    `xorm:"not null default '' VARCHAR(64) trace_id"`
+}
+
+// TableName audit log table name
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
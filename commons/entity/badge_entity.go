@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+const (
+	BadgeLevelBronze = 1
+	BadgeLevelSilver = 2
+	BadgeLevelGold   = 3
+
+	BadgeStatusEnabled  = 1
+	BadgeStatusDisabled = 2
+
+	// BadgeRuleAcceptedAnswers the number of the accepted answers of the user
+	BadgeRuleAcceptedAnswers = "accepted_answers"
+	// BadgeRuleUpvotes the number of the upvotes the questions and answers of the user received
+	BadgeRuleUpvotes = "upvotes"
+	// BadgeRuleHelpfulAnswers the number of the answers of the user which are accepted or upvoted
+	BadgeRuleHelpfulAnswers = "helpful_answers"
+	// BadgeRuleAnswerStreak the number of the consecutive days the user answered
+	BadgeRuleAnswerStreak = "answer_streak"
+)
+
+// Badge the badge and the rule to award it
+type Badge struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Name        string    `xorm:"not null default '' VARCHAR(50) name"`
+	Description string    `xorm:"not null default '' VARCHAR(255) description"`
+	Icon        string    `xorm:"not null default '' VARCHAR(1024) icon"`
+	Level       int       `xorm:"not null default 1 TINYINT(4) level"`
+	RuleType    string    `xorm:"not null default '' VARCHAR(32) rule_type"`
+	Threshold   int       `xorm:"not null default 1 INT(11) threshold"`
+	// count only in this tag, 0 means all tags
+	TagID string `xorm:"not null default 0 BIGINT(20) tag_id"`
+	// count and award the badge for each tag separately, such as 100 upvotes in a tag
+	PerTag bool `xorm:"not null default false BOOL per_tag"`
+	Status int  `xorm:"not null default 1 TINYINT(4) status"`
+}
+
+// TableName badge table name
+func (Badge) TableName() string {
+	return "badge"
+}
+
+// BadgeAward the badge awarded to the user
+type BadgeAward struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 UNIQUE(s) BIGINT(20) user_id"`
+	BadgeID   int       `xorm:"not null default 0 UNIQUE(s) INDEX INT(11) badge_id"`
+	// the tag the badge is awarded for if the badge is per tag, or 0
+	TagID string `xorm:"not null default 0 UNIQUE(s) BIGINT(20) tag_id"`
+	// the object which made the user reach the rule
+	ObjectID string `xorm:"not null default 0 BIGINT(20) object_id"`
+}
+
+// TableName badge award table name
+func (BadgeAward) TableName() string {
+	return "badge_award"
+}
@@ -13,6 +13,8 @@ const (
 	CaptchaActionReport           = "report"
 	CaptchaActionDelete           = "delete"
 	CaptchaActionVote             = "vote"
+	CaptchaActionTwoFactor        = "two_factor"
+	CaptchaActionMagicLink        = "magic_link"
 )
 
 type ActionRecordInfo struct {
@@ -13,6 +13,9 @@ const (
 	CommentStatusDeleted   = 10
 )
 
+// CommentStatusPending the comment is held for the approval of the moderators
+const CommentStatusPending = 11
+
 // Comment comment
 type Comment struct {
 	ID             string        `xorm:"not null pk autoincr BIGINT(20) id"`
@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// DashboardDailyStat the site statistics of one day, it is rolled up by the cron
+type DashboardDailyStat struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	// date format is 2006-01-02
+	Date          string `xorm:"not null default '' UNIQUE VARCHAR(10) date"`
+	QuestionCount int64  `xorm:"not null default 0 BIGINT(20) question_count"`
+	AnswerCount   int64  `xorm:"not null default 0 BIGINT(20) answer_count"`
+	UserCount     int64  `xorm:"not null default 0 BIGINT(20) user_count"`
+	// the questions asked on this day which have been answered or accepted
+	AnsweredQuestionCount int64 `xorm:"not null default 0 BIGINT(20) answered_question_count"`
+	AcceptedQuestionCount int64 `xorm:"not null default 0 BIGINT(20) accepted_question_count"`
+	// the median seconds from the question asked on this day to its first answer
+	MedianFirstAnswerSeconds int64 `xorm:"not null default 0 BIGINT(20) median_first_answer_seconds"`
+}
+
+// TableName dashboard daily stat table name
+func (DashboardDailyStat) TableName() string {
+	return "dashboard_daily_stat"
+}
+
+// DashboardDailyTagStat the activity of the tag in one day
+type DashboardDailyTagStat struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	Date          string    `xorm:"not null default '' UNIQUE(s) VARCHAR(10) date"`
+	TagID         string    `xorm:"not null default 0 UNIQUE(s) BIGINT(20) tag_id"`
+	QuestionCount int64     `xorm:"not null default 0 BIGINT(20) question_count"`
+	AnswerCount   int64     `xorm:"not null default 0 BIGINT(20) answer_count"`
+}
+
+// TableName dashboard daily tag stat table name
+func (DashboardDailyTagStat) TableName() string {
+	return "dashboard_daily_tag_stat"
+}
+
+// DashboardDailyUserStat the contribution of the user in one day
+type DashboardDailyUserStat struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	Date          string    `xorm:"not null default '' UNIQUE(s) VARCHAR(10) date"`
+	UserID        string    `xorm:"not null default 0 UNIQUE(s) BIGINT(20) user_id"`
+	QuestionCount int64     `xorm:"not null default 0 BIGINT(20) question_count"`
+	AnswerCount   int64     `xorm:"not null default 0 BIGINT(20) answer_count"`
+	AcceptedCount int64     `xorm:"not null default 0 BIGINT(20) accepted_count"`
+}
+
+// TableName dashboard daily user stat table name
+func (DashboardDailyUserStat) TableName() string {
+	return "dashboard_daily_user_stat"
+}
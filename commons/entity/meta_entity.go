@@ -7,6 +7,10 @@ const (
 	QuestionCloseReasonKey = "question.close.reason"
 	AnswerEditSummaryKey   = "answer.edit.summary"
 	TagEditSummaryKey      = "tag.edit.summary"
+	// QuestionAcceptRemindedKey the time the asker is reminded to accept an answer
+	QuestionAcceptRemindedKey = "question.lifecycle.accept_reminded"
+	// QuestionBumpedKey the time the unanswered question is bumped
+	QuestionBumpedKey = "question.lifecycle.bumped"
 )
 
 // Meta meta
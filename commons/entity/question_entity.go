@@ -14,6 +14,16 @@ const (
 	QuestionHide            = 2
 )
 
+// QuestionStatusPending the question is waiting for the approval of the moderators, it is greater than deleted
+// so that it is excluded from the lists and the search like the deleted questions
+const QuestionStatusPending = 11
+
+// QuestionLifecycleConfigKey the config key of the question lifecycle rules
+const QuestionLifecycleConfigKey = "question.lifecycle"
+
+// PreModerationConfigKey the config key of the pre-moderation rules of the questions and answers
+const PreModerationConfigKey = "content.pre_moderation"
+
 var AdminQuestionSearchStatus = map[string]int{
 	"available": QuestionStatusAvailable,
 	"closed":    QuestionStatusClosed,
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// QuestionDailyViewStat the views of the question in one day, it is flushed from the cache by the cron
+type QuestionDailyViewStat struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	// date format is 2006-01-02
+	Date            string `xorm:"not null default '' UNIQUE(s) VARCHAR(10) date"`
+	QuestionID      string `xorm:"not null default 0 UNIQUE(s) BIGINT(20) question_id"`
+	ViewCount       int64  `xorm:"not null default 0 BIGINT(20) view_count"`
+	UniqueViewCount int64  `xorm:"not null default 0 BIGINT(20) unique_view_count"`
+}
+
+// TableName question daily view stat table name
+func (QuestionDailyViewStat) TableName() string {
+	return "question_daily_view_stat"
+}
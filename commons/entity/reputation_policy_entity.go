@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// ReputationPolicyConfigKey the config key of the current reputation policy
+const ReputationPolicyConfigKey = "reputation.policy"
+
+// ReputationPolicy the saved versions of the reputation policy, the current one is also stored in config
+type ReputationPolicy struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	Version   int       `xorm:"not null default 0 UNIQUE INT(11) version"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Content   string    `xorm:"not null MEDIUMTEXT content"`
+}
+
+// TableName reputation policy table name
+func (ReputationPolicy) TableName() string {
+	return "reputation_policy"
+}
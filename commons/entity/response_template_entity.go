@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+const (
+	ResponseTemplateStatusAvailable = 1
+	ResponseTemplateStatusDeleted   = 10
+
+	// ResponseTemplateScopeAnswer the template is used when answering
+	ResponseTemplateScopeAnswer = "answer"
+	// ResponseTemplateScopeComment the template is used when commenting, such as the close or moderation text
+	ResponseTemplateScopeComment = "comment"
+)
+
+// ResponseTemplate the canned response which can be inserted when answering or commenting
+type ResponseTemplate struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	// the owner of the template, 0 means the template is site-wide
+	UserID  string `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	Title   string `xorm:"not null default '' VARCHAR(100) title"`
+	Content string `xorm:"not null MEDIUMTEXT content"`
+	// answer or comment, empty means both
+	Scope    string `xorm:"not null default '' VARCHAR(32) scope"`
+	UseCount int    `xorm:"not null default 0 INT(11) use_count"`
+	Status   int    `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName response template table name
+func (ResponseTemplate) TableName() string {
+	return "response_template"
+}
+
+// ResponseTemplateUse the record of the template used by the answer or the comment
+type ResponseTemplateUse struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	TemplateID int64     `xorm:"not null default 0 INDEX BIGINT(20) template_id"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	ObjectID   string    `xorm:"not null default 0 INDEX BIGINT(20) object_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(32) object_type"`
+}
+
+// TableName response template use table name
+func (ResponseTemplateUse) TableName() string {
+	return "response_template_use"
+}
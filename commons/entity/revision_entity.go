@@ -13,8 +13,13 @@ const (
 	RevisionReviewPassStatus = 2
 	// RevisionReviewRejectStatus this revision is reviewed and rejected by operator
 	RevisionReviewRejectStatus = 3
+	// RevisionReviewExpiredStatus this revision is not decided by the reviewers in time
+	RevisionReviewExpiredStatus = 4
 )
 
+// RevisionReviewConfigKey the config key of the suggested edits review rules
+const RevisionReviewConfigKey = "revision.review"
+
 // Revision revision
 type Revision struct {
 	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
@@ -34,3 +39,19 @@ type Revision struct {
 func (Revision) TableName() string {
 	return "revision"
 }
+
+// RevisionReview the vote of the reviewer on the unreviewed revision
+type RevisionReview struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	RevisionID string    `xorm:"not null default 0 UNIQUE(revision_user) BIGINT(20) revision_id"`
+	UserID     string    `xorm:"not null default 0 UNIQUE(revision_user) INDEX BIGINT(20) user_id"`
+	// approve or reject
+	Operation string `xorm:"not null default '' VARCHAR(16) operation"`
+	Comment   string `xorm:"not null default '' VARCHAR(500) comment"`
+}
+
+// TableName revision review table name
+func (RevisionReview) TableName() string {
+	return "revision_review"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// SiteInfoHistory the saved versions of the site settings, every change of a setting type adds a new version
+type SiteInfoHistory struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	SiteType  string    `xorm:"not null default '' UNIQUE(type_version) VARCHAR(64) site_type"`
+	Version   int       `xorm:"not null default 0 UNIQUE(type_version) INT(11) version"`
+	// the settings of this version as json
+	Content string `xorm:"not null MEDIUMTEXT content"`
+	// the admin who saved this version, 0 means the settings before the history was recorded
+	UserID string `xorm:"not null default 0 BIGINT(20) user_id"`
+}
+
+// TableName site info history table name
+func (SiteInfoHistory) TableName() string {
+	return "site_info_history"
+}
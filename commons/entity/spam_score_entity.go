@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// SpamPolicyConfigKey the config key of the spam scoring rules
+const SpamPolicyConfigKey = "content.spam_policy"
+
+// SpamHoldScoreConfigKey the config key of the hold score, it is kept apart from the policy because the review
+// decisions adjust it
+const SpamHoldScoreConfigKey = "content.spam_hold_score"
+
+// SpamScore the spam score of the question, answer or comment when it is posted
+type SpamScore struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	ObjectID   string    `xorm:"not null default 0 UNIQUE BIGINT(20) object_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(32) object_type"`
+	UserID     string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	// the md5 of the normalized content, used to find the repeated content
+	ContentHash string `xorm:"not null default '' INDEX VARCHAR(32) content_hash"`
+	Score       int    `xorm:"not null default 0 INT(11) score"`
+	// the score of every signal as json
+	Signals string `xorm:"not null TEXT signals"`
+	// the post is held for review because the score reaches the hold score
+	Held bool `xorm:"not null default false BOOL held"`
+	// approve or reject, empty if the held post is not reviewed
+	Decision string `xorm:"not null default '' VARCHAR(16) decision"`
+}
+
+// TableName spam score table name
+func (SpamScore) TableName() string {
+	return "spam_score"
+}
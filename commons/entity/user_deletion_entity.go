@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+const (
+	// UserDeletionStatusPending the account is deleted when the grace period ends, the user can cancel it before
+	UserDeletionStatusPending = 1
+	// UserDeletionStatusCanceled the user canceled the deletion in the grace period
+	UserDeletionStatusCanceled = 2
+	// UserDeletionStatusCompleted the personal data of the user is erased and the content is anonymized
+	UserDeletionStatusCompleted = 3
+)
+
+// UserDeletion the account deletion requested by the user
+type UserDeletion struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID      string    `xorm:"not null default 0 UNIQUE BIGINT(20) user_id"`
+	Status      int       `xorm:"not null default 1 INT(11) status"`
+	ScheduledAt time.Time `xorm:"not null INDEX TIMESTAMP scheduled_at"`
+}
+
+// TableName user deletion table name
+func (UserDeletion) TableName() string {
+	return "user_deletion"
+}
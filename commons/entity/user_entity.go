@@ -34,6 +34,7 @@ type User struct {
 	AnswerCount    int       `xorm:"not null default 0 INT(11) answer_count"`
 	QuestionCount  int       `xorm:"not null default 0 INT(11) question_count"`
 	Rank           int       `xorm:"not null default 0 INT(11) rank"`
+	BadgeCount     int       `xorm:"not null default 0 INT(11) badge_count"`
 	Status         int       `xorm:"not null default 1 INT(11) status"`
 	AuthorityGroup int       `xorm:"not null default 1 INT(11) authority_group"`
 	DisplayName    string    `xorm:"not null default '' VARCHAR(30) display_name"`
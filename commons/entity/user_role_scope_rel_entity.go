@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const (
+	// UserRoleScopeTypeTag the role only takes effect on the questions with the tag
+	UserRoleScopeTypeTag = "tag"
+)
+
+// UserRoleScopeRel user role relation limited to a scope
+type UserRoleScopeRel struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) user_id"`
+	RoleID    int       `xorm:"not null default 0 INT(11) role_id"`
+	ScopeType string    `xorm:"not null default '' VARCHAR(32) UNIQUE(s) scope_type"`
+	ScopeID   string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) INDEX scope_id"`
+}
+
+// TableName user role scope rel table name
+func (UserRoleScopeRel) TableName() string {
+	return "user_role_scope_rel"
+}
@@ -0,0 +1,17 @@
+package entity
+
+// UserSession the login session of a device, it is kept in the cache until it is revoked or the refresh token expires
+type UserSession struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	// Device the browser and the os parsed from the user agent
+	Device    string `json:"device"`
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+	// RefreshTokenHash the hash of the current refresh token
+	RefreshTokenHash string `json:"refresh_token_hash"`
+	// PrevRefreshTokenHash the hash of the rotated refresh token, reusing it means the token is leaked
+	PrevRefreshTokenHash string `json:"prev_refresh_token_hash"`
+	CreatedAt            int64  `json:"created_at"`
+	LastSeenAt           int64  `json:"last_seen_at"`
+}
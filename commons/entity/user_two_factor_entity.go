@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// TwoFactorPolicyConfigKey the config key of the two-factor authentication policy
+const TwoFactorPolicyConfigKey = "user.two_factor_policy"
+
+const (
+	// TwoFactorStatusPending the secret is generated but not confirmed by a code yet
+	TwoFactorStatusPending = 1
+	// TwoFactorStatusEnabled the code is required when the user login
+	TwoFactorStatusEnabled = 2
+)
+
+// UserTwoFactor the TOTP secret and the recovery codes of the user
+type UserTwoFactor struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 UNIQUE BIGINT(20) user_id"`
+	Secret    string    `xorm:"not null default '' VARCHAR(64) secret"`
+	Status    int       `xorm:"not null default 1 INT(11) status"`
+	// the sha256 hashes of the unused recovery codes as json
+	RecoveryCodes string `xorm:"not null TEXT recovery_codes"`
+	// the time step of the last accepted code, the codes of this step or before are rejected
+	LastUsedStep int64 `xorm:"not null default 0 BIGINT(20) last_used_step"`
+}
+
+// TableName user two factor table name
+func (UserTwoFactor) TableName() string {
+	return "user_two_factor"
+}
+
+// TwoFactorChallenge the pending login which is waiting for the two-factor verification, it is kept in the cache
+type TwoFactorChallenge struct {
+	UserID string `json:"user_id"`
+}
@@ -13,6 +13,8 @@ type ActivityMsg struct {
 	ActivityTypeKey  constant.ActivityTypeKey
 	RevisionID       string
 	ExtraInfo        map[string]string
+	// the activity has been saved by the sender, such as votes and accepts, so it is only for the other handlers
+	HasRecorded bool
 }
 
 // GetObjectTimelineReq get object timeline request
@@ -19,6 +19,8 @@ type AcceptAnswerActivity struct {
 	TriggerUserID    string
 	OriginalObjectID string
 	Rank             int
+	// the version of the reputation policy which the rank is calculated by
+	RuleVersion int
 }
 
 func (v *AcceptAnswerActivity) HasRank() int {
@@ -0,0 +1,82 @@
+package schema
+
+// AnswerDisclaimer the disclaimer block shown below the answers, content is markdown
+type AnswerDisclaimer struct {
+	Title   string `validate:"omitempty,lte=100" json:"title"`
+	Content string `validate:"required,notblank,lte=5000" json:"content"`
+}
+
+// AnswerDisclaimerPolicy the answer disclaimers, stored as json in the config answer.disclaimer
+type AnswerDisclaimerPolicy struct {
+	// increased every time the policy is updated, recorded with the acknowledgement
+	Version int  `json:"version"`
+	Enabled bool `json:"enabled"`
+	// the answer can not be posted without acknowledging the disclaimer
+	RequireAcknowledgement bool `json:"require_acknowledgement"`
+	// the disclaimer of the questions without tag disclaimer
+	Default *AnswerDisclaimer `json:"default"`
+	// tag id to the disclaimer, if the question has many tags with disclaimer, the disclaimer of the first tag is used
+	Tags map[string]*AnswerDisclaimer `json:"tags"`
+}
+
+// GetDisclaimer get the disclaimer of the question with the tags, tag id 0 means the default disclaimer,
+// nil if the disclaimers are disabled or there is no disclaimer for the question
+func (p *AnswerDisclaimerPolicy) GetDisclaimer(tagIDs []string) (disclaimer *AnswerDisclaimer, tagID string) {
+	if !p.Enabled {
+		return nil, ""
+	}
+	for _, tagID := range tagIDs {
+		if disclaimer, ok := p.Tags[tagID]; ok && disclaimer != nil {
+			return disclaimer, tagID
+		}
+	}
+	if p.Default == nil || len(p.Default.Content) == 0 {
+		return nil, ""
+	}
+	return p.Default, "0"
+}
+
+// UpdateAnswerDisclaimerPolicyReq update answer disclaimer policy request
+type UpdateAnswerDisclaimerPolicyReq struct {
+	Enabled                bool                         `json:"enabled"`
+	RequireAcknowledgement bool                         `json:"require_acknowledgement"`
+	Default                *AnswerDisclaimer            `validate:"omitempty" json:"default"`
+	Tags                   map[string]*AnswerDisclaimer `validate:"omitempty,dive,keys,required,endkeys,required" json:"tags"`
+	UserID                 string                       `json:"-"`
+}
+
+// AnswerDisclaimerInfo the disclaimer block rendered in the answer
+type AnswerDisclaimerInfo struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	HTML    string `json:"html"`
+	Version int    `json:"version"`
+}
+
+// GetAnswerDisclaimerReq get the disclaimer before answering the question
+type GetAnswerDisclaimerReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// GetAnswerDisclaimerResp get answer disclaimer response
+type GetAnswerDisclaimerResp struct {
+	// nil if there is no disclaimer for the question
+	Disclaimer             *AnswerDisclaimerInfo `json:"disclaimer"`
+	RequireAcknowledgement bool                  `json:"require_acknowledgement"`
+}
+
+// GetAnswerDisclaimerAckReq get the disclaimer acknowledgement of the answer
+type GetAnswerDisclaimerAckReq struct {
+	AnswerID string `validate:"required" form:"answer_id"`
+}
+
+// GetAnswerDisclaimerAckResp get answer disclaimer acknowledgement response
+type GetAnswerDisclaimerAckResp struct {
+	AnswerID      string         `json:"answer_id"`
+	UserInfo      *UserBasicInfo `json:"user_info"`
+	PolicyVersion int            `json:"policy_version"`
+	TagID         string         `json:"tag_id"`
+	Content       string         `json:"content"`
+	Acknowledged  bool           `json:"acknowledged"`
+	CreatedAt     int64          `json:"created_at"`
+}
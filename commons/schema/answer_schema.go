@@ -35,6 +35,10 @@ type AnswerAddReq struct {
 	CanRecover  bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	// the response template used to write the answer
+	TemplateID int64 `validate:"omitempty" json:"template_id"`
+	// the answerer checked the disclaimer of the question
+	DisclaimerAcknowledged bool `json:"disclaimer_acknowledged"`
 }
 
 func (req *AnswerAddReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -95,6 +99,8 @@ type AnswerInfo struct {
 	VoteCount      int            `json:"vote_count"`
 	QuestionInfo   *QuestionInfo  `json:"question_info,omitempty"`
 	Status         int            `json:"status"`
+	// the disclaimer of the question, nil if there is no disclaimer
+	Disclaimer *AnswerDisclaimerInfo `json:"disclaimer,omitempty"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
@@ -0,0 +1,88 @@
+package schema
+
+// AuditLogMsg the admin or moderator action to be recorded
+type AuditLogMsg struct {
+	// the user who did the action
+	UserID     string
+	Action     string
+	ObjectType string
+	ObjectID   string
+	// the snapshot of the object before and after the action, they will be marshaled into json
+	Before interface{}
+	After  interface{}
+}
+
+// GetAuditLogPageReq get audit log page request
+type GetAuditLogPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// the user who did the action
+	UserID string `validate:"omitempty" form:"user_id"`
+	// action, such as question.pin, user.update_role
+	Action string `validate:"omitempty,lte=64" form:"action"`
+	// object type, such as question, answer, user
+	ObjectType string `validate:"omitempty,lte=32" form:"object_type"`
+	// object id
+	ObjectID string `validate:"omitempty" form:"object_id"`
+	// start time, unix timestamp
+	StartTime int64 `validate:"omitempty,min=0" form:"start_time"`
+	// end time, unix timestamp
+	EndTime int64 `validate:"omitempty,min=0" form:"end_time"`
+}
+
+// GetAuditLogResp get audit log response
+type GetAuditLogResp struct {
+	ID         int64          `json:"id"`
+	CreatedAt  int64          `json:"created_at"`
+	UserInfo   *UserBasicInfo `json:"user_info"`
+	Action     string         `json:"action"`
+	ObjectType string         `json:"object_type"`
+	ObjectID   string         `json:"object_id"`
+	Before     string         `json:"before"`
+	After      string         `json:"after"`
+	IP         string         `json:"ip"`
+	TraceID    string         `json:"trace_id"`
+}
+
+// QuestionOperationSnapshot the pin and show status of the question
+type QuestionOperationSnapshot struct {
+	Pin  int `json:"pin"`
+	Show int `json:"show"`
+}
+
+// ObjectStatusSnapshot the status of the question or answer
+type ObjectStatusSnapshot struct {
+	Status int `json:"status"`
+}
+
+// UserStatusSnapshot the status of the user
+type UserStatusSnapshot struct {
+	Status           int  `json:"status"`
+	MailStatus       int  `json:"mail_status"`
+	RemoveAllContent bool `json:"remove_all_content,omitempty"`
+}
+
+// UserRoleSnapshot the role of the user
+type UserRoleSnapshot struct {
+	RoleID int `json:"role_id"`
+}
+
+// UserRankSnapshot the rank of the user
+type UserRankSnapshot struct {
+	Rank int `json:"rank"`
+}
+
+// ReportHandleSnapshot the status of the report
+type ReportHandleSnapshot struct {
+	Status         int    `json:"status"`
+	ObjectID       string `json:"object_id"`
+	FlaggedType    int    `json:"flagged_type,omitempty"`
+	FlaggedContent string `json:"flagged_content,omitempty"`
+}
+
+// TagSynonymSnapshot the synonyms of the tag
+type TagSynonymSnapshot struct {
+	Synonyms []string `json:"synonyms"`
+}
@@ -66,6 +66,10 @@ type GetUserPageResp struct {
 	RoleID int `json:"role_id"`
 	// role name
 	RoleName string `json:"role_name"`
+	// location
+	Location string `json:"location"`
+	// website
+	Website string `json:"website"`
 }
 
 // GetUserInfoReq get user request
@@ -0,0 +1,68 @@
+package schema
+
+// AddBadgeReq add badge request
+type AddBadgeReq struct {
+	Name        string `validate:"required,gt=0,lte=50" json:"name"`
+	Description string `validate:"omitempty,lte=255" json:"description"`
+	Icon        string `validate:"omitempty,lte=1024" json:"icon"`
+	// level 1 bronze 2 silver 3 gold
+	Level int `validate:"required,oneof=1 2 3" json:"level"`
+	// rule type, accepted_answers upvotes helpful_answers answer_streak
+	RuleType string `validate:"required,oneof=accepted_answers upvotes helpful_answers answer_streak" json:"rule_type"`
+	// the number the user needs to reach
+	Threshold int `validate:"required,min=1" json:"threshold"`
+	// count only in this tag, empty means all tags
+	TagID string `validate:"omitempty" json:"tag_id"`
+	// count and award the badge for each tag separately
+	PerTag bool `json:"per_tag"`
+}
+
+// UpdateBadgeReq update badge request
+type UpdateBadgeReq struct {
+	ID int `validate:"required" json:"id"`
+	AddBadgeReq
+	// status 1 enabled 2 disabled
+	Status int `validate:"required,oneof=1 2" json:"status"`
+}
+
+// GetBadgeListReq get badge list request
+type GetBadgeListReq struct {
+	// status 1 enabled 2 disabled, empty means all
+	Status int `validate:"omitempty,oneof=1 2" form:"status"`
+}
+
+// GetBadgeResp get badge response
+type GetBadgeResp struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Level       int    `json:"level"`
+	RuleType    string `json:"rule_type"`
+	Threshold   int    `json:"threshold"`
+	TagID       string `json:"tag_id"`
+	PerTag      bool   `json:"per_tag"`
+	Status      int    `json:"status"`
+	// how many times the badge is awarded
+	AwardCount int64 `json:"award_count"`
+}
+
+// GetUserBadgeReq get user badge request
+type GetUserBadgeReq struct {
+	Username string `validate:"required,gt=0,lte=100" form:"username"`
+}
+
+// GetUserBadgeResp the badge awarded to the user
+type GetUserBadgeResp struct {
+	BadgeID     int    `json:"badge_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Level       int    `json:"level"`
+	// the tag the badge is awarded for if the badge is per tag
+	TagID   string `json:"tag_id,omitempty"`
+	TagName string `json:"tag_name,omitempty"`
+	// the object which made the user earn the badge
+	ObjectID  string `json:"object_id"`
+	CreatedAt int64  `json:"created_at"`
+}
@@ -29,6 +29,8 @@ type AddCommentReq struct {
 	CanDelete   bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
+	// the response template used to write the comment
+	TemplateID int64 `validate:"omitempty" json:"template_id"`
 }
 
 func (req *AddCommentReq) Check() (errFields []*validator.FormErrorField, err error) {
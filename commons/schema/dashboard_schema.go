@@ -41,3 +41,46 @@ type RemoteVersion struct {
 		URL     string `json:"url"`
 	} `json:"release"`
 }
+
+// GetDashboardStatReq get dashboard statistics request, both dates are included
+type GetDashboardStatReq struct {
+	StartDate string `validate:"required,datetime=2006-01-02" form:"start_date" json:"start_date"`
+	EndDate   string `validate:"required,datetime=2006-01-02" form:"end_date" json:"end_date"`
+}
+
+// GetDashboardStatResp get dashboard statistics response
+type GetDashboardStatResp struct {
+	Days         []*DashboardDailyStat       `json:"days"`
+	Tags         []*DashboardTagStat         `json:"tags"`
+	Contributors []*DashboardContributorStat `json:"contributors"`
+}
+
+// DashboardDailyStat the statistics of one day
+type DashboardDailyStat struct {
+	Date          string `json:"date"`
+	QuestionCount int64  `json:"question_count"`
+	AnswerCount   int64  `json:"answer_count"`
+	UserCount     int64  `json:"user_count"`
+	// the rate of the questions asked on this day which have been answered
+	AnswerRate float64 `json:"answer_rate"`
+	// the rate of the questions asked on this day which have accepted answer
+	AcceptanceRate           float64 `json:"acceptance_rate"`
+	MedianFirstAnswerSeconds int64   `json:"median_first_answer_seconds"`
+}
+
+// DashboardTagStat the activity of the tag
+type DashboardTagStat struct {
+	TagID         string `json:"tag_id"`
+	SlugName      string `json:"slug_name"`
+	DisplayName   string `json:"display_name"`
+	QuestionCount int64  `json:"question_count"`
+	AnswerCount   int64  `json:"answer_count"`
+}
+
+// DashboardContributorStat the contribution of the user
+type DashboardContributorStat struct {
+	UserInfo      *UserBasicInfo `json:"user_info"`
+	QuestionCount int64          `json:"question_count"`
+	AnswerCount   int64          `json:"answer_count"`
+	AcceptedCount int64          `json:"accepted_count"`
+}
@@ -53,6 +53,13 @@ type TestTemplateData struct {
 	SiteName string
 }
 
+type MagicLinkTemplateData struct {
+	SiteName      string
+	MagicLinkUrl  string
+	Code          string
+	ExpireMinutes int
+}
+
 type NewAnswerTemplateRawData struct {
 	AnswerUserDisplayName string
 	QuestionTitle         string
@@ -0,0 +1,42 @@
+package schema
+
+// PreModerationPolicy the rules of the pre-moderation, stored as json in the config content.pre_moderation
+type PreModerationPolicy struct {
+	Enabled bool `json:"enabled"`
+	// the first N questions and answers of the user wait for the approval of the moderators
+	FirstPosts int `validate:"min=0,max=100" json:"first_posts"`
+	// only the users with rank lower than it are pre-moderated, 0 means all users
+	MaxRank int `validate:"min=0" json:"max_rank"`
+}
+
+// UpdatePreModerationPolicyReq update pre-moderation policy request
+type UpdatePreModerationPolicyReq struct {
+	PreModerationPolicy
+	UserID string `json:"-"`
+}
+
+// GetPendingPostPageReq get the pending questions, answers or comments
+type GetPendingPostPageReq struct {
+	ObjectType string `validate:"required,oneof=question answer comment" form:"object_type"`
+	Page       int    `validate:"omitempty,min=1" form:"page"`
+	PageSize   int    `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// PendingPostInfo the pending question, answer or comment
+type PendingPostInfo struct {
+	ObjectID   string         `json:"object_id"`
+	ObjectType string         `json:"object_type"`
+	QuestionID string         `json:"question_id"`
+	Title      string         `json:"title"`
+	Content    string         `json:"content"`
+	HTML       string         `json:"html"`
+	UserInfo   *UserBasicInfo `json:"user_info"`
+	CreatedAt  int64          `json:"created_at"`
+}
+
+// ReviewPendingPostReq approve or reject the pending question, answer or comment
+type ReviewPendingPostReq struct {
+	ObjectID  string `validate:"required" json:"object_id"`
+	Operation string `validate:"required,oneof=approve reject" json:"operation"`
+	UserID    string `json:"-"`
+}
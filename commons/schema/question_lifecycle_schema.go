@@ -0,0 +1,62 @@
+package schema
+
+// QuestionLifecycleRule the lifecycle rule of the open questions, 0 days means the step is disabled
+type QuestionLifecycleRule struct {
+	// remind the asker to accept an answer if the question has answers but none is accepted after N days
+	AcceptReminderDays int `validate:"min=0" json:"accept_reminder_days"`
+	// bump the question and notify the followers of its tags if it is not answered after N days
+	UnansweredBumpDays int `validate:"min=0" json:"unanswered_bump_days"`
+	// close the question if it is not answered and not updated for N days
+	AutoCloseDays int `validate:"min=0" json:"auto_close_days"`
+	// the reason type of the auto close, one of the question.close.reasons
+	CloseReasonType int `validate:"omitempty" json:"close_reason_type"`
+	// the message of the auto close
+	CloseMsg string `validate:"omitempty,lte=500" json:"close_msg"`
+}
+
+// QuestionLifecyclePolicy the question lifecycle rules, stored as json in the config question.lifecycle
+type QuestionLifecyclePolicy struct {
+	// the rule of the questions without tag rule
+	Default *QuestionLifecycleRule `json:"default"`
+	// tag id to the rule, if the question has many tags with rule, the rule of the first tag is used
+	Tags map[string]*QuestionLifecycleRule `json:"tags"`
+}
+
+// GetRule get the rule of the question with the tags
+func (p *QuestionLifecyclePolicy) GetRule(tagIDs []string) *QuestionLifecycleRule {
+	for _, tagID := range tagIDs {
+		if rule, ok := p.Tags[tagID]; ok && rule != nil {
+			return rule
+		}
+	}
+	if p.Default == nil {
+		return &QuestionLifecycleRule{}
+	}
+	return p.Default
+}
+
+// MinDays the least days of all enabled steps, the questions created after it need not be checked
+func (p *QuestionLifecyclePolicy) MinDays() (days int) {
+	rules := []*QuestionLifecycleRule{p.Default}
+	for _, rule := range p.Tags {
+		rules = append(rules, rule)
+	}
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		for _, d := range []int{rule.AcceptReminderDays, rule.UnansweredBumpDays, rule.AutoCloseDays} {
+			if d > 0 && (days == 0 || d < days) {
+				days = d
+			}
+		}
+	}
+	return days
+}
+
+// UpdateQuestionLifecyclePolicyReq update question lifecycle policy request
+type UpdateQuestionLifecyclePolicyReq struct {
+	Default *QuestionLifecycleRule            `validate:"required" json:"default"`
+	Tags    map[string]*QuestionLifecycleRule `validate:"omitempty,dive,keys,required,endkeys,required" json:"tags"`
+	UserID  string                            `json:"-"`
+}
@@ -0,0 +1,23 @@
+package schema
+
+// AddQuestionViewReq the view of the question, the visitor is the login user or the ip of the guest
+type AddQuestionViewReq struct {
+	QuestionID string
+	UserID     string
+	IP         string
+	UserAgent  string
+}
+
+// GetQuestionViewTrendReq get question view trend request, both dates are included
+type GetQuestionViewTrendReq struct {
+	QuestionID string `validate:"required" form:"question_id" json:"question_id"`
+	StartDate  string `validate:"required,datetime=2006-01-02" form:"start_date" json:"start_date"`
+	EndDate    string `validate:"required,datetime=2006-01-02" form:"end_date" json:"end_date"`
+}
+
+// QuestionDailyView the views of the question in one day
+type QuestionDailyView struct {
+	Date            string `json:"date"`
+	ViewCount       int64  `json:"view_count"`
+	UniqueViewCount int64  `json:"unique_view_count"`
+}
@@ -46,6 +46,7 @@ type ReportHandleReq struct {
 	ID             string `validate:"required" comment:"report id" form:"id" json:"id"`
 	FlaggedType    int    `validate:"required" comment:"flagged type" form:"flagged_type" json:"flagged_type"`
 	FlaggedContent string `validate:"omitempty" comment:"flagged content" form:"flagged_content" json:"flagged_content"`
+	UserID         string `json:"-"`
 }
 
 // GetReportListPageDTO report list data transfer object
@@ -54,6 +55,9 @@ type GetReportListPageDTO struct {
 	Status     string
 	Page       int
 	PageSize   int
+	UserID     string
+	// only the reports of the questions with these tags will be listed if ScopeTagIDs is not nil
+	ScopeTagIDs []string
 }
 
 // GetReportListPageResp get report list
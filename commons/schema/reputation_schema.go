@@ -0,0 +1,152 @@
+package schema
+
+import "math"
+
+const (
+	// ReputationLedgerNoteDailyLimit the reputation is not earned because the daily limit is reached
+	ReputationLedgerNoteDailyLimit = "daily_limit_reached"
+	// ReputationLedgerNoteMinRank the reputation is reduced less because the rank can not be lower than the min rank
+	ReputationLedgerNoteMinRank = "min_rank_reached"
+)
+
+// GetReputationLedgerReq get reputation ledger request
+type GetReputationLedgerReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// user id
+	UserID string `validate:"required" form:"user_id"`
+}
+
+// GetReputationLedgerResp get reputation ledger response
+type GetReputationLedgerResp struct {
+	UserInfo *UserBasicInfo `json:"user_info"`
+	// the rank stored in the user table
+	StoredRank int `json:"stored_rank"`
+	// the rank before the first activity, the users added by the admin start from 1
+	InitialRank int `json:"initial_rank"`
+	// the rank replayed from the activities with the current rank rules
+	CalculatedRank int `json:"calculated_rank"`
+	// the number of ledger entries
+	Count int64 `json:"count"`
+	// ledger entries, the newest first
+	List []*ReputationLedgerEntry `json:"list"`
+}
+
+// ReputationLedgerEntry one change of the reputation
+type ReputationLedgerEntry struct {
+	ActivityID string `json:"activity_id"`
+	CreatedAt  int64  `json:"created_at"`
+	// activity type, such as answer.accepted, question.voted_up
+	ActivityType  string `json:"activity_type"`
+	ObjectID      string `json:"object_id"`
+	ObjectType    string `json:"object_type"`
+	TriggerUserID string `json:"trigger_user_id"`
+	// the reputation recorded when the activity happened
+	RecordedReputation int `json:"recorded_reputation"`
+	// the version of the reputation policy when the activity happened
+	RuleVersion int `json:"rule_version"`
+	// the reputation with the current rank rules
+	Reputation int `json:"reputation"`
+	// the rank after this change
+	Balance int `json:"balance"`
+	// why the reputation is different from the rule, daily_limit_reached or min_rank_reached
+	Note string `json:"note"`
+}
+
+// RecalculateReputationReq recalculate reputation request
+type RecalculateReputationReq struct {
+	// recalculate only this user, empty means all users
+	UserID string `validate:"omitempty" json:"user_id"`
+	// fix the rank of the users which are different from the recalculated rank
+	Fix bool `json:"fix"`
+	// operator user id
+	OperatorID string `json:"-"`
+}
+
+// RecalculateReputationResp recalculate reputation response
+type RecalculateReputationResp struct {
+	// the number of users checked
+	CheckedCount int `json:"checked_count"`
+	// the number of users fixed
+	FixedCount int `json:"fixed_count"`
+	// users whose stored rank is different from the recalculated rank
+	Discrepancies []*ReputationDiscrepancy `json:"discrepancies"`
+}
+
+// ReputationDiscrepancy the difference between the stored and recalculated rank of the user
+type ReputationDiscrepancy struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	StoredRank     int    `json:"stored_rank"`
+	CalculatedRank int    `json:"calculated_rank"`
+	Difference     int    `json:"difference"`
+}
+
+// ReputationPolicy the admin-editable reputation rules, stored as json in the config reputation.policy
+type ReputationPolicy struct {
+	// version, increased every time the policy is saved
+	Version int `json:"version"`
+	// points per activity type, such as answer.accepted, question.voted_up.
+	// The activity type not in it uses the value of the config with the same key.
+	Points map[string]int `json:"points"`
+	// the max reputation a user can earn in one day
+	DailyCap int `json:"daily_cap"`
+	// the activity types which are not limited by the daily cap
+	DailyCapExclude []string `json:"daily_cap_exclude"`
+	// the rank can not be reduced to lower than it
+	MinRank int `json:"min_rank"`
+	// tag id to multiplier, the points of the activity on the question with this tag are multiplied by it.
+	// If the question has many tags with multiplier, the largest one is used.
+	TagMultipliers map[string]float64 `json:"tag_multipliers"`
+}
+
+// GetRank get the rank of the activity type with this policy
+func (p *ReputationPolicy) GetRank(activityType string, defaultPoints int, tagIDs []string) int {
+	points, ok := p.Points[activityType]
+	if !ok {
+		points = defaultPoints
+	}
+	if points == 0 {
+		return 0
+	}
+	multiplier, found := 0.0, false
+	for _, tagID := range tagIDs {
+		if m, ok := p.TagMultipliers[tagID]; ok && (!found || m > multiplier) {
+			multiplier, found = m, true
+		}
+	}
+	if !found {
+		return points
+	}
+	return int(math.Round(float64(points) * multiplier))
+}
+
+// IsDailyCapExcluded whether the activity type is not limited by the daily cap
+func (p *ReputationPolicy) IsDailyCapExcluded(activityType string) bool {
+	for _, item := range p.DailyCapExclude {
+		if item == activityType {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateReputationPolicyReq update reputation policy request
+type UpdateReputationPolicyReq struct {
+	Points          map[string]int     `validate:"omitempty,dive,keys,required,lte=100,endkeys" json:"points"`
+	DailyCap        int                `validate:"min=0" json:"daily_cap"`
+	DailyCapExclude []string           `validate:"omitempty,dive,required,lte=100" json:"daily_cap_exclude"`
+	MinRank         int                `validate:"min=0" json:"min_rank"`
+	TagMultipliers  map[string]float64 `validate:"omitempty,dive,keys,required,endkeys,min=0,max=100" json:"tag_multipliers"`
+	UserID          string             `json:"-"`
+}
+
+// GetReputationPolicyVersionResp the saved version of the reputation policy
+type GetReputationPolicyVersionResp struct {
+	Version   int               `json:"version"`
+	CreatedAt int64             `json:"created_at"`
+	UserInfo  *UserBasicInfo    `json:"user_info"`
+	Policy    *ReputationPolicy `json:"policy"`
+}
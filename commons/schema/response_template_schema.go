@@ -0,0 +1,70 @@
+package schema
+
+const (
+	// ResponseTemplateVarAskerName the display name of the asker
+	ResponseTemplateVarAskerName = "asker_name"
+	// ResponseTemplateVarQuestionTitle the title of the question
+	ResponseTemplateVarQuestionTitle = "question_title"
+	// ResponseTemplateVarQuestionTags the tags of the question, separated by comma
+	ResponseTemplateVarQuestionTags = "question_tags"
+	// ResponseTemplateVarQuestionLink the link of the question
+	ResponseTemplateVarQuestionLink = "question_link"
+)
+
+// GetResponseTemplateListReq get response template list request
+type GetResponseTemplateListReq struct {
+	// answer or comment, empty means all
+	Scope  string `validate:"omitempty,oneof=answer comment" form:"scope"`
+	UserID string `json:"-"`
+}
+
+// AddResponseTemplateReq add response template request
+type AddResponseTemplateReq struct {
+	Title string `validate:"required,notblank,lte=100" json:"title"`
+	// the content can use the variables {{asker_name}} {{question_title}} {{question_tags}} {{question_link}}
+	Content string `validate:"required,notblank,lte=65535" json:"content"`
+	// answer or comment, empty means both
+	Scope string `validate:"omitempty,oneof=answer comment" json:"scope"`
+	// the template is for all users, only the user with the response_template.manage power can add it
+	SiteWide bool   `json:"site_wide"`
+	UserID   string `json:"-"`
+}
+
+// UpdateResponseTemplateReq update response template request
+type UpdateResponseTemplateReq struct {
+	ID      int64  `validate:"required" json:"id"`
+	Title   string `validate:"required,notblank,lte=100" json:"title"`
+	Content string `validate:"required,notblank,lte=65535" json:"content"`
+	Scope   string `validate:"omitempty,oneof=answer comment" json:"scope"`
+	UserID  string `json:"-"`
+}
+
+// RemoveResponseTemplateReq remove response template request
+type RemoveResponseTemplateReq struct {
+	ID     int64  `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
+
+// GetResponseTemplateResp get response template response
+type GetResponseTemplateResp struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	Scope    string `json:"scope"`
+	SiteWide bool   `json:"site_wide"`
+	UseCount int    `json:"use_count"`
+	// whether the user can update and remove it
+	CanEdit bool `json:"can_edit"`
+}
+
+// RenderResponseTemplateReq render response template request
+type RenderResponseTemplateReq struct {
+	ID         int64  `validate:"required" form:"id"`
+	QuestionID string `validate:"required" form:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// RenderResponseTemplateResp render response template response
+type RenderResponseTemplateResp struct {
+	Content string `json:"content"`
+}
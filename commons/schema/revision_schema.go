@@ -2,6 +2,7 @@ package schema
 
 import (
 	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/pkg/diff"
 	"time"
 )
 
@@ -32,8 +33,10 @@ const RevisionAuditReject = "reject"
 
 type RevisionAuditReq struct {
 	// object id
-	ID                string `validate:"required" comment:"id" form:"id"`
-	Operation         string `validate:"required" comment:"operation" form:"operation"` //approve or reject
+	ID        string `validate:"required" comment:"id" form:"id"`
+	Operation string `validate:"required,oneof=approve reject" comment:"operation" form:"operation"` //approve or reject
+	// the comment of the reviewer
+	Comment           string `validate:"omitempty,lte=500" comment:"comment" form:"comment" json:"comment"`
 	UserID            string `json:"-"`
 	CanReviewQuestion bool   `json:"-"`
 	CanReviewAnswer   bool   `json:"-"`
@@ -66,6 +69,12 @@ type GetUnreviewedRevisionResp struct {
 	Type           string                      `json:"type"`
 	Info           *UnreviewedRevisionInfoInfo `json:"info"`
 	UnreviewedInfo *GetRevisionResp            `json:"unreviewed_info"`
+	// the changes of the unreviewed revision from the previous approved revision
+	Diff *GetRevisionDiffResp `json:"diff,omitempty"`
+	// the votes of the reviewers so far
+	Reviews            []*RevisionReviewInfo `json:"reviews"`
+	RequiredApprovals  int                   `json:"required_approvals"`
+	RequiredRejections int                   `json:"required_rejections"`
 }
 
 // GetRevisionResp get revision response
@@ -92,3 +101,81 @@ type GetRevisionResp struct {
 	UserInfo        UserBasicInfo `json:"user_info"`
 	Log             string        `json:"reason"`
 }
+
+// GetRevisionDiffReq get the diff of two revisions of the same object
+type GetRevisionDiffReq struct {
+	NewID string `validate:"required" form:"new_id"`
+	// empty means the previous approved revision of the new revision
+	OldID             string `validate:"omitempty" form:"old_id"`
+	UserID            string `json:"-"`
+	CanReviewQuestion bool   `json:"-"`
+	CanReviewAnswer   bool   `json:"-"`
+	CanReviewTag      bool   `json:"-"`
+}
+
+// RevisionTextDiff the word level diff of the text
+type RevisionTextDiff struct {
+	Changed bool         `json:"changed"`
+	Hunks   []*diff.Hunk `json:"hunks"`
+	// the side-by-side rendering of the hunks
+	HTML string `json:"html"`
+}
+
+// RevisionTagDiff the diff of the tag slug names
+type RevisionTagDiff struct {
+	Changed bool     `json:"changed"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Kept    []string `json:"kept"`
+}
+
+// GetRevisionDiffResp get revision diff response
+type GetRevisionDiffResp struct {
+	ObjectID   string `json:"object_id"`
+	ObjectType string `json:"object_type"`
+	// empty if the new revision is the first one
+	OldID string            `json:"old_id"`
+	NewID string            `json:"new_id"`
+	Title *RevisionTextDiff `json:"title"`
+	Body  *RevisionTextDiff `json:"body"`
+	// only for question
+	Tags *RevisionTagDiff `json:"tags,omitempty"`
+}
+
+// RollbackRevisionReq restore the published revision as a new revision of the object
+type RollbackRevisionReq struct {
+	ID string `validate:"required" json:"id"`
+	// the default is "Rollback to revision {id}"
+	EditSummary string `validate:"omitempty,lte=255" json:"edit_summary"`
+	UserID      string `json:"-"`
+}
+
+// RollbackRevisionResp rollback revision response
+type RollbackRevisionResp struct {
+	// the new revision is waiting for review if the user can not edit without review
+	WaitForReview bool `json:"wait_for_review"`
+}
+
+// RevisionReviewPolicy the rules of the suggested edits review, stored as json in the config revision.review
+type RevisionReviewPolicy struct {
+	// the suggested edit is approved when it gets N approvals
+	RequiredApprovals int `validate:"min=1,max=10" json:"required_approvals"`
+	// the suggested edit is rejected when it gets N rejections
+	RequiredRejections int `validate:"min=1,max=10" json:"required_rejections"`
+	// the suggested edit is expired if it is not decided after N days, 0 means never
+	ExpireDays int `validate:"min=0" json:"expire_days"`
+}
+
+// UpdateRevisionReviewPolicyReq update revision review policy request
+type UpdateRevisionReviewPolicyReq struct {
+	RevisionReviewPolicy
+	UserID string `json:"-"`
+}
+
+// RevisionReviewInfo the vote of the reviewer
+type RevisionReviewInfo struct {
+	UserInfo  *UserBasicInfo `json:"user_info"`
+	Operation string         `json:"operation"`
+	Comment   string         `json:"comment"`
+	CreatedAt int64          `json:"created_at"`
+}
@@ -6,3 +6,98 @@ type GetRoleResp struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
+
+// AddRoleReq add role request
+type AddRoleReq struct {
+	// role name
+	Name string `validate:"required,gt=0,lte=50" json:"name"`
+	// role description
+	Description string `validate:"omitempty,lte=200" json:"description"`
+	// power type list, such as question.close
+	PowerTypes []string `validate:"omitempty,dive,gt=0,lte=100" json:"power_types"`
+}
+
+// AddRoleResp add role response
+type AddRoleResp struct {
+	ID int `json:"id"`
+}
+
+// UpdateRoleReq update role request
+type UpdateRoleReq struct {
+	// role id
+	ID int `validate:"required" json:"id"`
+	// role name
+	Name string `validate:"required,gt=0,lte=50" json:"name"`
+	// role description
+	Description string `validate:"omitempty,lte=200" json:"description"`
+}
+
+// RemoveRoleReq remove role request
+type RemoveRoleReq struct {
+	// role id
+	ID int `validate:"required" json:"id"`
+}
+
+// GetRolePowerReq get role power request
+type GetRolePowerReq struct {
+	// role id
+	RoleID int `validate:"required" form:"role_id"`
+}
+
+// GetRolePowerResp get role power response
+type GetRolePowerResp struct {
+	RoleID     int      `json:"role_id"`
+	PowerTypes []string `json:"power_types"`
+}
+
+// UpdateRolePowerReq update role power request
+type UpdateRolePowerReq struct {
+	// role id
+	RoleID int `validate:"required" json:"role_id"`
+	// power type list, replace all the powers of the role
+	PowerTypes []string `validate:"omitempty,dive,gt=0,lte=100" json:"power_types"`
+}
+
+// GetPowerResp get power response
+type GetPowerResp struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	PowerType   string `json:"power_type"`
+	Description string `json:"description"`
+}
+
+// SaveUserScopedRoleReq appoint the user with a role limited to the tag
+type SaveUserScopedRoleReq struct {
+	// user id
+	UserID string `validate:"required" json:"user_id"`
+	// role id
+	RoleID int `validate:"required" json:"role_id"`
+	// tag id
+	TagID string `validate:"required" json:"tag_id"`
+}
+
+// RemoveUserScopedRoleReq remove the role of the user limited to the tag
+type RemoveUserScopedRoleReq struct {
+	// user id
+	UserID string `validate:"required" json:"user_id"`
+	// tag id
+	TagID string `validate:"required" json:"tag_id"`
+}
+
+// GetUserScopedRoleReq get the scoped roles of the user or the tag
+type GetUserScopedRoleReq struct {
+	// user id
+	UserID string `validate:"omitempty" form:"user_id"`
+	// tag id
+	TagID string `validate:"omitempty" form:"tag_id"`
+}
+
+// GetUserScopedRoleResp get user scoped role response
+type GetUserScopedRoleResp struct {
+	UserID    string `json:"user_id"`
+	RoleID    int    `json:"role_id"`
+	RoleName  string `json:"role_name"`
+	ScopeType string `json:"scope_type"`
+	TagID     string `json:"tag_id"`
+	SlugName  string `json:"slug_name"`
+}
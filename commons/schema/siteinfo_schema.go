@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/lawyer/commons/base/validator"
 	"github.com/lawyer/commons/constant"
@@ -99,6 +100,10 @@ type SiteLoginReq struct {
 	AllowPasswordLogin      bool     `json:"allow_password_login"`
 	LoginRequired           bool     `json:"login_required"`
 	AllowEmailDomains       []string `json:"allow_email_domains"`
+	// the users can login with the link or the one-time code sent to their email
+	AllowMagicLinkLogin bool `json:"allow_magic_link_login"`
+	// the password login is turned off, the users can only login with the link or the one-time code
+	RequireMagicLinkLogin bool `json:"require_magic_link_login"`
 }
 
 // SiteCustomCssHTMLReq site custom css html
@@ -201,10 +206,35 @@ type TemplateSiteInfoResp struct {
 	Year          string
 	Canonical     string
 	JsonLD        string
+	OpenGraph     *OpenGraph
+	NoIndex       bool
 	Keywords      string
 	Description   string
 }
 
+// GetSiteInfoHistoryPageReq get the saved versions of the site settings type
+type GetSiteInfoHistoryPageReq struct {
+	SiteType string `validate:"required,oneof=general interface branding write legal seo login css-html theme privileges users smtp" form:"site_type"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// SiteInfoHistoryInfo a saved version of the site settings type
+type SiteInfoHistoryInfo struct {
+	SiteType  string          `json:"site_type"`
+	Version   int             `json:"version"`
+	Content   json.RawMessage `json:"content"`
+	UserInfo  *UserBasicInfo  `json:"user_info"`
+	CreatedAt int64           `json:"created_at"`
+}
+
+// RollbackSiteInfoReq restore the site settings type to the saved version, it is saved as a new version
+type RollbackSiteInfoReq struct {
+	SiteType string `validate:"required,oneof=general interface branding write legal seo login css-html theme privileges users smtp" json:"site_type"`
+	Version  int    `validate:"required,min=1" json:"version"`
+	UserID   string `json:"-"`
+}
+
 // UpdateSMTPConfigReq get smtp config request
 type UpdateSMTPConfigReq struct {
 	FromEmail          string `validate:"omitempty,gt=0,lte=256" json:"from_email"`
@@ -241,6 +271,18 @@ type GetSMTPConfigResp struct {
 	SMTPAuthentication bool   `json:"smtp_authentication"`
 }
 
+// SMTPPasswordMask the smtp password is shown as it, the saved password is kept when it is submitted
+const SMTPPasswordMask = "********"
+
+// Mask copy the smtp config with the password masked, it is used when the config leaves the email config
+func (r *GetSMTPConfigResp) Mask() *GetSMTPConfigResp {
+	masked := *r
+	if len(masked.SMTPPassword) > 0 {
+		masked.SMTPPassword = SMTPPasswordMask
+	}
+	return &masked
+}
+
 // GetManifestJsonResp get manifest json response
 type GetManifestJsonResp struct {
 	ManifestVersion int               `json:"manifest_version"`
@@ -0,0 +1,99 @@
+package schema
+
+import "strings"
+
+// the signals of the spam score
+const (
+	SpamSignalLinks           = "links"
+	SpamSignalBlockedDomain   = "blocked_domain"
+	SpamSignalRepeatedContent = "repeated_content"
+	SpamSignalNewAccount      = "new_account"
+	SpamSignalActionBurst     = "action_burst"
+	SpamSignalFilter          = "filter"
+)
+
+// SpamPolicy the rules of the spam scoring, stored as json in the config content.spam_policy
+type SpamPolicy struct {
+	Enabled bool `json:"enabled"`
+	// the post is held for review when its score reaches it, it is adjusted by the review decisions
+	HoldScore int `validate:"min=1" json:"hold_score"`
+	// the hold score is kept in [min_hold_score, max_hold_score] when it is adjusted
+	MinHoldScore int `validate:"min=1" json:"min_hold_score"`
+	MaxHoldScore int `validate:"min=1" json:"max_hold_score"`
+	// the hold score is increased by it when a held post is approved and decreased when it is rejected, 0 means fixed
+	AdjustStep int `validate:"min=0" json:"adjust_step"`
+
+	// every link after the first free links scores link_weight
+	FreeLinks  int `validate:"min=0" json:"free_links"`
+	LinkWeight int `validate:"min=0" json:"link_weight"`
+	// the post links to any of the domains or their sub domains
+	BlockedDomains      []string `validate:"omitempty,dive,required,lte=253" json:"blocked_domains"`
+	BlockedDomainWeight int      `validate:"min=0" json:"blocked_domain_weight"`
+	// the same content was posted in the last repeat_window_hours
+	RepeatWindowHours     int `validate:"min=0" json:"repeat_window_hours"`
+	RepeatedContentWeight int `validate:"min=0" json:"repeated_content_weight"`
+	// the account is created in the last new_account_days
+	NewAccountDays   int `validate:"min=0" json:"new_account_days"`
+	NewAccountWeight int `validate:"min=0" json:"new_account_weight"`
+	// the user did the same action at least action_burst_count times in the captcha record window
+	ActionBurstCount  int `validate:"min=0" json:"action_burst_count"`
+	ActionBurstWeight int `validate:"min=0" json:"action_burst_weight"`
+	// the content is refused by the filter plugins
+	FilterWeight int `validate:"min=0" json:"filter_weight"`
+}
+
+// IsBlockedDomain whether the host is one of the blocked domains or their sub domains
+func (p *SpamPolicy) IsBlockedDomain(host string) bool {
+	for _, domain := range p.BlockedDomains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if len(domain) == 0 {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateSpamPolicyReq update spam policy request
+type UpdateSpamPolicyReq struct {
+	SpamPolicy
+	UserID string `json:"-"`
+}
+
+// SpamCheckReq the post to be scored
+type SpamCheckReq struct {
+	UserID string
+	// question, answer or comment, it is also the captcha action type of the post
+	ObjectType string
+	// the question or answer which the post belongs to, the moderators of its tags are trusted
+	ObjectID string
+	Title    string
+	Content  string
+}
+
+// SpamScoreResult the spam score of the post
+type SpamScoreResult struct {
+	Score       int
+	Signals     map[string]int
+	ContentHash string
+	// the post should be held for review
+	Hold bool
+}
+
+// GetSpamScoreReq get the spam score of the post
+type GetSpamScoreReq struct {
+	ObjectID string `validate:"required" form:"object_id"`
+}
+
+// GetSpamScoreResp get spam score response
+type GetSpamScoreResp struct {
+	ObjectID   string         `json:"object_id"`
+	ObjectType string         `json:"object_type"`
+	Score      int            `json:"score"`
+	Signals    map[string]int `json:"signals"`
+	Held       bool           `json:"held"`
+	Decision   string         `json:"decision"`
+	CreatedAt  int64          `json:"created_at"`
+}
@@ -14,41 +14,51 @@ type QAPageJsonLD struct {
 	Context    string `json:"@context"`
 	Type       string `json:"@type"`
 	MainEntity struct {
-		Type        string    `json:"@type"`
-		Name        string    `json:"name"`
-		Text        string    `json:"text"`
-		AnswerCount int       `json:"answerCount"`
-		UpvoteCount int       `json:"upvoteCount"`
-		DateCreated time.Time `json:"dateCreated"`
-		Author      struct {
-			Type string `json:"@type"`
-			Name string `json:"name"`
-		} `json:"author"`
+		Type            string                 `json:"@type"`
+		Name            string                 `json:"name"`
+		Text            string                 `json:"text"`
+		AnswerCount     int                    `json:"answerCount"`
+		UpvoteCount     int                    `json:"upvoteCount"`
+		DateCreated     time.Time              `json:"dateCreated"`
+		Author          JsonLDPerson           `json:"author"`
+		URL             string                 `json:"url,omitempty"`
 		AcceptedAnswer  *AcceptedAnswerItem    `json:"acceptedAnswer,omitempty"`
 		SuggestedAnswer []*SuggestedAnswerItem `json:"suggestedAnswer"`
 	} `json:"mainEntity"`
 }
 
 type AcceptedAnswerItem struct {
-	Type        string    `json:"@type"`
-	Text        string    `json:"text"`
-	DateCreated time.Time `json:"dateCreated"`
-	UpvoteCount int       `json:"upvoteCount"`
-	URL         string    `json:"url"`
-	Author      struct {
-		Type string `json:"@type"`
-		Name string `json:"name"`
-	} `json:"author"`
+	Type        string       `json:"@type"`
+	Text        string       `json:"text"`
+	DateCreated time.Time    `json:"dateCreated"`
+	UpvoteCount int          `json:"upvoteCount"`
+	URL         string       `json:"url"`
+	Author      JsonLDPerson `json:"author"`
 }
 
 type SuggestedAnswerItem struct {
-	Type        string    `json:"@type"`
-	Text        string    `json:"text"`
-	DateCreated time.Time `json:"dateCreated"`
-	UpvoteCount int       `json:"upvoteCount"`
-	URL         string    `json:"url"`
-	Author      struct {
-		Type string `json:"@type"`
-		Name string `json:"name"`
-	} `json:"author"`
+	Type        string       `json:"@type"`
+	Text        string       `json:"text"`
+	DateCreated time.Time    `json:"dateCreated"`
+	UpvoteCount int          `json:"upvoteCount"`
+	URL         string       `json:"url"`
+	Author      JsonLDPerson `json:"author"`
+}
+
+// JsonLDPerson the author of the question or answer in the structured data
+type JsonLDPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// OpenGraph the open graph and twitter card metadata of the page
+type OpenGraph struct {
+	Type        string
+	Title       string
+	Description string
+	URL         string
+	Image       string
+	SiteName    string
+	TwitterCard string
 }
@@ -0,0 +1,106 @@
+package schema
+
+import "encoding/json"
+
+// RequestUserDeletionReq request the deletion of the own account, the password is required when the user has one
+type RequestUserDeletionReq struct {
+	Pass   string `validate:"omitempty,lte=32" json:"pass"`
+	UserID string `json:"-"`
+}
+
+// GetUserDeletionResp the account deletion status of the login user
+type GetUserDeletionResp struct {
+	// the deletion is scheduled and can still be canceled
+	Pending bool `json:"pending"`
+	// the account is deleted after this time
+	ScheduledAt int64 `json:"scheduled_at,omitempty"`
+}
+
+// UserDataExport all the personal data of the user, every part is written to the export archive as a json file
+type UserDataExport struct {
+	Profile        *UserDataProfile         `json:"profile"`
+	Questions      []*UserDataQuestion      `json:"questions"`
+	Answers        []*UserDataAnswer        `json:"answers"`
+	Comments       []*UserDataComment       `json:"comments"`
+	Votes          []*UserDataVote          `json:"votes"`
+	Collections    []*UserDataCollection    `json:"collections"`
+	Notifications  []*UserDataNotification  `json:"notifications"`
+	ExternalLogins []*UserDataExternalLogin `json:"external_logins"`
+}
+
+// UserDataProfile the profile of the user
+type UserDataProfile struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	DisplayName   string `json:"display_name"`
+	EMail         string `json:"e_mail"`
+	Bio           string `json:"bio"`
+	Website       string `json:"website"`
+	Location      string `json:"location"`
+	Avatar        string `json:"avatar"`
+	Language      string `json:"language"`
+	IPInfo        string `json:"ip_info"`
+	Rank          int    `json:"rank"`
+	CreatedAt     int64  `json:"created_at"`
+	LastLoginDate int64  `json:"last_login_date"`
+}
+
+// UserDataQuestion the question created by the user
+type UserDataQuestion struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Status    int    `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataAnswer the answer created by the user
+type UserDataAnswer struct {
+	ID         string `json:"id"`
+	QuestionID string `json:"question_id"`
+	Content    string `json:"content"`
+	Status     int    `json:"status"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// UserDataComment the comment created by the user
+type UserDataComment struct {
+	ID         string `json:"id"`
+	ObjectID   string `json:"object_id"`
+	QuestionID string `json:"question_id"`
+	Content    string `json:"content"`
+	Status     int    `json:"status"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// UserDataVote the vote of the user
+type UserDataVote struct {
+	ObjectID  string `json:"object_id"`
+	VoteType  string `json:"vote_type"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataCollection the object collected by the user
+type UserDataCollection struct {
+	ObjectID  string `json:"object_id"`
+	GroupID   string `json:"group_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataNotification the notification received by the user
+type UserDataNotification struct {
+	ID        string          `json:"id"`
+	ObjectID  string          `json:"object_id"`
+	Type      int             `json:"type"`
+	Content   json.RawMessage `json:"content"`
+	IsRead    bool            `json:"is_read"`
+	CreatedAt int64           `json:"created_at"`
+}
+
+// UserDataExternalLogin the external login bound to the user
+type UserDataExternalLogin struct {
+	Provider   string          `json:"provider"`
+	ExternalID string          `json:"external_id"`
+	MetaInfo   json.RawMessage `json:"meta_info"`
+	CreatedAt  int64           `json:"created_at"`
+}
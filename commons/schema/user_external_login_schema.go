@@ -4,6 +4,12 @@ package schema
 type UserExternalLoginResp struct {
 	BindingKey  string `json:"binding_key"`
 	AccessToken string `json:"access_token"`
+	// refresh token of the login session, it is used to get a new access token
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// the two-factor verification is required, the access token is issued after the verification
+	TwoFactorRequired      bool   `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required,omitempty"`
+	ChallengeToken         string `json:"challenge_token,omitempty"`
 	// ErrMsg error message, if not empty, means login failed and this message should be displayed.
 	ErrMsg   string `json:"-"`
 	ErrTitle string `json:"-"`
@@ -22,6 +28,12 @@ type ExternalLoginBindingUserSendEmailReq struct {
 type ExternalLoginBindingUserSendEmailResp struct {
 	EmailExistAndMustBeConfirmed bool   `json:"email_exist_and_must_be_confirmed"`
 	AccessToken                  string `json:"access_token"`
+	// refresh token of the login session, it is used to get a new access token
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// the two-factor verification is required, the access token is issued after the verification
+	TwoFactorRequired      bool   `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required,omitempty"`
+	ChallengeToken         string `json:"challenge_token,omitempty"`
 }
 
 // ExternalLoginBindingUserReq external login binding user request
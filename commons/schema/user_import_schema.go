@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// the columns of the user import and export csv, the export can be imported again without the column mapping
+const (
+	UserImportColumnUsername    = "username"
+	UserImportColumnEmail       = "email"
+	UserImportColumnDisplayName = "display_name"
+	UserImportColumnRole        = "role"
+	UserImportColumnLocation    = "location"
+	UserImportColumnWebsite     = "website"
+)
+
+// UserImportColumns the columns which can be imported, in the order of the export
+var UserImportColumns = []string{
+	UserImportColumnUsername,
+	UserImportColumnEmail,
+	UserImportColumnDisplayName,
+	UserImportColumnRole,
+	UserImportColumnLocation,
+	UserImportColumnWebsite,
+}
+
+// the status of the user import job
+const (
+	UserImportStatusValidating = "validating"
+	UserImportStatusImporting  = "importing"
+	UserImportStatusCompleted  = "completed"
+	UserImportStatusFailed     = "failed"
+)
+
+// ImportUsersReq import users from the csv file
+type ImportUsersReq struct {
+	// the json object mapping the column to the header in the file, such as {"email":"E-Mail"},
+	// the header with the same name as the column is used when the column is not mapped
+	Mapping string `validate:"omitempty,lte=2000" form:"mapping"`
+	// only validate the file and report the errors, no user is created
+	DryRun bool `validate:"omitempty" form:"dry_run"`
+	// send the activation email to the created users
+	SendWelcomeEmail bool              `validate:"omitempty" form:"send_welcome_email"`
+	Users            []*ImportUserInfo `json:"-" form:"-"`
+	LoginUserID      string            `json:"-" form:"-"`
+}
+
+// ImportUserInfo the user in one row of the csv file
+type ImportUserInfo struct {
+	// the line number in the file, the header is the first line
+	Line        int    `json:"-"`
+	Content     string `json:"-"`
+	Username    string `validate:"omitempty,gt=3,lte=30" json:"username"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	Role        string `validate:"omitempty,lte=50" json:"role"`
+	Location    string `validate:"omitempty,lte=100" json:"location"`
+	Website     string `validate:"omitempty,url,lte=255" json:"website"`
+}
+
+// ParseUsers read the users from the csv file, the first row is the header
+func (req *ImportUsersReq) ParseUsers(file io.Reader) (err error) {
+	mapping := make(map[string]string)
+	if len(req.Mapping) > 0 {
+		if err = json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+			return errors.BadRequest(reason.RequestFormatError).WithError(err)
+		}
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return errors.BadRequest(reason.UserImportFileInvalid).WithError(err)
+	}
+	headerIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := headerIndex[name]; !ok {
+			headerIndex[name] = i
+		}
+	}
+	columnIndex := make(map[string]int, len(UserImportColumns))
+	for _, column := range UserImportColumns {
+		name := column
+		if mapped, ok := mapping[column]; ok {
+			name = mapped
+		}
+		if i, ok := headerIndex[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columnIndex[column] = i
+		}
+	}
+	if _, ok := columnIndex[UserImportColumnEmail]; !ok {
+		return errors.BadRequest(reason.UserImportFileInvalid)
+	}
+
+	req.Users = make([]*ImportUserInfo, 0)
+	for {
+		record, e := reader.Read()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return errors.BadRequest(reason.UserImportFileInvalid).WithError(e)
+		}
+		if len(strings.TrimSpace(strings.Join(record, ""))) == 0 {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			i, ok := columnIndex[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		user := &ImportUserInfo{
+			Line:        line,
+			Content:     strings.Join(record, ","),
+			Username:    value(UserImportColumnUsername),
+			Email:       value(UserImportColumnEmail),
+			DisplayName: value(UserImportColumnDisplayName),
+			Role:        value(UserImportColumnRole),
+			Location:    value(UserImportColumnLocation),
+			Website:     value(UserImportColumnWebsite),
+		}
+		req.Users = append(req.Users, user)
+	}
+
+	if len(req.Users) == 0 {
+		return errors.BadRequest(reason.UserImportFileInvalid)
+	}
+	if len(req.Users) > constant.DefaultBulkUser {
+		return errors.BadRequest(reason.UserImportTooManyUsers)
+	}
+	return nil
+}
+
+// UserImportJob the progress and the report of the user import
+type UserImportJob struct {
+	JobID string `json:"job_id"`
+	// validating, importing, completed or failed
+	Status string `json:"status"`
+	DryRun bool   `json:"dry_run"`
+	// the number of users in the file
+	Total int `json:"total"`
+	// the number of users validated or imported in the current status
+	Processed int `json:"processed"`
+	// the number of users created
+	Created int `json:"created"`
+	// the invalid rows, no user is created when there is any error
+	Errors    []*AddUsersErrorData `json:"errors"`
+	CreatedAt int64                `json:"created_at"`
+}
+
+// ToJSONString to json string
+func (j *UserImportJob) ToJSONString() string {
+	data, _ := json.Marshal(j)
+	return string(data)
+}
+
+// FromJSONString from json string
+func (j *UserImportJob) FromJSONString(data string) error {
+	return json.Unmarshal([]byte(data), j)
+}
+
+// GetUserImportJobReq get the user import job
+type GetUserImportJobReq struct {
+	JobID string `validate:"required" form:"job_id"`
+}
@@ -0,0 +1,41 @@
+package schema
+
+import "encoding/json"
+
+// MagicLinkSendReq request the login link and the one-time code by email
+type MagicLinkSendReq struct {
+	Email       string `validate:"required,email,gt=0,lte=500" json:"email"`
+	CaptchaID   string `json:"captcha_id"`
+	CaptchaCode string `json:"captcha_code"`
+}
+
+// MagicLinkSendResp the request token is kept by the device which requested the login, the link or the code
+// only works together with it
+type MagicLinkSendResp struct {
+	RequestToken string `json:"request_token"`
+	// seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// MagicLinkLoginReq login with the code of the link or the one-time code of the email
+type MagicLinkLoginReq struct {
+	RequestToken string `validate:"required,gt=0,lte=100" json:"request_token"`
+	Code         string `validate:"required,gt=0,lte=100" json:"code"`
+}
+
+// MagicLinkCodeContent the content saved with the request token, only the hashes of the codes are saved
+type MagicLinkCodeContent struct {
+	UserID   string `json:"user_id"`
+	Email    string `json:"e_mail"`
+	LinkHash string `json:"link_hash"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (r *MagicLinkCodeContent) ToJSONString() string {
+	codeBytes, _ := json.Marshal(r)
+	return string(codeBytes)
+}
+
+func (r *MagicLinkCodeContent) FromJSONString(data string) error {
+	return json.Unmarshal([]byte(data), &r)
+}
@@ -64,6 +64,8 @@ type UserLoginResp struct {
 	Language string `json:"language"`
 	// access token
 	AccessToken string `json:"access_token"`
+	// refresh token of the login session, it is used to get a new access token
+	RefreshToken string `json:"refresh_token,omitempty"`
 	// role id
 	RoleID int `json:"role_id"`
 	// user status
@@ -72,6 +74,14 @@ type UserLoginResp struct {
 	HavePassword bool `json:"have_password"`
 	// visit token
 	VisitToken string `json:"visit_token"`
+	// the two-factor verification is required, the access token is issued after the verification
+	TwoFactorRequired bool `json:"two_factor_required,omitempty"`
+	// the two-factor authentication is required for the role of the user but not enabled, the user must enroll first
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+	// the challenge token of the two-factor verification
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	// the recovery codes generated when the user enrolls during the login
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -113,6 +123,8 @@ type GetOtherUserInfoByUsernameResp struct {
 	QuestionCount int `json:"question_count"`
 	// rank
 	Rank int `json:"rank"`
+	// badge count
+	BadgeCount int `json:"badge_count"`
 	// display name
 	DisplayName string `json:"display_name"`
 	// avatar
@@ -197,6 +209,7 @@ type UserModifyPasswordReq struct {
 	CaptchaCode string `validate:"omitempty,gt=0,lte=500" form:"captcha_code"`
 	UserID      string `json:"-"`
 	AccessToken string `json:"-"`
+	SessionID   string `json:"-"`
 }
 
 func (u *UserModifyPasswordReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -303,6 +316,7 @@ type UserBasicInfo struct {
 	ID          string `json:"id"`
 	Username    string `json:"username"`
 	Rank        int    `json:"rank"`
+	BadgeCount  int    `json:"badge_count"`
 	DisplayName string `json:"display_name"`
 	Avatar      string `json:"avatar"`
 	Website     string `json:"website"`
@@ -0,0 +1,41 @@
+package schema
+
+// RefreshTokenReq refresh the access token request
+type RefreshTokenReq struct {
+	RefreshToken string `validate:"required,gt=0,lte=200" json:"refresh_token"`
+}
+
+// RefreshTokenResp the new access token and the rotated refresh token, the old refresh token can not be used again
+type RefreshTokenResp struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LoginExchangeReq get the tokens of the login redirected to the frontend by the single-use code
+type LoginExchangeReq struct {
+	Code string `validate:"required,gt=0,lte=100" json:"code"`
+}
+
+// UserSessionResp the login session of a device
+type UserSessionResp struct {
+	ID         string `json:"id"`
+	Device     string `json:"device"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	// the session of the current request
+	Current bool `json:"current"`
+}
+
+// RevokeUserSessionReq log out the session of the device
+type RevokeUserSessionReq struct {
+	SessionID string `validate:"required,gt=0,lte=100" json:"session_id"`
+	UserID    string `json:"-"`
+}
+
+// ForceLogoutUserReq log out all the sessions of the user by the admin
+type ForceLogoutUserReq struct {
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+}
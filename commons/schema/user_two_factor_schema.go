@@ -0,0 +1,65 @@
+package schema
+
+// TwoFactorPolicy the two-factor authentication policy
+type TwoFactorPolicy struct {
+	// the users of these roles must enable the two-factor authentication, they are asked to enroll when they login
+	RequiredRoleIDs []int `validate:"omitempty,dive,gt=0" json:"required_role_ids"`
+}
+
+// UpdateTwoFactorPolicyReq update two-factor authentication policy request
+type UpdateTwoFactorPolicyReq struct {
+	TwoFactorPolicy
+	UserID string `json:"-"`
+}
+
+// GetTwoFactorStatusResp the two-factor authentication status of the login user
+type GetTwoFactorStatusResp struct {
+	Enabled bool `json:"enabled"`
+	// the two-factor authentication is enforced for the role of the user and can not be disabled
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// TwoFactorEnrollResp the secret to add to the authenticator app, the provisioning uri is shown as QR code
+type TwoFactorEnrollResp struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorConfirmReq confirm the enrollment with the code of the authenticator app
+type TwoFactorConfirmReq struct {
+	Code   string `validate:"required,len=6" json:"code"`
+	UserID string `json:"-"`
+}
+
+// TwoFactorRecoveryCodesResp the recovery codes, they are only shown once
+type TwoFactorRecoveryCodesResp struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorVerifyReq verify the login user with the code or one of the recovery codes
+type TwoFactorVerifyReq struct {
+	Code         string `validate:"omitempty,len=6" json:"code"`
+	RecoveryCode string `validate:"omitempty,lte=32" json:"recovery_code"`
+	UserID       string `json:"-"`
+}
+
+// TwoFactorLoginReq the second step of the login, the challenge token is returned by the first step
+type TwoFactorLoginReq struct {
+	ChallengeToken string `validate:"required,lte=64" json:"challenge_token"`
+	Code           string `validate:"omitempty,len=6" json:"code"`
+	RecoveryCode   string `validate:"omitempty,lte=32" json:"recovery_code"`
+	CaptchaID      string `json:"captcha_id"`
+	CaptchaCode    string `json:"captcha_code"`
+}
+
+// TwoFactorLoginSetupReq get the secret to enroll during the login when the two-factor authentication is required
+type TwoFactorLoginSetupReq struct {
+	ChallengeToken string `validate:"required,lte=64" json:"challenge_token"`
+}
+
+// ResetTwoFactorReq reset the two-factor authentication of the user who lost the authenticator and recovery codes
+type ResetTwoFactorReq struct {
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+}
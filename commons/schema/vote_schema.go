@@ -39,6 +39,8 @@ type VoteActivity struct {
 	ActivityUserID string
 	TriggerUserID  string
 	Rank           int
+	// the version of the reputation policy which the rank is calculated by
+	RuleVersion int
 }
 
 func (v *VoteActivity) HasRank() int {
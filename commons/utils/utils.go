@@ -46,6 +46,28 @@ func GetLangByCtx(ctx context.Context) i18n.Language {
 	return i18n.DefaultLanguage
 }
 
+// GetTraceIDByCtx get the trace id of the request
+func GetTraceIDByCtx(ctx context.Context) string {
+	traceID, _ := ctx.Value(constant.TraceID).(string)
+	return traceID
+}
+
+// GetClientIPByCtx get the client ip of the request, empty if the context is not from a request
+func GetClientIPByCtx(ctx context.Context) string {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		return ginCtx.ClientIP()
+	}
+	return ""
+}
+
+// GetUserAgentByCtx get the user agent of the request, empty if the context is not from a request
+func GetUserAgentByCtx(ctx context.Context) string {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		return ginCtx.Request.UserAgent()
+	}
+	return ""
+}
+
 func GenerateTraceId() string {
 	newUUID, _ := uuid.NewUUID()
 	return newUUID.String()
@@ -113,15 +135,28 @@ func ParseToken(tokenString string) (*CustomClaim, error) {
 	return nil, TokenInvalid
 }
 
+// ParseTokenIgnoreExpired parse the token whose signature is valid even if it is expired, it is used by the logout
+func ParseTokenIgnoreExpired(tokenString string) (*CustomClaim, error) {
+	claims := &CustomClaim{}
+	token, err := jwt.NewParser(jwt.WithoutClaimsValidation()).ParseWithClaims(tokenString, claims,
+		func(token *jwt.Token) (i interface{}, e error) {
+			return secret, nil
+		})
+	if err != nil || token == nil || !token.Valid {
+		return nil, TokenInvalid
+	}
+	return claims, nil
+}
+
 var secret = []byte("lawyer")
 var (
-	Issuer                 = "lawyer-test"
-	ExpireDate             = time.Hour * 24 * 15
-	ExpireBuffer     int64 = 1000 * 3600 * 24
-	TokenExpired           = errors.New("token is expired")
-	TokenNotValidYet       = errors.New("token not active yet")
-	TokenMalformed         = errors.New("that's not even a token")
-	TokenInvalid           = errors.New("token invalid")
+	Issuer = "lawyer-test"
+	// ExpireDate the access token is short-lived, the client gets a new one by the refresh token of the session
+	ExpireDate       = 2 * time.Hour
+	TokenExpired     = errors.New("token is expired")
+	TokenNotValidYet = errors.New("token not active yet")
+	TokenMalformed   = errors.New("that's not even a token")
+	TokenInvalid     = errors.New("token invalid")
 )
 
 type CustomClaim struct {
@@ -131,7 +166,8 @@ type CustomClaim struct {
 	Uid      string
 }
 
-func CreateToken(UserName, Uid string, Role int) (string, error) {
+// CreateToken create the access token of the login session, the session id is kept as the jwt id
+func CreateToken(UserName, Uid string, Role int, SessionID string) (string, error) {
 
 	claims := CustomClaim{
 		UserName: UserName,
@@ -140,8 +176,9 @@ func CreateToken(UserName, Uid string, Role int) (string, error) {
 		RegisteredClaims: jwt.RegisteredClaims{
 			Audience:  jwt.ClaimStrings{"lawyer"},                     // 受众
 			NotBefore: jwt.NewNumericDate(time.Now().Add(-1000)),      // 签名生效时间
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ExpireDate)), // 过期时间 7天  配置文件
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ExpireDate)), // 过期时间
 			Issuer:    Issuer,
+			ID:        SessionID,
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -156,3 +193,13 @@ func GetUidFromTokenByCtx(ctx *gin.Context) string {
 	claim := v.(*CustomClaim)
 	return claim.Uid
 }
+
+// GetSessionIDFromTokenByCtx get the login session id of the access token
+func GetSessionIDFromTokenByCtx(ctx *gin.Context) string {
+	v, ok := ctx.Get(constant.TokenClaim)
+	if !ok {
+		return ""
+	}
+	claim := v.(*CustomClaim)
+	return claim.ID
+}
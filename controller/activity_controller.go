@@ -7,6 +7,7 @@ import (
 	"github.com/lawyer/middleware"
 	"github.com/lawyer/pkg/uid"
 	"github.com/lawyer/service"
+	"github.com/lawyer/service/permission"
 )
 
 type ActivityController struct {
@@ -39,9 +40,7 @@ func (ac *ActivityController) GetObjectTimeline(ctx *gin.Context) {
 	req.ObjectID = uid.DeShortID(req.ObjectID)
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	if userInfo := middleware.GetUserInfoFromContext(ctx); userInfo != nil {
-		req.IsAdmin = userInfo.RoleID == service.RoleAdminID
-	}
+	req.IsAdmin = service.RankServicer.CheckUserPower(ctx, req.UserID, permission.AdminAccess)
 
 	resp, err := ac.activityService.GetObjectTimeline(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
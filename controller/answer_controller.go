@@ -9,7 +9,6 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/schema"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils"
 	middleware "github.com/lawyer/middleware"
 	"github.com/lawyer/pkg/uid"
@@ -146,6 +145,24 @@ func (ac *AnswerController) Get(ctx *gin.Context) {
 	})
 }
 
+// GetDisclaimer get the disclaimer of the question shown when answering
+// @Summary get answer disclaimer
+// @Description get the disclaimer of the question and whether it must be acknowledged when answering
+// @Tags api-answer
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.GetAnswerDisclaimerResp}
+// @Router /answer/api/v1/answer/disclaimer [get]
+func (ac *AnswerController) GetDisclaimer(ctx *gin.Context) {
+	req := &schema.GetAnswerDisclaimerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	resp, err := service.AnswerDisclaimerServicer.GetAnswerDisclaimer(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // Add godoc
 // @Summary Insert Answer
 // @Description Insert Answer
@@ -209,7 +226,11 @@ func (ac *AnswerController) Add(ctx *gin.Context) {
 		return
 	}
 
-	write := site.Config.GetSiteWrite()
+	write, err := service.SiteInfoServicer.GetSiteWrite(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 	if write.RestrictAnswer {
 		// check if there's already an answer by this user
 		ids, err := service.AnswerServicer.GetCountByUserIDQuestionID(ctx, req.UserID, req.QuestionID)
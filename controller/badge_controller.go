@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/service"
+)
+
+// BadgeController badge controller, no need login
+type BadgeController struct {
+}
+
+// NewBadgeController new controller
+func NewBadgeController() *BadgeController {
+	return &BadgeController{}
+}
+
+// GetBadgeList get the enabled badges
+// @Summary get badge list
+// @Description get the enabled badges and how many times each badge is awarded
+// @Tags Badge
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetBadgeResp}
+// @Router /answer/api/v1/badges [get]
+func (bc *BadgeController) GetBadgeList(ctx *gin.Context) {
+	resp, err := service.BadgeServicer.GetBadgeList(ctx, entity.BadgeStatusEnabled)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetUserBadgeList get the badges of the user
+// @Summary get user badge list
+// @Description get the badges the user earned, the newest first
+// @Tags Badge
+// @Produce json
+// @Param username query string true "username"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetUserBadgeResp}
+// @Router /answer/api/v1/user/badges [get]
+func (bc *BadgeController) GetUserBadgeList(ctx *gin.Context) {
+	req := &schema.GetUserBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := service.BadgeServicer.GetUserBadgeList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
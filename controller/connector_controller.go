@@ -3,8 +3,7 @@ package controller
 import (
 	"fmt"
 	"github.com/lawyer/commons/base/handler"
-	"github.com/lawyer/commons/site"
-	"github.com/lawyer/middleware"
+	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/service"
 	"net/http"
 
@@ -15,7 +14,7 @@ import (
 )
 
 const (
-	commonRouterPrefix            = "/answer/api/v1"
+	commonRouterPrefix            = "/lawyer"
 	ConnectorLoginRouterPrefix    = "/connector/login/"
 	ConnectorRedirectRouterPrefix = "/connector/redirect/"
 )
@@ -79,20 +78,32 @@ func (cc *ConnectorController) ConnectorRedirectDispatcher(ctx *gin.Context) {
 
 func (cc *ConnectorController) ConnectorLogin(connector plugin.Connector) (fn func(ctx *gin.Context)) {
 	return func(ctx *gin.Context) {
-		general := site.Config.GetSiteGeneral()
+		general, err := service.SiteInfoServicer.GetSiteGeneral(ctx)
+		if err != nil {
+			log.Error(err)
+			ctx.Redirect(http.StatusFound, "/50x")
+			return
+		}
 
 		receiverURL := fmt.Sprintf("%s%s%s%s", general.SiteUrl,
 			commonRouterPrefix, ConnectorRedirectRouterPrefix, connector.ConnectorSlugName())
 		redirectURL := connector.ConnectorSender(ctx, receiverURL)
-		if len(redirectURL) > 0 {
-			ctx.Redirect(http.StatusFound, redirectURL)
+		if len(redirectURL) == 0 {
+			ctx.Redirect(http.StatusFound, "/50x")
+			return
 		}
+		ctx.Redirect(http.StatusFound, redirectURL)
 	}
 }
 
 func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn func(ctx *gin.Context)) {
 	return func(ctx *gin.Context) {
-		siteGeneral := site.Config.GetSiteGeneral()
+		siteGeneral, err := service.SiteInfoServicer.GetSiteGeneral(ctx)
+		if err != nil {
+			log.Error(err)
+			ctx.Redirect(http.StatusFound, "/50x")
+			return
+		}
 		receiverURL := fmt.Sprintf("%s%s%s%s", siteGeneral.SiteUrl,
 			commonRouterPrefix, ConnectorRedirectRouterPrefix, connector.ConnectorSlugName())
 		userInfo, err := connector.ConnectorReceiver(ctx, receiverURL)
@@ -121,9 +132,18 @@ func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn
 			ctx.Redirect(http.StatusFound, fmt.Sprintf("/50x?title=%s&msg=%s", resp.ErrTitle, resp.ErrMsg))
 			return
 		}
-		if len(resp.AccessToken) > 0 {
-			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
-				siteGeneral.SiteUrl, resp.AccessToken))
+		if len(resp.ChallengeToken) > 0 {
+			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?challenge_token=%s&two_factor_setup_required=%t",
+				siteGeneral.SiteUrl, resp.ChallengeToken, resp.TwoFactorSetupRequired))
+		} else if len(resp.AccessToken) > 0 {
+			// the tokens are got by the single-use code, they never go into the url
+			code, err := service.AuthServicer.NewLoginExchange(ctx, resp.AccessToken, resp.RefreshToken)
+			if err != nil {
+				log.Errorf("external login failed: %v", err)
+				ctx.Redirect(http.StatusFound, "/50x")
+				return
+			}
+			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?code=%s", siteGeneral.SiteUrl, code))
 		} else {
 			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/confirm-email?binding_key=%s",
 				siteGeneral.SiteUrl, resp.BindingKey))
@@ -140,7 +160,11 @@ func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn
 // @Success 200 {object} handler.RespBody{data=[]schema.ConnectorInfoResp}
 // @Router /answer/api/v1/connector/info [get]
 func (cc *ConnectorController) ConnectorsInfo(ctx *gin.Context) {
-	general := site.Config.GetSiteGeneral()
+	general, err := service.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 	resp := make([]*schema.ConnectorInfoResp, 0)
 	_ = plugin.CallConnector(func(fn plugin.Connector) error {
 		connectorName := fn.ConnectorName()
@@ -183,8 +207,12 @@ func (cc *ConnectorController) ExternalLoginBindingUserSendEmail(ctx *gin.Contex
 // @Success 200 {object} handler.RespBody{data=[]schema.ConnectorUserInfoResp}
 // @Router /answer/api/v1/connector/user/info [get]
 func (cc *ConnectorController) ConnectorsUserInfo(ctx *gin.Context) {
-	general := site.Config.GetSiteGeneral()
-	userID := middleware.GetLoginUserIDFromContext(ctx)
+	general, err := service.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	userID := utils.GetUidFromTokenByCtx(ctx)
 
 	userInfoList, err := cc.userExternalService.GetExternalLoginUserInfoList(ctx, userID)
 	if err != nil {
@@ -229,7 +257,7 @@ func (cc *ConnectorController) ExternalLoginUnbinding(ctx *gin.Context) {
 		return
 	}
 
-	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
 
 	resp, err := cc.userExternalService.ExternalLoginUnbinding(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
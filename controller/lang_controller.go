@@ -5,7 +5,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/lawyer/commons/base/handler"
 	"github.com/lawyer/commons/base/translator"
-	"github.com/lawyer/commons/schema"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/service"
 )
@@ -46,11 +45,11 @@ func (u *LangController) GetAdminLangOptions(ctx *gin.Context) {
 // @Success 200 {object} handler.RespBody{}
 // @Router /answer/api/v1/language/options [get]
 func (u *LangController) GetUserLangOptions(ctx *gin.Context) {
-	//siteInterfaceResp, err := service.SiteInfoServicer.GetSiteInterface(ctx)
-	//todo 改成配置文件的方式即可，不需要走db
-	siteInterfaceResp := &schema.SiteInterfaceResp{}
-	siteInterfaceResp.Language = "en-us"
-	siteInterfaceResp.TimeZone = "UTC"
+	siteInterfaceResp, err := service.SiteInfoServicer.GetSiteInterface(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	options := translator.LanguageOptions
 	if len(siteInterfaceResp.Language) > 0 {
@@ -3,7 +3,6 @@ package controller
 import (
 	"fmt"
 	"github.com/lawyer/commons/base/handler"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/middleware"
 	services "github.com/lawyer/service"
 	"net/http"
@@ -40,7 +39,11 @@ func (uc *UserCenterController) UserCenterAgent(ctx *gin.Context) {
 		handler.HandleResponse(ctx, nil, resp)
 		return
 	}
-	siteGeneral := site.Config.GetSiteGeneral()
+	siteGeneral, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 	resp.AgentInfo = &schema.AgentInfo{}
 	resp.AgentInfo.LoginRedirectURL = fmt.Sprintf("%s%s%s", siteGeneral.SiteUrl,
 		commonRouterPrefix, UserCenterLoginRouter)
@@ -101,7 +104,12 @@ func (uc *UserCenterController) UserCenterSignUpRedirect(ctx *gin.Context) {
 }
 
 func (uc *UserCenterController) UserCenterLoginCallback(ctx *gin.Context) {
-	siteGeneral := site.Config.GetSiteGeneral()
+	siteGeneral, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
 	userCenter, ok := plugin.GetUserCenter()
 	if !ok {
 		ctx.Redirect(http.StatusFound, "/404")
@@ -127,12 +135,16 @@ func (uc *UserCenterController) UserCenterLoginCallback(ctx *gin.Context) {
 		return
 	}
 	userCenter.AfterLogin(userInfo.ExternalID, resp.AccessToken)
-	ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
-		siteGeneral.SiteUrl, resp.AccessToken))
+	uc.redirectLogin(ctx, siteGeneral.SiteUrl, resp)
 }
 
 func (uc *UserCenterController) UserCenterSignUpCallback(ctx *gin.Context) {
-	siteGeneral := site.Config.GetSiteGeneral()
+	siteGeneral, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
 	userCenter, ok := plugin.GetUserCenter()
 	if !ok {
 		ctx.Redirect(http.StatusFound, "/404")
@@ -156,8 +168,18 @@ func (uc *UserCenterController) UserCenterSignUpCallback(ctx *gin.Context) {
 		return
 	}
 	userCenter.AfterLogin(userInfo.ExternalID, resp.AccessToken)
-	ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
-		siteGeneral.SiteUrl, resp.AccessToken))
+	uc.redirectLogin(ctx, siteGeneral.SiteUrl, resp)
+}
+
+// redirectLogin redirect to the frontend with the single-use code of the tokens, they never go into the url
+func (uc *UserCenterController) redirectLogin(ctx *gin.Context, siteURL string, resp *schema.UserExternalLoginResp) {
+	code, err := services.AuthServicer.NewLoginExchange(ctx, resp.AccessToken, resp.RefreshToken)
+	if err != nil {
+		log.Errorf("external login failed: %v", err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
+	ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?code=%s", siteURL, code))
 }
 
 // UserCenterUserSettings user center user settings
@@ -61,9 +61,7 @@ func (qc *QuestionController) RemoveQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	//req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
-	//isAdmin := middleware.GetUserIsAdminModerator(ctx)
-	//if !isAdmin {
+	req.IsAdmin = service.RankServicer.CheckObjectPower(ctx, req.UserID, permission.QuestionDelete, req.ID)
 	captchaPass := service.CaptchaServicer.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionDelete, req.UserID, req.CaptchaID, req.CaptchaCode)
 	if !captchaPass {
 		handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), nil)
@@ -144,7 +142,7 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := service.RankServicer.CheckOperationPermission(ctx, req.UserID, permission.QuestionClose, "")
+	can, err := service.RankServicer.CheckObjectPermission(ctx, req.UserID, permission.QuestionClose, req.ID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -175,7 +173,7 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	}
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := service.RankServicer.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	can, err := service.RankServicer.CheckObjectPermission(ctx, req.UserID, permission.QuestionReopen, req.QuestionID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -205,7 +203,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	userID := utils.GetUidFromTokenByCtx(ctx)
 	req := schema.QuestionPermission{}
 	//
-	canList, err := service.RankServicer.CheckOperationPermissions(ctx, userID, []string{
+	canList, err := service.RankServicer.CheckObjectPermissions(ctx, userID, id, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionClose,
@@ -235,7 +233,12 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	req.CanInviteOtherToAnswer = canList[8]
 	req.CanRecover = canList[9]
 
-	info, err := service.QuestionServicer.GetQuestionAndAddPV(ctx, id, userID, req)
+	info, err := service.QuestionServicer.GetQuestionAndAddPV(ctx, id, userID, req, &schema.AddQuestionViewReq{
+		QuestionID: id,
+		UserID:     userID,
+		IP:         ctx.ClientIP(),
+		UserAgent:  ctx.Request.UserAgent(),
+	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -246,6 +249,26 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	handler.HandleResponse(ctx, nil, info)
 }
 
+// GetQuestionViewTrend get the daily views of the question
+// @Summary get the daily views of the question
+// @Description get the daily views and unique views of the question between the dates
+// @Tags Question
+// @Security ApiKeyAuth
+// @Produce json
+// @Param question_id query string true "question id"
+// @Param start_date query string true "start date, format is 2006-01-02"
+// @Param end_date query string true "end date, format is 2006-01-02"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionDailyView}
+// @Router /answer/api/v1/question/view/trend [get]
+func (qc *QuestionController) GetQuestionViewTrend(ctx *gin.Context) {
+	req := &schema.GetQuestionViewTrendReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := service.QuestionServicer.GetQuestionViewTrend(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetQuestionInviteUserInfo get question invite user info
 // @Summary get question invite user info
 // @Description get question invite user info
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/service"
+)
+
+// ResponseTemplateController response template controller, need login
+type ResponseTemplateController struct {
+}
+
+// NewResponseTemplateController new controller
+func NewResponseTemplateController() *ResponseTemplateController {
+	return &ResponseTemplateController{}
+}
+
+// GetTemplateList get the response templates the user can use
+// @Summary get response template list
+// @Description get the site-wide templates and the templates of the user, the most used first
+// @Security ApiKeyAuth
+// @Tags ResponseTemplate
+// @Produce json
+// @Param scope query string false "answer or comment"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetResponseTemplateResp}
+// @Router /answer/api/v1/response/templates [get]
+func (tc *ResponseTemplateController) GetTemplateList(ctx *gin.Context) {
+	req := &schema.GetResponseTemplateListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := service.ResponseTemplateServicer.GetTemplateList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddTemplate add response template
+// @Summary add response template
+// @Description add the template of the user, or the site-wide template by admin and moderator
+// @Security ApiKeyAuth
+// @Tags ResponseTemplate
+// @Accept json
+// @Produce json
+// @Param data body schema.AddResponseTemplateReq true "response template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/response/template [post]
+func (tc *ResponseTemplateController) AddTemplate(ctx *gin.Context) {
+	req := &schema.AddResponseTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := service.ResponseTemplateServicer.AddTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateTemplate update response template
+// @Summary update response template
+// @Description update response template
+// @Security ApiKeyAuth
+// @Tags ResponseTemplate
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateResponseTemplateReq true "response template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/response/template [put]
+func (tc *ResponseTemplateController) UpdateTemplate(ctx *gin.Context) {
+	req := &schema.UpdateResponseTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := service.ResponseTemplateServicer.UpdateTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveTemplate remove response template
+// @Summary remove response template
+// @Description remove response template
+// @Security ApiKeyAuth
+// @Tags ResponseTemplate
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveResponseTemplateReq true "response template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/response/template [delete]
+func (tc *ResponseTemplateController) RemoveTemplate(ctx *gin.Context) {
+	req := &schema.RemoveResponseTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := service.ResponseTemplateServicer.RemoveTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RenderTemplate render response template with the question
+// @Summary render response template
+// @Description replace the variables in the template with the asker name, tags and link of the question
+// @Security ApiKeyAuth
+// @Tags ResponseTemplate
+// @Produce json
+// @Param id query int true "template id"
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.RenderResponseTemplateResp}
+// @Router /answer/api/v1/response/template/render [get]
+func (tc *ResponseTemplateController) RenderTemplate(ctx *gin.Context) {
+	req := &schema.RenderResponseTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := service.ResponseTemplateServicer.RenderTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
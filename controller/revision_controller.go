@@ -7,6 +7,7 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/middleware"
 	"github.com/lawyer/pkg/obj"
 	"github.com/lawyer/pkg/uid"
@@ -94,6 +95,40 @@ func (rc *RevisionController) GetUnreviewedRevisionList(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetRevisionDiff godoc
+// @Summary get revision diff
+// @Description diff two revisions of the same question, answer or tag word by word, the tags are diffed as set.
+// @Description if old_id is empty the new revision is diffed with the previous approved revision
+// @Tags Revision
+// @Produce json
+// @Security ApiKeyAuth
+// @Param new_id query string true "new revision id"
+// @Param old_id query string false "old revision id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRevisionDiffResp}
+// @Router /answer/api/v1/revisions/diff [get]
+func (rc *RevisionController) GetRevisionDiff(ctx *gin.Context) {
+	req := &schema.GetRevisionDiffReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	canList, err := rc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.QuestionAudit,
+		permission.AnswerAudit,
+		permission.TagAudit,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanReviewQuestion = canList[0]
+	req.CanReviewAnswer = canList[1]
+	req.CanReviewTag = canList[2]
+
+	resp, err := rc.revisionListService.GetRevisionDiff(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // RevisionAudit godoc
 // @Summary revision audit
 // @Description revision audit operation:approve or reject
@@ -108,7 +143,7 @@ func (rc *RevisionController) RevisionAudit(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
-	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
 	canList, err := rc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
 		permission.QuestionAudit,
 		permission.AnswerAudit,
@@ -126,6 +161,27 @@ func (rc *RevisionController) RevisionAudit(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, gin.H{})
 }
 
+// RollbackRevision godoc
+// @Summary rollback revision
+// @Description restore the published revision of the question, answer or tag as a new revision,
+// @Description the new revision is reviewed if the user can not edit without review
+// @Tags Revision
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RollbackRevisionReq true "rollback"
+// @Success 200 {object} handler.RespBody{data=schema.RollbackRevisionResp}
+// @Router /answer/api/v1/revisions/rollback [post]
+func (rc *RevisionController) RollbackRevision(ctx *gin.Context) {
+	req := &schema.RollbackRevisionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := rc.revisionListService.RollbackRevision(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // CheckCanUpdateRevision check can update revision
 // @Summary check can update revision
 // @Description check can update revision
@@ -1,90 +1,79 @@
 package controller
 
-/*
-
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/lawyer/commons/base/handler"
-	constant "github.com/lawyer/commons/constant"
-	"github.com/lawyer/commons/utils/checker"
-	services "github.com/lawyer/initServer/initServices"
-	"html/template"
 	"net/http"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/commons/utils/checker"
 	templaterender "github.com/lawyer/controller/template_render"
 	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/pkg/feed"
 	"github.com/lawyer/pkg/htmltext"
 	"github.com/lawyer/pkg/obj"
 	"github.com/lawyer/pkg/uid"
+	"github.com/lawyer/service"
 	"github.com/segmentfault/pacman/log"
 )
 
 var SiteUrl = ""
 
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+)
+
+// TemplateController the server side rendered pages for the crawlers
 type TemplateController struct {
-	scriptPath string
-	cssPath    string
-	//templateRenderController *templaterender.TemplateRenderController
-	//siteInfoService          siteinfo_common.SiteInfoCommonService
+	templateRenderController *templaterender.TemplateRenderController
 }
 
 // NewTemplateController new controller
 func NewTemplateController() *TemplateController {
-	script, css := GetStyle()
 	return &TemplateController{
-		scriptPath: script,
-		cssPath:    css,
+		templateRenderController: templaterender.NewTemplateRenderController(),
 	}
 }
-func GetStyle() (script, css string) {
-	//file, err := ui.Build.ReadFile("build/index.html")
-	//if err != nil {
-	//	return
-	//}
-	//scriptRegexp := regexp.MustCompile(`<script defer="defer" src="(.*)"></script>`)
-	//scriptData := scriptRegexp.FindStringSubmatch(string(file))
-	//cssRegexp := regexp.MustCompile(`<link href="(.*)" rel="stylesheet">`)
-	//cssListData := cssRegexp.FindStringSubmatch(string(file))
-	//if len(scriptData) == 2 {
-	//	script = scriptData[1]
-	//}
-	//if len(cssListData) == 2 {
-	//	css = cssListData[1]
-	//}
-	return
-}
+
 func (tc *TemplateController) SiteInfo(ctx *gin.Context) *schema.TemplateSiteInfoResp {
 	var err error
 	resp := &schema.TemplateSiteInfoResp{}
-	resp.General, err = services.SiteInfoService.GetSiteGeneral(ctx)
+	resp.General, err = service.SiteInfoServicer.GetSiteGeneral(ctx)
 	if err != nil {
 		log.Error(err)
+		resp.General = &schema.SiteGeneralResp{}
 	}
 	SiteUrl = resp.General.SiteUrl
-	resp.Interface, err = services.SiteInfoService.GetSiteInterface(ctx)
+	resp.Interface, err = service.SiteInfoServicer.GetSiteInterface(ctx)
 	if err != nil {
 		log.Error(err)
+		resp.Interface = &schema.SiteInterfaceResp{}
 	}
 
-	resp.Branding, err = services.SiteInfoService.GetSiteBranding(ctx)
+	resp.Branding, err = service.SiteInfoServicer.GetSiteBranding(ctx)
 	if err != nil {
 		log.Error(err)
+		resp.Branding = &schema.SiteBrandingResp{}
 	}
 
-	resp.SiteSeo, err = services.SiteInfoCommonService.GetSiteSeo(ctx)
+	resp.SiteSeo, err = service.SiteInfoServicer.GetSiteSeo(ctx)
 	if err != nil {
 		log.Error(err)
+		resp.SiteSeo = &schema.SiteSeoResp{}
 	}
 
-	resp.CustomCssHtml, err = services.SiteInfoService.GetSiteCustomCssHTML(ctx)
+	resp.CustomCssHtml, err = service.SiteInfoServicer.GetSiteCustomCssHTML(ctx)
 	if err != nil {
 		log.Error(err)
+		resp.CustomCssHtml = &schema.SiteCustomCssHTMLResp{}
 	}
 	resp.Year = fmt.Sprintf("%d", time.Now().Year())
 	return resp
@@ -92,6 +81,10 @@ func (tc *TemplateController) SiteInfo(ctx *gin.Context) *schema.TemplateSiteInf
 
 // Index question list
 func (tc *TemplateController) Index(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
 	req := &schema.QuestionPageReq{
 		OrderCond: "newest",
 	}
@@ -110,21 +103,20 @@ func (tc *TemplateController) Index(ctx *gin.Context) {
 
 	site := tc.SiteInfo(ctx)
 	site.Canonical = site.General.SiteUrl
-
-	UrlUseTitle := false
-	if site.SiteSeo.Permalink == constant.PermalinkQuestionIDAndTitle {
-		UrlUseTitle = true
-	}
 	site.Title = ""
 	tc.html(ctx, http.StatusOK, "question.html", site, gin.H{
-		"data":     data,
-		"useTitle": UrlUseTitle,
-		"page":     templaterender.Paginator(page, req.PageSize, count),
-		"path":     "questions",
+		"questions": data,
+		"page":      templaterender.Paginator(page, req.PageSize, count),
+		"pageURL":   fmt.Sprintf("%s/questions", site.General.SiteUrl),
+		"path":      "questions",
 	})
 }
 
 func (tc *TemplateController) QuestionList(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
 	req := &schema.QuestionPageReq{
 		OrderCond: "newest",
 	}
@@ -143,16 +135,12 @@ func (tc *TemplateController) QuestionList(ctx *gin.Context) {
 	if page > 1 {
 		site.Canonical = fmt.Sprintf("%s/questions?page=%d", site.General.SiteUrl, page)
 	}
-
-	UrlUseTitle := false
-	if site.SiteSeo.Permalink == constant.PermalinkQuestionIDAndTitle {
-		UrlUseTitle = true
-	}
 	site.Title = fmt.Sprintf("Questions - %s", site.General.Name)
 	tc.html(ctx, http.StatusOK, "question.html", site, gin.H{
-		"data":     data,
-		"useTitle": UrlUseTitle,
-		"page":     templaterender.Paginator(page, req.PageSize, count),
+		"questions": data,
+		"page":      templaterender.Paginator(page, req.PageSize, count),
+		"pageURL":   fmt.Sprintf("%s/questions", site.General.SiteUrl),
+		"path":      "questions",
 	})
 }
 
@@ -168,12 +156,8 @@ func (tc *TemplateController) QuestionInfoeRdirect(ctx *gin.Context, site *schem
 		titleIsAnswerID = true
 	}
 
-	siteSeo, err := services.SiteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		return false, ""
-	}
 	isShortID := uid.IsShortID(questionID)
-	if siteSeo.IsShortLink() {
+	if site.SiteSeo.IsShortLink() {
 		if !isShortID {
 			questionID = uid.EnShortID(questionID)
 			needChangeShortID = true
@@ -236,16 +220,8 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	id := ctx.Param("id")
 	title := ctx.Param("title")
 	answerid := ctx.Param("answerid")
-	if checker.IsQuestionsIgnorePath(id) {
-		// if id == "ask" {
-		//file, err := ui.Build.ReadFile("build/index.html")
-		//if err != nil {
-		//	log.Error(err)
-		//	tc.Page404(ctx)
-		//	return
-		//}
-		ctx.Header("content-type", "text/html;charset=utf-8")
-		//ctx.String(http.StatusOK, string(file))
+	if checker.IsQuestionsIgnorePath(id) || tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
 		return
 	}
 
@@ -263,6 +239,9 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 
 	site := tc.SiteInfo(ctx)
 	jump, jumpurl := tc.QuestionInfoeRdirect(ctx, site, correctTitle)
+	if ctx.IsAborted() || ctx.Writer.Written() {
+		return
+	}
 	if jump {
 		ctx.Redirect(http.StatusFound, jumpurl)
 		return
@@ -276,7 +255,7 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 		PageSize:   999,
 		UserID:     "",
 	}
-	answers, answerCount, err := tc.templateRenderController.AnswerList(ctx, answerReq)
+	answers, _, err := tc.templateRenderController.AnswerList(ctx, answerReq)
 	if err != nil {
 		tc.Page404(ctx)
 		return
@@ -298,46 +277,12 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	if site.SiteSeo.Permalink == constant.PermalinkQuestionID || site.SiteSeo.Permalink == constant.PermalinkQuestionIDByShortID {
 		site.Canonical = fmt.Sprintf("%s/questions/%s", site.General.SiteUrl, id)
 	}
-	jsonLD := &schema.QAPageJsonLD{}
-	jsonLD.Context = "https://schema.org"
-	jsonLD.Type = "QAPage"
-	jsonLD.MainEntity.Type = "Question"
-	jsonLD.MainEntity.Name = detail.Title
-	jsonLD.MainEntity.Text = detail.HTML
-	jsonLD.MainEntity.AnswerCount = int(answerCount)
-	jsonLD.MainEntity.UpvoteCount = detail.VoteCount
-	jsonLD.MainEntity.DateCreated = time.Unix(detail.CreateTime, 0)
-	jsonLD.MainEntity.Author.Type = "Person"
-	jsonLD.MainEntity.Author.Name = detail.UserInfo.DisplayName
-	answerList := make([]*schema.SuggestedAnswerItem, 0)
-	for _, answer := range answers {
-		if answer.Accepted == schema.AnswerAcceptedEnable {
-			acceptedAnswerItem := &schema.AcceptedAnswerItem{}
-			acceptedAnswerItem.Type = "Answer"
-			acceptedAnswerItem.Text = answer.HTML
-			acceptedAnswerItem.DateCreated = time.Unix(answer.CreateTime, 0)
-			acceptedAnswerItem.UpvoteCount = answer.VoteCount
-			acceptedAnswerItem.URL = fmt.Sprintf("%s/%s", site.Canonical, answer.ID)
-			acceptedAnswerItem.Author.Type = "Person"
-			acceptedAnswerItem.Author.Name = answer.UserInfo.DisplayName
-			jsonLD.MainEntity.AcceptedAnswer = acceptedAnswerItem
-		} else {
-			item := &schema.SuggestedAnswerItem{}
-			item.Type = "Answer"
-			item.Text = answer.HTML
-			item.DateCreated = time.Unix(answer.CreateTime, 0)
-			item.UpvoteCount = answer.VoteCount
-			item.URL = fmt.Sprintf("%s/%s", site.Canonical, answer.ID)
-			item.Author.Type = "Person"
-			item.Author.Name = answer.UserInfo.DisplayName
-			answerList = append(answerList, item)
-		}
-
-	}
-	jsonLD.MainEntity.SuggestedAnswer = answerList
-	jsonLDStr, err := json.Marshal(jsonLD)
-	if err == nil {
-		site.JsonLD = `<script data-react-helmet="true" type="application/ld+json">` + string(jsonLDStr) + ` </script>`
+	// the hidden questions are reachable by the link but are kept out of the search results
+	if detail.Show == entity.QuestionHide {
+		site.NoIndex = true
+	} else {
+		site.JsonLD = tc.templateRenderController.QuestionJsonLD(detail, answers, site.General.SiteUrl, site.Canonical)
+		site.OpenGraph = tc.templateRenderController.QuestionOpenGraph(detail, site)
 	}
 
 	site.Description = htmltext.FetchExcerpt(detail.HTML, "...", 240)
@@ -348,16 +293,21 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	site.Keywords = strings.Replace(strings.Trim(fmt.Sprint(tags), "[]"), " ", ",", -1)
 	site.Title = fmt.Sprintf("%s - %s", detail.Title, site.General.Name)
 	tc.html(ctx, http.StatusOK, "question-detail.html", site, gin.H{
-		"id":       id,
-		"answerid": answerid,
-		"detail":   detail,
-		"answers":  answers,
-		"comments": comments,
+		"id":         id,
+		"answerid":   answerid,
+		"detail":     detail,
+		"answers":    answers,
+		"comments":   comments,
+		"disclaimer": tc.templateRenderController.AnswerDisclaimer(ctx, id),
 	})
 }
 
 // TagList tags list
 func (tc *TemplateController) TagList(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
 	req := &schema.GetTagWithPageReq{}
 	if handler.BindAndCheck(ctx, req) {
 		return
@@ -376,13 +326,19 @@ func (tc *TemplateController) TagList(ctx *gin.Context) {
 	}
 	site.Title = fmt.Sprintf("%s - %s", "Tags", site.General.Name)
 	tc.html(ctx, http.StatusOK, "tags.html", site, gin.H{
-		"page": page,
-		"data": data,
+		"page":    page,
+		"pageURL": fmt.Sprintf("%s/tags", site.General.SiteUrl),
+		"data":    data,
+		"path":    "tags",
 	})
 }
 
 // TagInfo taginfo
 func (tc *TemplateController) TagInfo(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
 	tag := ctx.Param("tag")
 	req := &schema.GetTamplateTagInfoReq{}
 	if handler.BindAndCheck(ctx, req) {
@@ -408,41 +364,24 @@ func (tc *TemplateController) TagInfo(ctx *gin.Context) {
 		site.Description = "The tag has no description."
 	}
 	site.Keywords = taginifo.DisplayName
-
-	UrlUseTitle := false
-	if site.SiteSeo.Permalink == constant.PermalinkQuestionIDAndTitle {
-		UrlUseTitle = true
-	}
 	site.Title = fmt.Sprintf("'%s' Questions - %s", taginifo.DisplayName, site.General.Name)
 	tc.html(ctx, http.StatusOK, "tag-detail.html", site, gin.H{
 		"tag":           taginifo,
-		"questionList":  questionList,
+		"questions":     questionList,
 		"questionCount": questionCount,
-		"useTitle":      UrlUseTitle,
 		"page":          page,
+		"pageURL":       fmt.Sprintf("%s/tags/%s", site.General.SiteUrl, tag),
+		"path":          "tags",
 	})
 }
 
 // UserInfo user info
 func (tc *TemplateController) UserInfo(ctx *gin.Context) {
 	username := ctx.Param("username")
-	if username == "" {
+	if username == "" || checker.IsUsersIgnorePath(username) || tc.checkPrivateMode(ctx) {
 		tc.Page404(ctx)
 		return
 	}
-
-	exist := checker.IsUsersIgnorePath(username)
-	if exist {
-		//file, err := ui.Build.ReadFile("build/index.html")
-		//if err != nil {
-		//	log.Error(err)
-		//	tc.Page404(ctx)
-		//	return
-		//}
-		ctx.Header("content-type", "text/html;charset=utf-8")
-		//ctx.String(http.StatusOK, string(file))
-		return
-	}
 	req := &schema.GetOtherUserInfoByUsernameReq{}
 	req.Username = username
 	userinfo, err := tc.templateRenderController.UserInfo(ctx, req)
@@ -456,7 +395,7 @@ func (tc *TemplateController) UserInfo(ctx *gin.Context) {
 	site.Title = fmt.Sprintf("%s - %s", username, site.General.Name)
 	tc.html(ctx, http.StatusOK, "homepage.html", site, gin.H{
 		"userinfo": userinfo,
-		"bio":      template.HTML(userinfo.BioHTML),
+		"path":     "users",
 	})
 
 }
@@ -467,8 +406,6 @@ func (tc *TemplateController) Page404(ctx *gin.Context) {
 
 func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, site *schema.TemplateSiteInfoResp, data gin.H) {
 	data["siteinfo"] = site
-	data["scriptPath"] = tc.scriptPath
-	data["cssPath"] = tc.cssPath
 	data["keywords"] = site.Keywords
 	if site.Description == "" {
 		site.Description = site.General.Description
@@ -482,6 +419,7 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, site
 	data["timezone"] = site.Interface.TimeZone
 	language := strings.Replace(site.Interface.Language, "_", "-", -1)
 	data["lang"] = language
+	data["permalink"] = site.SiteSeo.Permalink
 	data["HeadCode"] = site.CustomCssHtml.CustomHead
 	data["HeaderCode"] = site.CustomCssHtml.CustomHeader
 	data["FooterCode"] = site.CustomCssHtml.CustomFooter
@@ -495,14 +433,22 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, site
 	ctx.HTML(code, tpl, data)
 }
 
+// Sitemap the sitemap or the sitemap index when the questions need more than one sitemap
 func (tc *TemplateController) Sitemap(ctx *gin.Context) {
 	if tc.checkPrivateMode(ctx) {
 		tc.Page404(ctx)
 		return
 	}
-	tc.templateRenderController.Sitemap(ctx)
+	content, err := tc.templateRenderController.Sitemap(ctx)
+	if err != nil {
+		log.Errorf("get sitemap failed: %v", err)
+		tc.Page404(ctx)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/xml; charset=utf-8", content)
 }
 
+// SitemapPage the sitemap page of the sitemap index
 func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
 	if tc.checkPrivateMode(ctx) {
 		tc.Page404(ctx)
@@ -521,15 +467,73 @@ func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
 		tc.Page404(ctx)
 		return
 	}
-	err := tc.templateRenderController.SitemapPage(ctx, page)
+	content, err := tc.templateRenderController.SitemapPage(ctx, page)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/xml; charset=utf-8", content)
+}
+
+// QuestionFeed the rss or atom feed of the newest questions
+func (tc *TemplateController) QuestionFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	f, err := tc.templateRenderController.NewestQuestionFeed(ctx)
+	tc.feed(ctx, f, err)
+}
+
+// TagFeed the rss or atom feed of the newest questions of the tag
+func (tc *TemplateController) TagFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	f, err := tc.templateRenderController.TagQuestionFeed(ctx, ctx.Param("tag"))
+	tc.feed(ctx, f, err)
+}
+
+// UserFeed the rss or atom feed of the latest questions and answers of the user
+func (tc *TemplateController) UserFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	f, err := tc.templateRenderController.UserActivityFeed(ctx, ctx.Param("username"))
+	tc.feed(ctx, f, err)
+}
+
+func (tc *TemplateController) feed(ctx *gin.Context, f *feed.Feed, err error) {
+	if err != nil {
+		log.Errorf("get feed failed: %v", err)
+		tc.Page404(ctx)
+		return
+	}
+	var content []byte
+	var contentType string
+	switch ctx.Param("format") {
+	case feedFormatRSS:
+		content, err = f.RSS()
+		contentType = feed.RSSContentType
+	case feedFormatAtom:
+		content, err = f.Atom()
+		contentType = feed.AtomContentType
+	default:
+		tc.Page404(ctx)
+		return
+	}
 	if err != nil {
+		log.Errorf("encode feed failed: %v", err)
 		tc.Page404(ctx)
 		return
 	}
+	ctx.Data(http.StatusOK, contentType, content)
 }
 
 func (tc *TemplateController) checkPrivateMode(ctx *gin.Context) bool {
-	resp, err := services.SiteInfoService.GetSiteLogin(ctx)
+	resp, err := service.SiteInfoServicer.GetSiteLogin(ctx)
 	if err != nil {
 		log.Error(err)
 		return false
@@ -539,4 +543,3 @@ func (tc *TemplateController) checkPrivateMode(ctx *gin.Context) bool {
 	}
 	return false
 }
-*/
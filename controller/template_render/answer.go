@@ -2,6 +2,7 @@ package templaterender
 
 import (
 	"context"
+	"github.com/lawyer/pkg/uid"
 	services "github.com/lawyer/service"
 
 	"github.com/lawyer/commons/schema"
@@ -10,3 +11,8 @@ import (
 func (t *TemplateRenderController) AnswerList(ctx context.Context, req *schema.AnswerListReq) ([]*schema.AnswerInfo, int64, error) {
 	return services.AnswerServicer.SearchList(ctx, req)
 }
+
+// AnswerDisclaimer the disclaimer rendered in the answers of the question, nil if there is none
+func (t *TemplateRenderController) AnswerDisclaimer(ctx context.Context, questionID string) *schema.AnswerDisclaimerInfo {
+	return services.AnswerDisclaimerServicer.GetQuestionDisclaimer(ctx, uid.DeShortID(questionID))
+}
@@ -0,0 +1,187 @@
+package templaterender
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/pkg/display"
+	"github.com/lawyer/pkg/feed"
+	services "github.com/lawyer/service"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// feedSize the number of the latest items in the feed
+const feedSize = 20
+
+// NewestQuestionFeed the feed of the newest questions
+func (t *TemplateRenderController) NewestQuestionFeed(ctx *gin.Context) (f *feed.Feed, err error) {
+	general, siteSeo, err := t.feedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questions, _, err := services.QuestionServicer.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:      1,
+		PageSize:  feedSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	f = &feed.Feed{
+		Title:       fmt.Sprintf("Newest questions - %s", general.Name),
+		Link:        fmt.Sprintf("%s/questions", general.SiteUrl),
+		Description: general.Description,
+		Items:       questionFeedItems(questions, siteSeo.Permalink, general.SiteUrl),
+	}
+	f.Updated = latestUpdated(f.Items)
+	return f, nil
+}
+
+// TagQuestionFeed the feed of the newest questions of the tag
+func (t *TemplateRenderController) TagQuestionFeed(ctx *gin.Context, tagName string) (f *feed.Feed, err error) {
+	general, siteSeo, err := t.feedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagName = strings.ToLower(tagName)
+	tagInfo, exist, err := services.TagServicer.GetTagBySlugName(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.TagNotFound)
+	}
+	questions, _, err := services.QuestionServicer.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:      1,
+		PageSize:  feedSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+		Tag:       tagName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	f = &feed.Feed{
+		Title:       fmt.Sprintf("'%s' Questions - %s", tagInfo.DisplayName, general.Name),
+		Link:        fmt.Sprintf("%s/tags/%s", general.SiteUrl, tagInfo.SlugName),
+		Description: tagInfo.OriginalText,
+		Items:       questionFeedItems(questions, siteSeo.Permalink, general.SiteUrl),
+	}
+	f.Updated = latestUpdated(f.Items)
+	return f, nil
+}
+
+// UserActivityFeed the feed of the latest questions and answers of the user
+func (t *TemplateRenderController) UserActivityFeed(ctx *gin.Context, username string) (f *feed.Feed, err error) {
+	general, siteSeo, err := t.feedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, exist, err := services.UserCommonServicer.GetUserBasicInfoByUserName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.UserNotFound)
+	}
+	questions, _, err := services.QuestionServicer.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:      1,
+		PageSize:  feedSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+		Username:  userInfo.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := questionFeedItems(questions, siteSeo.Permalink, general.SiteUrl)
+
+	answerPage, err := services.QuestionServicer.PersonalAnswerPage(ctx, &schema.PersonalAnswerPageReq{
+		Page:      1,
+		PageSize:  feedSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+		Username:  userInfo.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	answers, _ := answerPage.List.([]*schema.UserAnswerInfo)
+	for _, answer := range answers {
+		items = append(items, &feed.Item{
+			Title: fmt.Sprintf("Answer to: %s", answer.QuestionInfo.Title),
+			Link: display.AnswerURL(siteSeo.Permalink, general.SiteUrl, answer.QuestionID,
+				answer.QuestionInfo.Title, answer.AnswerID),
+			Author:    userInfo.DisplayName,
+			Published: time.Unix(int64(answer.CreateTime), 0),
+			Updated:   time.Unix(int64(answer.UpdateTime), 0),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published)
+	})
+	if len(items) > feedSize {
+		items = items[:feedSize]
+	}
+
+	f = &feed.Feed{
+		Title:       fmt.Sprintf("%s - %s", userInfo.DisplayName, general.Name),
+		Link:        fmt.Sprintf("%s/users/%s", general.SiteUrl, userInfo.Username),
+		Description: fmt.Sprintf("The latest questions and answers of %s", userInfo.DisplayName),
+		Items:       items,
+	}
+	f.Updated = latestUpdated(f.Items)
+	return f, nil
+}
+
+func (t *TemplateRenderController) feedSiteInfo(ctx *gin.Context) (
+	general *schema.SiteGeneralResp, siteSeo *schema.SiteSeoResp, err error) {
+	general, err = services.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	siteSeo, err = services.SiteInfoServicer.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return general, siteSeo, nil
+}
+
+func questionFeedItems(questions []*schema.QuestionPageResp, permalink int, siteUrl string) []*feed.Item {
+	items := make([]*feed.Item, 0, len(questions))
+	for _, question := range questions {
+		item := &feed.Item{
+			Title:       question.Title,
+			Link:        display.QuestionURL(permalink, siteUrl, question.ID, question.Title),
+			Description: question.Description,
+			Published:   time.Unix(question.CreatedAt, 0),
+			Updated:     time.Unix(question.OperatedAt, 0),
+		}
+		if question.Operator != nil && question.OperationType == schema.QuestionPageRespOperationTypeAsked {
+			item.Author = question.Operator.DisplayName
+		}
+		for _, tag := range question.Tags {
+			item.Categories = append(item.Categories, tag.DisplayName)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func latestUpdated(items []*feed.Item) time.Time {
+	updated := time.Time{}
+	for _, item := range items {
+		if item.Updated.After(updated) {
+			updated = item.Updated
+		}
+		if item.Published.After(updated) {
+			updated = item.Published
+		}
+	}
+	if updated.IsZero() {
+		return time.Now()
+	}
+	return updated
+}
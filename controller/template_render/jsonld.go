@@ -0,0 +1,98 @@
+package templaterender
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/pkg/htmltext"
+)
+
+// QuestionJsonLD the schema.org QAPage structured data of the question, the deleted and pending answers are left out
+func (t *TemplateRenderController) QuestionJsonLD(detail *schema.QuestionInfo, answers []*schema.AnswerInfo,
+	siteUrl, canonical string) string {
+	jsonLD := &schema.QAPageJsonLD{}
+	jsonLD.Context = "https://schema.org"
+	jsonLD.Type = "QAPage"
+	jsonLD.MainEntity.Type = "Question"
+	jsonLD.MainEntity.Name = detail.Title
+	jsonLD.MainEntity.Text = detail.HTML
+	jsonLD.MainEntity.UpvoteCount = detail.VoteCount
+	jsonLD.MainEntity.DateCreated = time.Unix(detail.CreateTime, 0)
+	jsonLD.MainEntity.URL = canonical
+	jsonLD.MainEntity.Author = jsonLDPerson(detail.UserInfo, siteUrl)
+	answerList := make([]*schema.SuggestedAnswerItem, 0)
+	for _, answer := range answers {
+		if answer.Status != entity.AnswerStatusAvailable {
+			continue
+		}
+		jsonLD.MainEntity.AnswerCount++
+		answerURL := fmt.Sprintf("%s/%s", canonical, answer.ID)
+		if answer.Accepted == schema.AnswerAcceptedEnable {
+			jsonLD.MainEntity.AcceptedAnswer = &schema.AcceptedAnswerItem{
+				Type:        "Answer",
+				Text:        answer.HTML,
+				DateCreated: time.Unix(answer.CreateTime, 0),
+				UpvoteCount: answer.VoteCount,
+				URL:         answerURL,
+				Author:      jsonLDPerson(answer.UserInfo, siteUrl),
+			}
+			continue
+		}
+		answerList = append(answerList, &schema.SuggestedAnswerItem{
+			Type:        "Answer",
+			Text:        answer.HTML,
+			DateCreated: time.Unix(answer.CreateTime, 0),
+			UpvoteCount: answer.VoteCount,
+			URL:         answerURL,
+			Author:      jsonLDPerson(answer.UserInfo, siteUrl),
+		})
+	}
+	jsonLD.MainEntity.SuggestedAnswer = answerList
+
+	// the json encoder escapes <, > and &, so the content can not close the script tag
+	jsonLDStr, err := json.Marshal(jsonLD)
+	if err != nil {
+		return ""
+	}
+	return `<script data-react-helmet="true" type="application/ld+json">` + string(jsonLDStr) + ` </script>`
+}
+
+// QuestionOpenGraph the open graph and twitter card metadata of the question, the image is the first image of the
+// question or the square icon of the site
+func (t *TemplateRenderController) QuestionOpenGraph(detail *schema.QuestionInfo, site *schema.TemplateSiteInfoResp) *schema.OpenGraph {
+	og := &schema.OpenGraph{
+		Type:        "article",
+		Title:       detail.Title,
+		Description: htmltext.FetchExcerpt(detail.HTML, "...", 200),
+		URL:         site.Canonical,
+		SiteName:    site.General.Name,
+		TwitterCard: "summary",
+	}
+	image := htmltext.FetchFirstImage(detail.HTML)
+	if len(image) > 0 {
+		og.TwitterCard = "summary_large_image"
+	} else if site.Branding != nil {
+		image = site.Branding.SquareIcon
+	}
+	if strings.HasPrefix(image, "/") {
+		image = site.General.SiteUrl + image
+	}
+	og.Image = image
+	return og
+}
+
+func jsonLDPerson(userInfo *schema.UserBasicInfo, siteUrl string) schema.JsonLDPerson {
+	person := schema.JsonLDPerson{Type: "Person"}
+	if userInfo == nil {
+		return person
+	}
+	person.Name = userInfo.DisplayName
+	if len(userInfo.Username) > 0 {
+		person.URL = fmt.Sprintf("%s/users/%s", siteUrl, userInfo.Username)
+	}
+	return person
+}
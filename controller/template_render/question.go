@@ -1,104 +1,78 @@
 package templaterender
 
-//
-//import (
-//	constant "github.com/lawyer/commons/constant"
-//	"github.com/lawyer/repo"
-//	services "github.com/lawyer/service"
-//	"html/template"
-//	"math"
-//	"net/http"
-//
-//	"github.com/gin-gonic/gin"
-//	"github.com/lawyer/commons/schema"
-//	"github.com/segmentfault/pacman/log"
-//)
-//
-//func (t *TemplateRenderController) Index(ctx *gin.Context, req *schema.QuestionPageReq) ([]*schema.QuestionPageResp, int64, error) {
-//	return services.QuestionServicer.GetQuestionPage(ctx, req)
-//}
-//
-//func (t *TemplateRenderController) QuestionDetail(ctx *gin.Context, id string) (resp *schema.QuestionInfo, err error) {
-//	return services.QuestionServicer.GetQuestion(ctx, id, "", schema.QuestionPermission{})
-//}
-//
-//func (t *TemplateRenderController) Sitemap(ctx *gin.Context) {
-//	//general, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
-//	//if err != nil {
-//	//	log.Error("get site general failed:", err)
-//	//	return
-//	//}
-//	//site, err := services.SiteInfoCommonServicer.GetSiteSeo(ctx)
-//	//if err != nil {
-//	//	log.Error("get site GetSiteSeo failed:", err)
-//	//	return
-//	//}
-//
-//	questions, err := repo.QuestionRepo.SitemapQuestions(ctx, 1, constant.SitemapMaxSize)
-//	if err != nil {
-//		log.Errorf("get sitemap questions failed: %s", err)
-//		return
-//	}
-//
-//	ctx.Header("Content-Type", "application/xml")
-//	if len(questions) < constant.SitemapMaxSize {
-//		ctx.HTML(
-//			http.StatusOK, "sitemap.xml", gin.H{
-//				"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-//				"list":      questions,
-//				"general":   general,
-//				"hastitle": site.Permalink == constant.PermalinkQuestionIDAndTitle ||
-//					site.Permalink == constant.PermalinkQuestionIDAndTitleByShortID,
-//			},
-//		)
-//		return
-//	}
-//
-//	questionNum, err := repo.QuestionRepo.GetQuestionCount(ctx)
-//	if err != nil {
-//		log.Error("GetQuestionCount error", err)
-//		return
-//	}
-//	var pageList []int
-//	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
-//	for i := 1; i <= totalPages; i++ {
-//		pageList = append(pageList, i)
-//	}
-//	ctx.HTML(
-//		http.StatusOK, "sitemap-list.xml", gin.H{
-//			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-//			"page":      pageList,
-//			"general":   general,
-//		},
-//	)
-//}
-//
-//func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, page int) error {
-//	general, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
-//	if err != nil {
-//		log.Error("get site general failed:", err)
-//		return err
-//	}
-//	site, err := services.SiteInfoCommonServicer.GetSiteSeo(ctx)
-//	if err != nil {
-//		log.Error("get site GetSiteSeo failed:", err)
-//		return err
-//	}
-//
-//	questions, err := repo.QuestionRepo.SitemapQuestions(ctx, page, constant.SitemapMaxSize)
-//	if err != nil {
-//		log.Errorf("get sitemap questions failed: %s", err)
-//		return err
-//	}
-//	ctx.Header("Content-Type", "application/xml")
-//	ctx.HTML(
-//		http.StatusOK, "sitemap.xml", gin.H{
-//			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-//			"list":      questions,
-//			"general":   general,
-//			"hastitle": site.Permalink == constant.PermalinkQuestionIDAndTitle ||
-//				site.Permalink == constant.PermalinkQuestionIDAndTitleByShortID,
-//		},
-//	)
-//	return nil
-//}
+import (
+	"fmt"
+	"math"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/pkg/display"
+	"github.com/lawyer/pkg/sitemap"
+	"github.com/lawyer/repo"
+	services "github.com/lawyer/service"
+	"github.com/segmentfault/pacman/errors"
+)
+
+func (t *TemplateRenderController) Index(ctx *gin.Context, req *schema.QuestionPageReq) ([]*schema.QuestionPageResp, int64, error) {
+	return services.QuestionServicer.GetQuestionPage(ctx, req)
+}
+
+func (t *TemplateRenderController) QuestionDetail(ctx *gin.Context, id string) (resp *schema.QuestionInfo, err error) {
+	return services.QuestionServicer.GetQuestion(ctx, id, "", schema.QuestionPermission{})
+}
+
+// Sitemap the sitemap of the questions, when there are more questions than one sitemap can hold,
+// the sitemap index of the question sitemap pages is returned instead
+func (t *TemplateRenderController) Sitemap(ctx *gin.Context) (content []byte, err error) {
+	questionNum, err := repo.QuestionRepo.GetQuestionCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if questionNum <= constant.SitemapMaxSize {
+		return t.SitemapPage(ctx, 1)
+	}
+
+	general, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
+	sitemaps := make([]*sitemap.URL, 0, totalPages)
+	for i := 1; i <= totalPages; i++ {
+		sitemaps = append(sitemaps, &sitemap.URL{Loc: fmt.Sprintf("%s/sitemap/question-%d.xml", general.SiteUrl, i)})
+	}
+	return sitemap.Index(sitemaps)
+}
+
+// SitemapPage the sitemap of the questions in the page, the question urls follow the permalink setting
+func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, page int) (content []byte, err error) {
+	general, err := services.SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	siteSeo, err := services.SiteInfoServicer.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := repo.QuestionRepo.SitemapQuestions(ctx, page, constant.SitemapMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 && page > 1 {
+		return nil, errors.NotFound(reason.ObjectNotFound)
+	}
+	urls := make([]*sitemap.URL, 0, len(questions)+1)
+	if page == 1 {
+		urls = append(urls, &sitemap.URL{Loc: general.SiteUrl})
+	}
+	for _, question := range questions {
+		urls = append(urls, &sitemap.URL{
+			Loc:     display.QuestionURL(siteSeo.Permalink, general.SiteUrl, question.ID, question.Title),
+			LastMod: question.UpdateTime,
+		})
+	}
+	return sitemap.URLSet(urls)
+}
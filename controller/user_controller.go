@@ -27,6 +27,11 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	// the password login is turned off when the magic link login is required
+	if err := service.UserServicer.CheckPasswordLogin(ctx); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 	//验证码是否正确
 	captchaPass := service.CaptchaServicer.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
 	if !captchaPass {
@@ -131,16 +136,16 @@ func (uc *UserController) UserReplacePassWord(ctx *gin.Context) {
 }
 
 /*
-退出登录，直接删除用户token就行
+退出登录，删除token对应的登录会话，已过期的access token也可以退出
 */
 // @Router /answer/api/v1/user/logout [get]
 func (uc *UserController) UserLogout(ctx *gin.Context) {
-	accessToken := utils.ExtractToken(ctx)
-	if len(accessToken) == 0 {
+	claims, err := utils.ParseTokenIgnoreExpired(ctx.GetHeader(constant.AccessTokenHeader))
+	if err != nil {
 		handler.HandleResponse(ctx, nil, nil)
 		return
 	}
-	_ = service.AuthServicer.RemoveUserCacheInfo(ctx, accessToken)
+	_ = service.AuthServicer.RevokeSession(ctx, &schema.RevokeUserSessionReq{SessionID: claims.ID, UserID: claims.Uid})
 	//_ = service.AuthServicer.RemoveAdminUserCacheInfo(ctx, accessToken)
 	//visitToken, _ := ctx.Cookie(constant.UserVisitCookiesCacheKey)
 	//_ = service.AuthServicer.RemoveUserVisitCacheInfo(ctx, visitToken)
@@ -212,6 +217,7 @@ func (uc *UserController) UserModifyPassWord(ctx *gin.Context) {
 	}
 	uid := utils.GetUidFromTokenByCtx(ctx)
 	req.UserID = uid
+	req.SessionID = utils.GetSessionIDFromTokenByCtx(ctx)
 	//校对验证码
 	captchaPass := service.CaptchaServicer.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, req.UserID,
 		req.CaptchaID, req.CaptchaCode)
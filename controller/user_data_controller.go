@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/service"
+)
+
+// UserDataController the personal data export and the account deletion of the login user
+type UserDataController struct {
+}
+
+// NewUserDataController new controller
+func NewUserDataController() *UserDataController {
+	return &UserDataController{}
+}
+
+// ExportUserData download the personal data
+// @Summary export the personal data of the login user
+// @Description the zip archive contains the profile, questions, answers, comments, votes, collections, notifications
+// @Description and external logins as json files, and the written content as markdown
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce application/zip
+// @Success 200 {file} file
+// @Router /answer/api/v1/user/data/export [get]
+func (dc *UserDataController) ExportUserData(ctx *gin.Context) {
+	userID := utils.GetUidFromTokenByCtx(ctx)
+	buf, err := service.UserDataServicer.ExportToBuffer(ctx, userID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	filename := fmt.Sprintf("personal-data-%s-%s.zip", userID, time.Now().Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// GetUserDeletion get the account deletion status
+// @Summary get the account deletion status of the login user
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetUserDeletionResp}
+// @Router /answer/api/v1/user/deletion [get]
+func (dc *UserDataController) GetUserDeletion(ctx *gin.Context) {
+	resp, err := service.UserDataServicer.GetDeletion(ctx, utils.GetUidFromTokenByCtx(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RequestUserDeletion delete the own account after the grace period
+// @Summary request the deletion of the own account
+// @Description the account is anonymized after the grace period, the content stays without the author,
+// @Description the deletion can be canceled before
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.RequestUserDeletionReq true "password"
+// @Success 200 {object} handler.RespBody{data=schema.GetUserDeletionResp}
+// @Router /answer/api/v1/user/deletion [post]
+func (dc *UserDataController) RequestUserDeletion(ctx *gin.Context) {
+	req := &schema.RequestUserDeletionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := service.UserDataServicer.RequestDeletion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// CancelUserDeletion cancel the pending account deletion
+// @Summary cancel the pending account deletion in the grace period
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/deletion [delete]
+func (dc *UserDataController) CancelUserDeletion(ctx *gin.Context) {
+	err := service.UserDataServicer.CancelDeletion(ctx, utils.GetUidFromTokenByCtx(ctx))
+	handler.HandleResponse(ctx, err, nil)
+}
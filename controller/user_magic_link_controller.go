@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/service"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserMagicLinkController the passwordless login by the link or the one-time code sent to the email
+type UserMagicLinkController struct {
+}
+
+// NewUserMagicLinkController new controller
+func NewUserMagicLinkController() *UserMagicLinkController {
+	return &UserMagicLinkController{}
+}
+
+// SendMagicLink send the login email
+// @Summary send the login link and the one-time code to the email
+// @Description the request token in the response must be kept by the client, the captcha is required after
+// @Description too many login emails are requested from the ip or to the email
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.MagicLinkSendReq true "email"
+// @Success 200 {object} handler.RespBody{data=schema.MagicLinkSendResp}
+// @Router /answer/api/v1/user/login/magic-link [post]
+func (mc *UserMagicLinkController) SendMagicLink(ctx *gin.Context) {
+	req := &schema.MagicLinkSendReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	unit := ctx.ClientIP()
+	if service.CaptchaServicer.ValidationStrategy(ctx, req.Email, entity.CaptchaActionMagicLink) {
+		unit = req.Email
+	}
+	captchaPass := service.CaptchaServicer.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionMagicLink, unit,
+		req.CaptchaID, req.CaptchaCode)
+	if !captchaPass {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), nil)
+		return
+	}
+	_, _ = service.CaptchaServicer.ActionRecordAdd(ctx, entity.CaptchaActionMagicLink, ctx.ClientIP())
+	_, _ = service.CaptchaServicer.ActionRecordAdd(ctx, entity.CaptchaActionMagicLink, req.Email)
+	resp, err := service.UserServicer.SendMagicLink(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// MagicLinkLogin login with the link or the one-time code
+// @Summary login with the code of the link or the one-time code
+// @Description the request token returned when the email was sent is required, the response is the same as the
+// @Description email login, the users with two-factor authentication get the challenge token
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.MagicLinkLoginReq true "magic link login"
+// @Success 200 {object} handler.RespBody{data=schema.UserLoginResp}
+// @Router /answer/api/v1/user/login/magic-link/verification [post]
+func (mc *UserMagicLinkController) MagicLinkLogin(ctx *gin.Context) {
+	req := &schema.MagicLinkLoginReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := service.UserServicer.MagicLinkLogin(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
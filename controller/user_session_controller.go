@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/service"
+)
+
+// UserSessionController the login sessions of the user
+type UserSessionController struct {
+}
+
+// NewUserSessionController new controller
+func NewUserSessionController() *UserSessionController {
+	return &UserSessionController{}
+}
+
+// RefreshToken get a new access token by the refresh token
+// @Summary refresh the access token
+// @Description issue a new access token and rotate the refresh token, the old refresh token can not be used again
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.RefreshTokenReq true "refresh token"
+// @Success 200 {object} handler.RespBody{data=schema.RefreshTokenResp}
+// @Router /answer/api/v1/user/token/refresh [post]
+func (sc *UserSessionController) RefreshToken(ctx *gin.Context) {
+	req := &schema.RefreshTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := service.AuthServicer.RefreshSession(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExchangeLogin get the tokens of the login redirected to the frontend
+// @Summary exchange the login code for the tokens
+// @Description the external and the user center logins redirect to the frontend with a single-use code,
+// @Description the frontend gets the access token and the refresh token of the login by it
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.LoginExchangeReq true "code"
+// @Success 200 {object} handler.RespBody{data=schema.RefreshTokenResp}
+// @Router /answer/api/v1/user/login/exchange [post]
+func (sc *UserSessionController) ExchangeLogin(ctx *gin.Context) {
+	req := &schema.LoginExchangeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := service.AuthServicer.ExchangeLogin(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetSessionList get the login sessions of the user
+// @Summary get the login sessions
+// @Description get the device, ip and last seen time of each login session, the current one is marked
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSessionResp}
+// @Router /answer/api/v1/user/sessions [get]
+func (sc *UserSessionController) GetSessionList(ctx *gin.Context) {
+	resp, err := service.AuthServicer.GetSessionList(ctx, utils.GetUidFromTokenByCtx(ctx),
+		utils.GetSessionIDFromTokenByCtx(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeSession log out the session of a device
+// @Summary revoke the login session
+// @Description log out the device of the session, its tokens are rejected immediately
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.RevokeUserSessionReq true "session"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/session [delete]
+func (sc *UserSessionController) RevokeSession(ctx *gin.Context) {
+	req := &schema.RevokeUserSessionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := service.AuthServicer.RevokeSession(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RevokeOtherSessions log out everywhere except the current device
+// @Summary revoke the other login sessions
+// @Description log out all the other devices of the user, the current session is kept
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/sessions [delete]
+func (sc *UserSessionController) RevokeOtherSessions(ctx *gin.Context) {
+	err := service.AuthServicer.RemoveTokensExceptCurrentUser(ctx, utils.GetUidFromTokenByCtx(ctx),
+		utils.GetSessionIDFromTokenByCtx(ctx))
+	handler.HandleResponse(ctx, err, nil)
+}
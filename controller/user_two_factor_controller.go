@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/service"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserTwoFactorController the two-factor authentication of the user
+type UserTwoFactorController struct {
+}
+
+// NewUserTwoFactorController new controller
+func NewUserTwoFactorController() *UserTwoFactorController {
+	return &UserTwoFactorController{}
+}
+
+// TwoFactorLogin the second step of the login
+// @Summary verify the two-factor code of the login
+// @Description verify the code or a recovery code with the challenge token returned by the email login,
+// @Description the captcha is required after repeated failures
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.TwoFactorLoginReq true "two-factor login"
+// @Success 200 {object} handler.RespBody{data=schema.UserLoginResp}
+// @Router /answer/api/v1/user/login/two-factor [post]
+func (tc *UserTwoFactorController) TwoFactorLogin(ctx *gin.Context) {
+	req := &schema.TwoFactorLoginReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	captchaPass := service.CaptchaServicer.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionTwoFactor, ctx.ClientIP(),
+		req.CaptchaID, req.CaptchaCode)
+	if !captchaPass {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), nil)
+		return
+	}
+	resp, err := service.UserServicer.TwoFactorLogin(ctx, req)
+	if err != nil {
+		_, _ = service.CaptchaServicer.ActionRecordAdd(ctx, entity.CaptchaActionTwoFactor, ctx.ClientIP())
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	service.CaptchaServicer.ActionRecordDel(ctx, entity.CaptchaActionTwoFactor, ctx.ClientIP())
+	handler.HandleResponse(ctx, nil, resp)
+}
+
+// TwoFactorLoginSetup get the secret to enroll during the login
+// @Summary enroll the two-factor authentication during the login
+// @Description the users of the roles which require the two-factor authentication enroll with the challenge token
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.TwoFactorLoginSetupReq true "challenge token"
+// @Success 200 {object} handler.RespBody{data=schema.TwoFactorEnrollResp}
+// @Router /answer/api/v1/user/login/two-factor/setup [post]
+func (tc *UserTwoFactorController) TwoFactorLoginSetup(ctx *gin.Context) {
+	req := &schema.TwoFactorLoginSetupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := service.UserTwoFactorServicer.LoginSetup(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetTwoFactorStatus get the two-factor authentication status
+// @Summary get the two-factor authentication status
+// @Description whether the two-factor authentication is enabled or required and the number of the recovery codes left
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetTwoFactorStatusResp}
+// @Router /answer/api/v1/user/two-factor [get]
+func (tc *UserTwoFactorController) GetTwoFactorStatus(ctx *gin.Context) {
+	resp, err := service.UserTwoFactorServicer.GetStatus(ctx, utils.GetUidFromTokenByCtx(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// EnrollTwoFactor generate the secret of the authenticator app
+// @Summary enroll the two-factor authentication
+// @Description generate a new secret and its provisioning uri, it takes effect after it is confirmed
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.TwoFactorEnrollResp}
+// @Router /answer/api/v1/user/two-factor/enroll [post]
+func (tc *UserTwoFactorController) EnrollTwoFactor(ctx *gin.Context) {
+	resp, err := service.UserTwoFactorServicer.StartEnroll(ctx, utils.GetUidFromTokenByCtx(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ConfirmTwoFactor confirm the enrollment
+// @Summary confirm the two-factor authentication
+// @Description enable the two-factor authentication with the code of the authenticator app, the recovery codes
+// @Description are returned only this time
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.TwoFactorConfirmReq true "code"
+// @Success 200 {object} handler.RespBody{data=schema.TwoFactorRecoveryCodesResp}
+// @Router /answer/api/v1/user/two-factor/confirm [post]
+func (tc *UserTwoFactorController) ConfirmTwoFactor(ctx *gin.Context) {
+	req := &schema.TwoFactorConfirmReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := service.UserTwoFactorServicer.ConfirmEnroll(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DisableTwoFactor disable the two-factor authentication
+// @Summary disable the two-factor authentication
+// @Description disable with the code or a recovery code, it can not be disabled when it is required for the role
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.TwoFactorVerifyReq true "code or recovery code"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/two-factor [delete]
+func (tc *UserTwoFactorController) DisableTwoFactor(ctx *gin.Context) {
+	req := &schema.TwoFactorVerifyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := service.UserTwoFactorServicer.Disable(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RegenerateRecoveryCodes regenerate the recovery codes
+// @Summary regenerate the recovery codes
+// @Description replace all the recovery codes with the new ones, the code of the authenticator app is required
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.TwoFactorVerifyReq true "code"
+// @Success 200 {object} handler.RespBody{data=schema.TwoFactorRecoveryCodesResp}
+// @Router /answer/api/v1/user/two-factor/recovery-codes [post]
+func (tc *UserTwoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	req := &schema.TwoFactorVerifyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := service.UserTwoFactorServicer.RegenerateRecoveryCodes(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,69 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// AnswerDisclaimerController answer disclaimer controller
+type AnswerDisclaimerController struct {
+}
+
+// NewAnswerDisclaimerController new controller
+func NewAnswerDisclaimerController() *AnswerDisclaimerController {
+	return &AnswerDisclaimerController{}
+}
+
+// GetAnswerDisclaimerPolicy get the answer disclaimers
+// @Summary get answer disclaimer policy
+// @Description get the disclaimer blocks shown in the answers, the default disclaimer and the disclaimers per tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.AnswerDisclaimerPolicy}
+// @Router /answer/admin/api/answer/disclaimer [get]
+func (dc *AnswerDisclaimerController) GetAnswerDisclaimerPolicy(ctx *gin.Context) {
+	resp, err := services.AnswerDisclaimerServicer.GetAnswerDisclaimerPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAnswerDisclaimerPolicy update the answer disclaimers
+// @Summary update answer disclaimer policy
+// @Description update the disclaimer blocks and whether the answerers must acknowledge them
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateAnswerDisclaimerPolicyReq true "answer disclaimer policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/answer/disclaimer [put]
+func (dc *AnswerDisclaimerController) UpdateAnswerDisclaimerPolicy(ctx *gin.Context) {
+	req := &schema.UpdateAnswerDisclaimerPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.AnswerDisclaimerServicer.UpdateAnswerDisclaimerPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAnswerDisclaimerAck get the disclaimer acknowledgement of the answer
+// @Summary get answer disclaimer acknowledgement
+// @Description get the disclaimer the answer was posted with and whether the answerer acknowledged it
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param answer_id query string true "answer id"
+// @Success 200 {object} handler.RespBody{data=schema.GetAnswerDisclaimerAckResp}
+// @Router /answer/admin/api/answer/disclaimer/ack [get]
+func (dc *AnswerDisclaimerController) GetAnswerDisclaimerAck(ctx *gin.Context) {
+	req := &schema.GetAnswerDisclaimerAckReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.AnswerDisclaimerServicer.GetAnswerDisclaimerAck(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,75 @@
+package controller_admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	services "github.com/lawyer/service"
+)
+
+// AuditLogController audit log controller
+type AuditLogController struct {
+}
+
+// NewAuditLogController new controller
+func NewAuditLogController() *AuditLogController {
+	return &AuditLogController{}
+}
+
+// GetAuditLogPage get audit log page
+// @Summary get audit log page
+// @Description get the admin and moderator actions by page
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "the user who did the action"
+// @Param action query string false "action"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start time, unix timestamp"
+// @Param end_time query int false "end time, unix timestamp"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetAuditLogResp}}
+// @Router /answer/admin/api/audit-logs/page [get]
+func (ac *AuditLogController) GetAuditLogPage(ctx *gin.Context) {
+	req := &schema.GetAuditLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.AuditLogServicer.GetAuditLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportAuditLog export audit log as csv
+// @Summary export audit log
+// @Description export the audit logs matching the filter as csv
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv
+// @Param user_id query string false "the user who did the action"
+// @Param action query string false "action"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start time, unix timestamp"
+// @Param end_time query int false "end time, unix timestamp"
+// @Success 200 {string} string ""
+// @Router /answer/admin/api/audit-logs/export [get]
+func (ac *AuditLogController) ExportAuditLog(ctx *gin.Context) {
+	req := &schema.GetAuditLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	content, err := services.AuditLogServicer.ExportAuditLog(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=audit_log_%s.csv", time.Now().Format("20060102150405")))
+	ctx.Data(http.StatusOK, "text/csv;charset=utf-8", content)
+}
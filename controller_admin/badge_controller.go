@@ -0,0 +1,73 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	services "github.com/lawyer/service"
+)
+
+// BadgeController badge controller
+type BadgeController struct {
+}
+
+// NewBadgeController new controller
+func NewBadgeController() *BadgeController {
+	return &BadgeController{}
+}
+
+// GetBadgeList get all badges
+// @Summary get badge list
+// @Description get the badges with the rules and how many times each badge is awarded
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param status query int false "status 1 enabled 2 disabled"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetBadgeResp}
+// @Router /answer/admin/api/badges [get]
+func (bc *BadgeController) GetBadgeList(ctx *gin.Context) {
+	req := &schema.GetBadgeListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.BadgeServicer.GetBadgeList(ctx, req.Status)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddBadge add badge
+// @Summary add badge
+// @Description add badge with the rule to award it
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddBadgeReq true "badge"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge [post]
+func (bc *BadgeController) AddBadge(ctx *gin.Context) {
+	req := &schema.AddBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.BadgeServicer.AddBadge(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateBadge update badge
+// @Summary update badge
+// @Description update badge, the badges already awarded are kept
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateBadgeReq true "badge"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge [put]
+func (bc *BadgeController) UpdateBadge(ctx *gin.Context) {
+	req := &schema.UpdateBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.BadgeServicer.UpdateBadge(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,83 @@
+package controller_admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	services "github.com/lawyer/service"
+)
+
+// DashboardController dashboard statistics controller
+type DashboardController struct {
+}
+
+// NewDashboardController new controller
+func NewDashboardController() *DashboardController {
+	return &DashboardController{}
+}
+
+// GetStat get dashboard statistics
+// @Summary get dashboard statistics
+// @Description get the daily statistics, the most active tags and top contributors between the dates
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param start_date query string true "start date, such as 2006-01-02"
+// @Param end_date query string true "end date, such as 2006-01-02"
+// @Success 200 {object} handler.RespBody{data=schema.GetDashboardStatResp}
+// @Router /answer/admin/api/dashboard/stats [get]
+func (dc *DashboardController) GetStat(ctx *gin.Context) {
+	req := &schema.GetDashboardStatReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.DashboardServicer.GetStat(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportStat export dashboard daily statistics as csv
+// @Summary export dashboard statistics
+// @Description export the daily statistics between the dates as csv
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv
+// @Param start_date query string true "start date, such as 2006-01-02"
+// @Param end_date query string true "end date, such as 2006-01-02"
+// @Success 200 {string} string ""
+// @Router /answer/admin/api/dashboard/stats/export [get]
+func (dc *DashboardController) ExportStat(ctx *gin.Context) {
+	req := &schema.GetDashboardStatReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	content, err := services.DashboardServicer.ExportStat(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=dashboard_%s_%s.csv", req.StartDate, req.EndDate))
+	ctx.Data(http.StatusOK, "text/csv;charset=utf-8", content)
+}
+
+// RollupStat roll up dashboard statistics again
+// @Summary roll up dashboard statistics
+// @Description roll up the statistics between the dates again, such as after the data is fixed
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.GetDashboardStatReq true "date range"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/dashboard/stats/rollup [post]
+func (dc *DashboardController) RollupStat(ctx *gin.Context) {
+	req := &schema.GetDashboardStatReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.DashboardServicer.RollupStat(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
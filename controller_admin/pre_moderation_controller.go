@@ -0,0 +1,91 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// PreModerationController pre-moderation controller
+type PreModerationController struct {
+}
+
+// NewPreModerationController new controller
+func NewPreModerationController() *PreModerationController {
+	return &PreModerationController{}
+}
+
+// GetPreModerationPolicy get the pre-moderation rules
+// @Summary get pre-moderation policy
+// @Description get how many first questions and answers of the low rank users wait for the approval
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.PreModerationPolicy}
+// @Router /answer/admin/api/moderation/policy [get]
+func (pc *PreModerationController) GetPreModerationPolicy(ctx *gin.Context) {
+	resp, err := services.PreModerationServicer.GetPreModerationPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdatePreModerationPolicy update the pre-moderation rules
+// @Summary update pre-moderation policy
+// @Description update how many first questions and answers of the low rank users wait for the approval
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdatePreModerationPolicyReq true "pre-moderation policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/moderation/policy [put]
+func (pc *PreModerationController) UpdatePreModerationPolicy(ctx *gin.Context) {
+	req := &schema.UpdatePreModerationPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.PreModerationServicer.UpdatePreModerationPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPendingPostPage get the pending questions, answers or comments
+// @Summary get pending post page
+// @Description get the questions, answers or comments waiting for the approval, the oldest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param object_type query string true "question, answer or comment" Enums(question, answer, comment)
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.PendingPostInfo}}
+// @Router /answer/admin/api/moderation/pending/page [get]
+func (pc *PreModerationController) GetPendingPostPage(ctx *gin.Context) {
+	req := &schema.GetPendingPostPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.PreModerationServicer.GetPendingPostPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ReviewPendingPost approve or reject the pending question, answer or comment
+// @Summary review pending post
+// @Description the approved post is published and the rejected post is deleted, the author is notified
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ReviewPendingPostReq true "review pending post"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/moderation/pending/review [put]
+func (pc *PreModerationController) ReviewPendingPost(ctx *gin.Context) {
+	req := &schema.ReviewPendingPostReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.PreModerationServicer.ReviewPendingPost(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,51 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// QuestionLifecycleController question lifecycle controller
+type QuestionLifecycleController struct {
+}
+
+// NewQuestionLifecycleController new controller
+func NewQuestionLifecycleController() *QuestionLifecycleController {
+	return &QuestionLifecycleController{}
+}
+
+// GetQuestionLifecyclePolicy get the question lifecycle rules
+// @Summary get question lifecycle policy
+// @Description get the rules to remind, bump and close the open questions, the default rule and the rules per tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.QuestionLifecyclePolicy}
+// @Router /answer/admin/api/question/lifecycle [get]
+func (lc *QuestionLifecycleController) GetQuestionLifecyclePolicy(ctx *gin.Context) {
+	resp, err := services.QuestionLifecycleServicer.GetQuestionLifecyclePolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateQuestionLifecyclePolicy update the question lifecycle rules
+// @Summary update question lifecycle policy
+// @Description update the rules to remind, bump and close the open questions
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateQuestionLifecyclePolicyReq true "question lifecycle policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/question/lifecycle [put]
+func (lc *QuestionLifecycleController) UpdateQuestionLifecyclePolicy(ctx *gin.Context) {
+	req := &schema.UpdateQuestionLifecyclePolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.QuestionLifecycleServicer.UpdateQuestionLifecyclePolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/lawyer/commons/base/handler"
 	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/pkg/converter"
 	services "github.com/lawyer/service"
 )
@@ -45,6 +46,7 @@ func (rc *ReportController) ListReportPage(ctx *gin.Context) {
 		Status:     status,
 		Page:       page,
 		PageSize:   pageSize,
+		UserID:     utils.GetUidFromTokenByCtx(ctx),
 	}
 
 	resp, err := services.ReportAdminServicer.ListReportPage(ctx, dto)
@@ -71,6 +73,7 @@ func (rc *ReportController) Handle(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, &req) {
 		return
 	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
 
 	err := services.ReportAdminServicer.HandleReported(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
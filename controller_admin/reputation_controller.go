@@ -0,0 +1,104 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// ReputationController reputation controller
+type ReputationController struct {
+}
+
+// NewReputationController new controller
+func NewReputationController() *ReputationController {
+	return &ReputationController{}
+}
+
+// GetReputationLedger get the reputation ledger of the user
+// @Summary get reputation ledger
+// @Description replay the activities of the user with the current rank rules and explain each reputation change
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=schema.GetReputationLedgerResp}
+// @Router /answer/admin/api/reputation/ledger [get]
+func (rc *ReputationController) GetReputationLedger(ctx *gin.Context) {
+	req := &schema.GetReputationLedgerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.ReputationServicer.GetReputationLedger(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RecalculateReputation recalculate the reputation of the users
+// @Summary recalculate reputation
+// @Description recalculate the reputation of one or all users, report the discrepancies and fix them if required
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RecalculateReputationReq true "recalculate reputation"
+// @Success 200 {object} handler.RespBody{data=schema.RecalculateReputationResp}
+// @Router /answer/admin/api/reputation/recalculate [post]
+func (rc *ReputationController) RecalculateReputation(ctx *gin.Context) {
+	req := &schema.RecalculateReputationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.OperatorID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := services.ReputationServicer.RecalculateReputation(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetReputationPolicy get the current reputation policy
+// @Summary get reputation policy
+// @Description get the current reputation policy, such as the points per activity type and the daily cap
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.ReputationPolicy}
+// @Router /answer/admin/api/reputation/policy [get]
+func (rc *ReputationController) GetReputationPolicy(ctx *gin.Context) {
+	resp, err := services.ReputationPolicyServicer.GetReputationPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateReputationPolicy update the reputation policy
+// @Summary update reputation policy
+// @Description save the reputation policy as a new version, it applies to the activities after it
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateReputationPolicyReq true "reputation policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/reputation/policy [put]
+func (rc *ReputationController) UpdateReputationPolicy(ctx *gin.Context) {
+	req := &schema.UpdateReputationPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.ReputationPolicyServicer.UpdateReputationPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetReputationPolicyVersionList get all versions of the reputation policy
+// @Summary get reputation policy versions
+// @Description get all saved versions of the reputation policy, the newest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetReputationPolicyVersionResp}
+// @Router /answer/admin/api/reputation/policy/versions [get]
+func (rc *ReputationController) GetReputationPolicyVersionList(ctx *gin.Context) {
+	resp, err := services.ReputationPolicyServicer.GetReputationPolicyVersionList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
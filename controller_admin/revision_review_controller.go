@@ -0,0 +1,51 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// RevisionReviewController revision review controller
+type RevisionReviewController struct {
+}
+
+// NewRevisionReviewController new controller
+func NewRevisionReviewController() *RevisionReviewController {
+	return &RevisionReviewController{}
+}
+
+// GetRevisionReviewPolicy get the suggested edits review rules
+// @Summary get revision review policy
+// @Description get the number of approvals and rejections to decide a suggested edit and the days before it expires
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.RevisionReviewPolicy}
+// @Router /answer/admin/api/revision/review [get]
+func (rc *RevisionReviewController) GetRevisionReviewPolicy(ctx *gin.Context) {
+	resp, err := services.RevisionReviewServicer.GetRevisionReviewPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRevisionReviewPolicy update the suggested edits review rules
+// @Summary update revision review policy
+// @Description update the number of approvals and rejections to decide a suggested edit and the days before it expires
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRevisionReviewPolicyReq true "revision review policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/revision/review [put]
+func (rc *RevisionReviewController) UpdateRevisionReviewPolicy(ctx *gin.Context) {
+	req := &schema.UpdateRevisionReviewPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.RevisionReviewServicer.UpdateRevisionReviewPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -31,3 +31,167 @@ func (rc *RoleController) GetRoleList(ctx *gin.Context) {
 	resp, err := services.RoleServicer.GetRoleList(ctx)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// AddRole add custom role
+// @Summary add custom role
+// @Description add custom role with powers
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddRoleReq true "role"
+// @Success 200 {object} handler.RespBody{data=schema.AddRoleResp}
+// @Router /answer/admin/api/role [post]
+func (rc *RoleController) AddRole(ctx *gin.Context) {
+	req := &schema.AddRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.RoleServicer.AddRole(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRole update custom role
+// @Summary update custom role
+// @Description update custom role name and description
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [put]
+func (rc *RoleController) UpdateRole(ctx *gin.Context) {
+	req := &schema.UpdateRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.RoleServicer.UpdateRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveRole remove custom role
+// @Summary remove custom role
+// @Description remove custom role which is not assigned to any user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [delete]
+func (rc *RoleController) RemoveRole(ctx *gin.Context) {
+	req := &schema.RemoveRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.RoleServicer.RemoveRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRolePowerList get role power list
+// @Summary get role power list
+// @Description get role power list
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param role_id query int true "role id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRolePowerResp}
+// @Router /answer/admin/api/role/powers [get]
+func (rc *RoleController) GetRolePowerList(ctx *gin.Context) {
+	req := &schema.GetRolePowerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.RolePowerRelServicer.GetRolePowerDetail(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRolePowerList update role power list
+// @Summary update role power list
+// @Description replace all the powers of the role
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRolePowerReq true "role power"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role/powers [put]
+func (rc *RoleController) UpdateRolePowerList(ctx *gin.Context) {
+	req := &schema.UpdateRolePowerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.RolePowerRelServicer.UpdateRolePowerList(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPowerList get power list
+// @Summary get power list
+// @Description get all powers which can be assigned to roles
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPowerResp}
+// @Router /answer/admin/api/powers [get]
+func (rc *RoleController) GetPowerList(ctx *gin.Context) {
+	resp, err := services.PowerServicer.GetPowerList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetUserScopedRoleList get the tag scoped roles of the user or the moderators of the tag
+// @Summary get user scoped role list
+// @Description get the tag scoped roles of the user, or the moderators of the tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string false "user id"
+// @Param tag_id query string false "tag id"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetUserScopedRoleResp}
+// @Router /answer/admin/api/user/scoped-roles [get]
+func (rc *RoleController) GetUserScopedRoleList(ctx *gin.Context) {
+	req := &schema.GetUserScopedRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.UserRoleRelServicer.GetUserScopedRoleList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveUserScopedRole appoint the user as a moderator of the tag
+// @Summary save user scoped role
+// @Description appoint the user with a role which only takes effect on the questions with the tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.SaveUserScopedRoleReq true "user scoped role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/scoped-role [put]
+func (rc *RoleController) SaveUserScopedRole(ctx *gin.Context) {
+	req := &schema.SaveUserScopedRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.UserRoleRelServicer.SaveUserScopedRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveUserScopedRole remove the user from the moderators of the tag
+// @Summary remove user scoped role
+// @Description remove the user from the moderators of the tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveUserScopedRoleReq true "user scoped role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/scoped-role [delete]
+func (rc *RoleController) RemoveUserScopedRole(ctx *gin.Context) {
+	req := &schema.RemoveUserScopedRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := services.UserRoleRelServicer.RemoveUserScopedRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
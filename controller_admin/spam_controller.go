@@ -0,0 +1,71 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/uid"
+	services "github.com/lawyer/service"
+)
+
+// SpamController spam scoring controller
+type SpamController struct {
+}
+
+// NewSpamController new controller
+func NewSpamController() *SpamController {
+	return &SpamController{}
+}
+
+// GetSpamPolicy get the spam scoring rules
+// @Summary get spam policy
+// @Description get the weights of the spam signals and the score to hold the posts for review
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SpamPolicy}
+// @Router /answer/admin/api/moderation/spam/policy [get]
+func (sc *SpamController) GetSpamPolicy(ctx *gin.Context) {
+	resp, err := services.SpamServicer.GetSpamPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSpamPolicy update the spam scoring rules
+// @Summary update spam policy
+// @Description update the weights of the spam signals and the score to hold the posts for review
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateSpamPolicyReq true "spam policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/moderation/spam/policy [put]
+func (sc *SpamController) UpdateSpamPolicy(ctx *gin.Context) {
+	req := &schema.UpdateSpamPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.SpamServicer.UpdateSpamPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSpamScore get the spam score of the post
+// @Summary get spam score
+// @Description get the spam score and the signals of the question, answer or comment
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param object_id query string true "object id"
+// @Success 200 {object} handler.RespBody{data=schema.GetSpamScoreResp}
+// @Router /answer/admin/api/moderation/spam/score [get]
+func (sc *SpamController) GetSpamScore(ctx *gin.Context) {
+	req := &schema.GetSpamScoreReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	resp, err := services.SpamServicer.GetSpamScore(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
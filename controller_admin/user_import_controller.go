@@ -0,0 +1,101 @@
+package controller_admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserImportController the bulk user import and export in csv
+type UserImportController struct {
+}
+
+// NewUserImportController new controller
+func NewUserImportController() *UserImportController {
+	return &UserImportController{}
+}
+
+// ImportUsers import users from csv
+// @Summary import users from csv
+// @Description the first row of the file is the header, the columns are username, email, display_name, role,
+// @Description location and website, only the email is required. The file with more than 100 users is imported in
+// @Description the background, the returned job is polled for the progress. No user is created when any row is invalid.
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "csv file"
+// @Param mapping formData string false "the json object mapping the column to the header, such as {\"email\":\"E-Mail\"}"
+// @Param dry_run formData bool false "only validate the file"
+// @Param send_welcome_email formData bool false "send the activation email to the created users"
+// @Success 200 {object} handler.RespBody{data=schema.UserImportJob}
+// @Router /answer/admin/api/users/import [post]
+func (ic *UserImportController) ImportUsers(ctx *gin.Context) {
+	// max size
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, 5*1024*1024)
+	req := &schema.ImportUsersReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	file, _, err := ctx.Request.FormFile("file")
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError).WithError(err), nil)
+		return
+	}
+	defer file.Close()
+
+	req.LoginUserID = utils.GetUidFromTokenByCtx(ctx)
+	resp, err := services.UserAdminServicer.ImportUsers(ctx, req, file)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetUserImportJob get the user import job
+// @Summary get the progress and the report of the user import
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param job_id query string true "job id"
+// @Success 200 {object} handler.RespBody{data=schema.UserImportJob}
+// @Router /answer/admin/api/users/import [get]
+func (ic *UserImportController) GetUserImportJob(ctx *gin.Context) {
+	req := &schema.GetUserImportJobReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := services.UserAdminServicer.GetUserImportJob(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportUsers export users as csv
+// @Summary export users
+// @Description export the users matching the filter of the user page as csv, the file can be imported again
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv
+// @Param query query string false "search query: email, username or id:[id]"
+// @Param staff query bool false "staff user"
+// @Param status query string false "user status" Enums(suspended, deleted, inactive)
+// @Success 200 {string} string ""
+// @Router /answer/admin/api/users/export [get]
+func (ic *UserImportController) ExportUsers(ctx *gin.Context) {
+	req := &schema.GetUserPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	content, err := services.UserAdminServicer.ExportUsers(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=users_%s.csv", time.Now().Format("20060102150405")))
+	ctx.Data(http.StatusOK, "text/csv;charset=utf-8", content)
+}
@@ -0,0 +1,38 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// UserSessionController the login sessions of the users
+type UserSessionController struct {
+}
+
+// NewUserSessionController new controller
+func NewUserSessionController() *UserSessionController {
+	return &UserSessionController{}
+}
+
+// ForceLogoutUser log out the user from all the devices
+// @Summary force logout user
+// @Description revoke all the login sessions of the user, the issued access tokens are rejected immediately
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ForceLogoutUserReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/sessions [delete]
+func (sc *UserSessionController) ForceLogoutUser(ctx *gin.Context) {
+	req := &schema.ForceLogoutUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.UserAdminServicer.ForceLogout(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,72 @@
+package controller_admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	services "github.com/lawyer/service"
+)
+
+// UserTwoFactorController two-factor authentication controller
+type UserTwoFactorController struct {
+}
+
+// NewUserTwoFactorController new controller
+func NewUserTwoFactorController() *UserTwoFactorController {
+	return &UserTwoFactorController{}
+}
+
+// GetTwoFactorPolicy get the two-factor authentication policy
+// @Summary get two-factor authentication policy
+// @Description get the roles which must enable the two-factor authentication
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.TwoFactorPolicy}
+// @Router /answer/admin/api/user/two-factor/policy [get]
+func (tc *UserTwoFactorController) GetTwoFactorPolicy(ctx *gin.Context) {
+	resp, err := services.UserTwoFactorServicer.GetTwoFactorPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateTwoFactorPolicy update the two-factor authentication policy
+// @Summary update two-factor authentication policy
+// @Description update the roles which must enable the two-factor authentication, the users of these roles
+// @Description enroll when they login next time
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateTwoFactorPolicyReq true "two-factor authentication policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/two-factor/policy [put]
+func (tc *UserTwoFactorController) UpdateTwoFactorPolicy(ctx *gin.Context) {
+	req := &schema.UpdateTwoFactorPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.UserTwoFactorServicer.UpdateTwoFactorPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ResetTwoFactor reset the two-factor authentication of the user
+// @Summary reset two-factor authentication of the user
+// @Description remove the two-factor authentication of the user who lost the authenticator and the recovery codes
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ResetTwoFactorReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/two-factor [delete]
+func (tc *UserTwoFactorController) ResetTwoFactor(ctx *gin.Context) {
+	req := &schema.ResetTwoFactorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = utils.GetUidFromTokenByCtx(ctx)
+	err := services.UserTwoFactorServicer.Reset(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -1,10 +1,13 @@
 package initServer
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/cron"
 	"github.com/lawyer/commons/config"
 	"github.com/lawyer/commons/handler"
+	_ "github.com/lawyer/plugin/connector_oidc"
 	"github.com/lawyer/repo"
 	"github.com/lawyer/router"
 	"github.com/lawyer/service"
@@ -29,6 +32,10 @@ func Init(filename string) *gin.Engine {
 	checkErr(err)
 	repo.InitRepo()
 	service.InitServices()
+	service.SiteInfoServicer.SubscribeInvalidation(context.Background())
+	cron.NewScheduledTaskManager(service.QuestionServicer, service.DashboardServicer,
+		service.QuestionLifecycleServicer, service.RevisionReviewServicer, service.QuestionViewServicer,
+		service.UserDataServicer).Run()
 	application, err := initApplication(c.Debug)
 	checkErr(err)
 	return application
@@ -1 +1,29 @@
 package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/handler"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/service"
+	"github.com/lawyer/service/permission"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PowerAccess only the user whose role has the power can access, use it after AccessToken.
+func PowerAccess(power string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := utils.GetUidFromTokenByCtx(ctx)
+		if !service.RankServicer.CheckUserPower(ctx, userID, power) {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// AdminAccess only the user whose role has admin access power can access, use it after AccessToken.
+func AdminAccess() gin.HandlerFunc {
+	return PowerAccess(permission.AdminAccess)
+}
@@ -42,6 +42,8 @@ func (m *Mentor) InitDB() error {
 	m.do("init admin user", m.initAdminUser)
 	m.do("init config", m.initConfig)
 	m.do("init default privileges config", m.initDefaultRankPrivileges)
+	m.do("init reputation policy", m.initReputationPolicy)
+	m.do("init badges", m.initBadges)
 	m.do("init role", m.initRole)
 	m.do("init power", m.initPower)
 	m.do("init role power rel", m.initRolePowerRel)
@@ -54,6 +56,7 @@ func (m *Mentor) InitDB() error {
 	m.do("init site info user config", m.initSiteInfoUsersConfig)
 	m.do("init site info privilege rank", m.initSiteInfoPrivilegeRank)
 	m.do("init site info write", m.initSiteInfoWrite)
+	m.do("init site info config", m.initSiteInfoConfig)
 	return m.err
 }
 
@@ -114,6 +117,14 @@ func (m *Mentor) initDefaultRankPrivileges() {
 	}
 }
 
+func (m *Mentor) initReputationPolicy() {
+	_, m.err = m.engine.Context(m.ctx).Insert(defaultReputationPolicy)
+}
+
+func (m *Mentor) initBadges() {
+	_, m.err = m.engine.Context(m.ctx).Insert(badges)
+}
+
 func (m *Mentor) initRole() {
 	_, m.err = m.engine.Context(m.ctx).Insert(roles)
 }
@@ -236,3 +247,7 @@ func (m *Mentor) initSiteInfoWrite() {
 		Status:  1,
 	})
 }
+
+func (m *Mentor) initSiteInfoConfig() {
+	m.err = moveSiteInfoToConfig(m.ctx, m.engine)
+}
@@ -20,6 +20,20 @@ const (
 							Disallow: /*?code*
 							
 								Sitemap: `
+	// the points not in the policy use the value of the config with the same key
+	defaultReputationPolicyContent = `{"version":1,"points":{},"daily_cap":200,"daily_cap_exclude":["answer.accepted"],"min_rank":1,"tag_multipliers":{}}`
+	// auto close is disabled by default, the close reason is reason.something
+	defaultQuestionLifecyclePolicyContent = `{"default":{"accept_reminder_days":7,"unanswered_bump_days":3,"auto_close_days":0,"close_reason_type":59,"close_msg":""},"tags":{}}`
+	// the default disclaimer is shown below all answers, the acknowledgement is optional
+	defaultAnswerDisclaimerPolicyContent = `{"version":1,"enabled":true,"require_acknowledgement":false,"default":{"title":"Not legal advice","content":"This answer provides general information only and is not legal advice. No lawyer-client relationship is created. Consult a qualified lawyer in your jurisdiction about your specific situation."},"tags":{}}`
+	// the suggested edits need two approvals or two rejections, and expire after two weeks
+	defaultRevisionReviewPolicyContent = `{"required_approvals":2,"required_rejections":2,"expire_days":14}`
+	// pre-moderation is disabled by default, when enabled the first 3 posts of the users under 100 rank are reviewed
+	defaultPreModerationPolicyContent = `{"enabled":false,"first_posts":3,"max_rank":100}`
+	// spam scoring is disabled by default, the hold score moves between 5 and 20 with the review decisions
+	defaultSpamPolicyContent = `{"enabled":false,"hold_score":10,"min_hold_score":5,"max_hold_score":20,"adjust_step":1,"free_links":2,"link_weight":2,"blocked_domains":[],"blocked_domain_weight":10,"repeat_window_hours":24,"repeated_content_weight":5,"new_account_days":3,"new_account_weight":3,"action_burst_count":10,"action_burst_weight":4,"filter_weight":10}`
+	// no role is required to enable the two-factor authentication by default
+	defaultTwoFactorPolicyContent = `{"required_role_ids":[]}`
 )
 
 var (
@@ -45,11 +59,45 @@ var (
 		&entity.RolePowerRel{},
 		&entity.Power{},
 		&entity.UserRoleRel{},
+		&entity.UserRoleScopeRel{},
+		&entity.AuditLog{},
+		&entity.DashboardDailyStat{},
+		&entity.DashboardDailyTagStat{},
+		&entity.DashboardDailyUserStat{},
+		&entity.ReputationPolicy{},
+		&entity.Badge{},
+		&entity.BadgeAward{},
+		&entity.ResponseTemplate{},
+		&entity.ResponseTemplateUse{},
+		&entity.AnswerDisclaimerAck{},
+		&entity.RevisionReview{},
+		&entity.SpamScore{},
+		&entity.SiteInfoHistory{},
+		&entity.QuestionDailyViewStat{},
+		&entity.UserTwoFactor{},
+		&entity.UserDeletion{},
 		&entity.PluginConfig{},
 		&entity.UserExternalLogin{},
 		&entity.UserNotificationConfig{},
 	}
 
+	defaultReputationPolicy = &entity.ReputationPolicy{Version: 1, Content: defaultReputationPolicyContent}
+
+	badges = []*entity.Badge{
+		{ID: 1, Name: "First Accepted Answer", Description: "Your answer was accepted for the first time.",
+			Level: entity.BadgeLevelBronze, RuleType: entity.BadgeRuleAcceptedAnswers, Threshold: 1, TagID: "0",
+			Status: entity.BadgeStatusEnabled},
+		{ID: 2, Name: "Tag Upvotes", Description: "Received 100 upvotes in a tag.",
+			Level: entity.BadgeLevelSilver, RuleType: entity.BadgeRuleUpvotes, Threshold: 100, TagID: "0", PerTag: true,
+			Status: entity.BadgeStatusEnabled},
+		{ID: 3, Name: "Jurisdiction Expert", Description: "Posted 10 accepted or upvoted answers in a jurisdiction.",
+			Level: entity.BadgeLevelSilver, RuleType: entity.BadgeRuleHelpfulAnswers, Threshold: 10, TagID: "0", PerTag: true,
+			Status: entity.BadgeStatusEnabled},
+		{ID: 4, Name: "Answer Streak", Description: "Answered questions 7 days in a row.",
+			Level: entity.BadgeLevelBronze, RuleType: entity.BadgeRuleAnswerStreak, Threshold: 7, TagID: "0",
+			Status: entity.BadgeStatusEnabled},
+	}
+
 	roles = []*entity.Role{
 		{ID: 1, Name: "User", Description: "Default with no special access."},
 		{ID: 2, Name: "Admin", Description: "Have the full power to access the site."},
@@ -98,6 +146,8 @@ var (
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
 		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
+		{ID: 42, Name: "report handle", PowerType: permission.ReportHandle, Description: "handle the reports"},
+		{ID: 43, Name: "response template manage", PowerType: permission.ResponseTemplateManage, Description: "manage the site-wide response templates"},
 	}
 
 	rolePowerRels = []*entity.RolePowerRel{
@@ -143,6 +193,8 @@ var (
 		{RoleID: 2, PowerType: permission.AnswerUnDelete},
 		{RoleID: 2, PowerType: permission.QuestionUnDelete},
 		{RoleID: 2, PowerType: permission.TagUnDelete},
+		{RoleID: 2, PowerType: permission.ReportHandle},
+		{RoleID: 2, PowerType: permission.ResponseTemplateManage},
 
 		{RoleID: 3, PowerType: permission.QuestionAdd},
 		{RoleID: 3, PowerType: permission.QuestionEdit},
@@ -185,6 +237,8 @@ var (
 		{RoleID: 3, PowerType: permission.AnswerUnDelete},
 		{RoleID: 3, PowerType: permission.QuestionUnDelete},
 		{RoleID: 3, PowerType: permission.TagUnDelete},
+		{RoleID: 3, PowerType: permission.ReportHandle},
+		{RoleID: 3, PowerType: permission.ResponseTemplateManage},
 	}
 
 	adminUserRoleRel = &entity.UserRoleRel{
@@ -322,5 +376,17 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: entity.ReputationPolicyConfigKey, Value: defaultReputationPolicyContent},
+		{ID: 132, Key: "question.accept_reminded", Value: `0`},
+		{ID: 133, Key: "question.bumped", Value: `0`},
+		{ID: 134, Key: entity.QuestionLifecycleConfigKey, Value: defaultQuestionLifecyclePolicyContent},
+		{ID: 135, Key: entity.AnswerDisclaimerConfigKey, Value: defaultAnswerDisclaimerPolicyContent},
+		{ID: 136, Key: entity.RevisionReviewConfigKey, Value: defaultRevisionReviewPolicyContent},
+		{ID: 137, Key: "revision.reviewed", Value: `2`},
+		{ID: 138, Key: entity.PreModerationConfigKey, Value: defaultPreModerationPolicyContent},
+		{ID: 139, Key: entity.SpamPolicyConfigKey, Value: defaultSpamPolicyContent},
+		{ID: 151, Key: entity.TwoFactorPolicyConfigKey, Value: defaultTwoFactorPolicyContent},
+		{ID: 152, Key: "rank.response_template.manage", Value: `-1`},
+		{ID: 153, Key: entity.SpamHoldScoreConfigKey, Value: `10`},
 	}
 )
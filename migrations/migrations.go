@@ -75,6 +75,23 @@ var migrations = []Migration{
 	NewMigration("v1.1.3", "set default user notification config", setDefaultUserNotificationConfig, false),
 	NewMigration("v1.2.0", "add recover answer permission", addRecoverPermission, true),
 	NewMigration("v1.2.1", "add password login control", addPasswordLoginControl, true),
+	NewMigration("v1.2.2", "add tag scoped moderator", addTagScopedModerator, true),
+	NewMigration("v1.2.3", "add audit log", addAuditLog, false),
+	NewMigration("v1.2.4", "add dashboard statistics", addDashboardStat, false),
+	NewMigration("v1.2.5", "add reputation policy", addReputationPolicy, true),
+	NewMigration("v1.2.6", "add badges", addBadges, true),
+	NewMigration("v1.2.7", "add question lifecycle", addQuestionLifecycle, false),
+	NewMigration("v1.2.8", "add response template", addResponseTemplate, false),
+	NewMigration("v1.2.9", "add answer disclaimer", addAnswerDisclaimer, false),
+	NewMigration("v1.2.10", "add revision review", addRevisionReview, false),
+	NewMigration("v1.2.11", "add pre-moderation", addPreModeration, false),
+	NewMigration("v1.2.12", "add spam score", addSpamScore, false),
+	NewMigration("v1.2.13", "add site info history", addSiteInfoHistory, false),
+	NewMigration("v1.2.14", "add question daily view stat", addQuestionDailyViewStat, false),
+	NewMigration("v1.2.15", "add user two factor", addUserTwoFactor, false),
+	NewMigration("v1.2.16", "add user deletion", addUserDeletion, false),
+	NewMigration("v1.2.17", "add response template power", addResponseTemplatePower, false),
+	NewMigration("v1.2.18", "add spam hold score", addSpamHoldScore, false),
 }
 
 func GetMigrations() []Migration {
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"github.com/lawyer/service/permission"
+	"xorm.io/xorm"
+)
+
+func addTagScopedModerator(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.UserRoleScopeRel)); err != nil {
+		return fmt.Errorf("sync user role scope rel table failed: %w", err)
+	}
+
+	power := &entity.Power{ID: 42, Name: "report handle", PowerType: permission.ReportHandle, Description: "handle the reports"}
+	exist, err := x.Context(ctx).Get(&entity.Power{ID: power.ID})
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err = x.Context(ctx).ID(power.ID).Update(power)
+	} else {
+		_, err = x.Context(ctx).Insert(power)
+	}
+	if err != nil {
+		return err
+	}
+
+	rolePowerRels := []*entity.RolePowerRel{
+		{RoleID: 2, PowerType: permission.ReportHandle},
+		{RoleID: 3, PowerType: permission.ReportHandle},
+	}
+	for _, rel := range rolePowerRels {
+		exist, err := x.Context(ctx).Get(&entity.RolePowerRel{RoleID: rel.RoleID, PowerType: rel.PowerType})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		_, err = x.Context(ctx).Insert(rel)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
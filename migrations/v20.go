@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addAuditLog(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.AuditLog)); err != nil {
+		return fmt.Errorf("sync audit log table failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addDashboardStat(ctx context.Context, x *xorm.Engine) error {
+	err := x.Context(ctx).Sync(new(entity.DashboardDailyStat), new(entity.DashboardDailyTagStat),
+		new(entity.DashboardDailyUserStat))
+	if err != nil {
+		return fmt.Errorf("sync dashboard statistics table failed: %w", err)
+	}
+	return nil
+}
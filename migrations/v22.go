@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"xorm.io/xorm"
+)
+
+func addReputationPolicy(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.ReputationPolicy), new(entity.Activity)); err != nil {
+		return fmt.Errorf("sync reputation policy table failed: %w", err)
+	}
+
+	// the first version is built from the current daily rank limit config
+	policy := &schema.ReputationPolicy{
+		Version:         1,
+		Points:          map[string]int{},
+		DailyCap:        200,
+		DailyCapExclude: []string{},
+		MinRank:         1,
+		TagMultipliers:  map[string]float64{},
+	}
+	dailyLimit := &entity.Config{Key: "daily_rank_limit"}
+	if exist, err := x.Context(ctx).Get(dailyLimit); err != nil {
+		return err
+	} else if exist {
+		policy.DailyCap = dailyLimit.GetIntValue()
+	}
+	exclude := &entity.Config{Key: "daily_rank_limit.exclude"}
+	if exist, err := x.Context(ctx).Get(exclude); err != nil {
+		return err
+	} else if exist {
+		policy.DailyCapExclude = exclude.GetArrayStringValue()
+	}
+	content, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	cfg := &entity.Config{ID: 131, Key: entity.ReputationPolicyConfigKey, Value: string(content)}
+	exist, err := x.Context(ctx).Get(&entity.Config{ID: cfg.ID})
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err = x.Context(ctx).Update(cfg, &entity.Config{ID: cfg.ID})
+	} else {
+		_, err = x.Context(ctx).Insert(cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	exist, err = x.Context(ctx).Get(&entity.ReputationPolicy{Version: policy.Version})
+	if err != nil {
+		return err
+	}
+	if exist {
+		return nil
+	}
+	_, err = x.Context(ctx).Insert(&entity.ReputationPolicy{Version: policy.Version, Content: string(content)})
+	return err
+}
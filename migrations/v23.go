@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addBadges(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.Badge), new(entity.BadgeAward), new(entity.User)); err != nil {
+		return fmt.Errorf("sync badge table failed: %w", err)
+	}
+	for _, badge := range badges {
+		exist, err := x.Context(ctx).Get(&entity.Badge{ID: badge.ID})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(badge); err != nil {
+			return fmt.Errorf("insert badge %s failed: %w", badge.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addQuestionLifecycle(ctx context.Context, x *xorm.Engine) error {
+	configs := []*entity.Config{
+		{ID: 132, Key: "question.accept_reminded", Value: `0`},
+		{ID: 133, Key: "question.bumped", Value: `0`},
+		{ID: 134, Key: entity.QuestionLifecycleConfigKey, Value: defaultQuestionLifecyclePolicyContent},
+	}
+	for _, c := range configs {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+				return fmt.Errorf("update config failed: %w", err)
+			}
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(c); err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addResponseTemplate(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.ResponseTemplate), new(entity.ResponseTemplateUse)); err != nil {
+		return fmt.Errorf("sync response template table failed: %w", err)
+	}
+	return nil
+}
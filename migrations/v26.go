@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addAnswerDisclaimer(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.AnswerDisclaimerAck)); err != nil {
+		return fmt.Errorf("sync answer disclaimer ack table failed: %w", err)
+	}
+	c := &entity.Config{ID: 135, Key: entity.AnswerDisclaimerConfigKey, Value: defaultAnswerDisclaimerPolicyContent}
+	exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+			return fmt.Errorf("update config failed: %w", err)
+		}
+		return nil
+	}
+	if _, err = x.Context(ctx).Insert(c); err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
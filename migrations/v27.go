@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addRevisionReview(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.RevisionReview)); err != nil {
+		return fmt.Errorf("sync revision review table failed: %w", err)
+	}
+	configs := []*entity.Config{
+		{ID: 136, Key: entity.RevisionReviewConfigKey, Value: defaultRevisionReviewPolicyContent},
+		{ID: 137, Key: "revision.reviewed", Value: `2`},
+	}
+	for _, c := range configs {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+				return fmt.Errorf("update config failed: %w", err)
+			}
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(c); err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
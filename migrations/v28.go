@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addPreModeration(ctx context.Context, x *xorm.Engine) error {
+	c := &entity.Config{ID: 138, Key: entity.PreModerationConfigKey, Value: defaultPreModerationPolicyContent}
+	exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+			return fmt.Errorf("update config failed: %w", err)
+		}
+		return nil
+	}
+	if _, err = x.Context(ctx).Insert(c); err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"github.com/lawyer/commons/constant"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+// siteInfoConfigs the config ids of the site settings types
+var siteInfoConfigs = []struct {
+	ID       int
+	SiteType string
+}{
+	{ID: 140, SiteType: constant.SiteTypeGeneral},
+	{ID: 141, SiteType: constant.SiteTypeInterface},
+	{ID: 142, SiteType: constant.SiteTypeBranding},
+	{ID: 143, SiteType: constant.SiteTypeWrite},
+	{ID: 144, SiteType: constant.SiteTypeLegal},
+	{ID: 145, SiteType: constant.SiteTypeSeo},
+	{ID: 146, SiteType: constant.SiteTypeLogin},
+	{ID: 147, SiteType: constant.SiteTypeCustomCssHTML},
+	{ID: 148, SiteType: constant.SiteTypeTheme},
+	{ID: 149, SiteType: constant.SiteTypePrivileges},
+	{ID: 150, SiteType: constant.SiteTypeUsers},
+}
+
+func addSiteInfoHistory(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.SiteInfoHistory)); err != nil {
+		return fmt.Errorf("sync site info history table failed: %w", err)
+	}
+	return moveSiteInfoToConfig(ctx, x)
+}
+
+// moveSiteInfoToConfig copy the site settings from the site_info table to the config table,
+// the existing configs are kept
+func moveSiteInfoToConfig(ctx context.Context, x *xorm.Engine) error {
+	for _, item := range siteInfoConfigs {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: item.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			continue
+		}
+		c := &entity.Config{ID: item.ID, Key: constant.SiteInfoConfigKeyPrefix + item.SiteType, Value: `{}`}
+		siteInfo := &entity.SiteInfo{Type: item.SiteType, Status: 1}
+		exist, err = x.Context(ctx).Get(siteInfo)
+		if err != nil {
+			return fmt.Errorf("get site info failed: %w", err)
+		}
+		if exist && len(siteInfo.Content) > 0 {
+			c.Value = siteInfo.Content
+		}
+		if _, err = x.Context(ctx).Insert(c); err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addQuestionDailyViewStat(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.QuestionDailyViewStat)); err != nil {
+		return fmt.Errorf("sync question daily view stat table failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addUserTwoFactor(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.UserTwoFactor)); err != nil {
+		return fmt.Errorf("sync user two factor table failed: %w", err)
+	}
+	c := &entity.Config{ID: 151, Key: entity.TwoFactorPolicyConfigKey, Value: defaultTwoFactorPolicyContent}
+	exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+			return fmt.Errorf("update config failed: %w", err)
+		}
+		return nil
+	}
+	if _, err = x.Context(ctx).Insert(c); err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+func addUserDeletion(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.UserDeletion)); err != nil {
+		return fmt.Errorf("sync user deletion table failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	entity "github.com/lawyer/commons/entity"
+	"github.com/lawyer/service/permission"
+	"xorm.io/xorm"
+)
+
+func addResponseTemplatePower(ctx context.Context, x *xorm.Engine) error {
+	power := &entity.Power{ID: 43, Name: "response template manage", PowerType: permission.ResponseTemplateManage,
+		Description: "manage the site-wide response templates"}
+	exist, err := x.Context(ctx).Get(&entity.Power{ID: power.ID})
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err = x.Context(ctx).ID(power.ID).Update(power)
+	} else {
+		_, err = x.Context(ctx).Insert(power)
+	}
+	if err != nil {
+		return err
+	}
+
+	rolePowerRels := []*entity.RolePowerRel{
+		{RoleID: 2, PowerType: permission.ResponseTemplateManage},
+		{RoleID: 3, PowerType: permission.ResponseTemplateManage},
+	}
+	for _, rel := range rolePowerRels {
+		exist, err := x.Context(ctx).Get(&entity.RolePowerRel{RoleID: rel.RoleID, PowerType: rel.PowerType})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		_, err = x.Context(ctx).Insert(rel)
+		if err != nil {
+			return err
+		}
+	}
+
+	// no rank is enough to manage the site-wide response templates, only the power
+	c := &entity.Config{ID: 152, Key: "rank.response_template.manage", Value: `-1`}
+	exist, err = x.Context(ctx).Get(&entity.Config{ID: c.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+			return fmt.Errorf("update config failed: %w", err)
+		}
+		return nil
+	}
+	if _, err = x.Context(ctx).Insert(c); err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	entity "github.com/lawyer/commons/entity"
+	"xorm.io/xorm"
+)
+
+// addSpamHoldScore move the hold score adjusted by the review decisions out of the spam policy
+func addSpamHoldScore(ctx context.Context, x *xorm.Engine) error {
+	policy := &struct {
+		HoldScore int `json:"hold_score"`
+	}{HoldScore: 10}
+	spamPolicy := &entity.Config{Key: entity.SpamPolicyConfigKey}
+	exist, err := x.Context(ctx).Get(spamPolicy)
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist && len(spamPolicy.Value) > 0 {
+		_ = json.Unmarshal([]byte(spamPolicy.Value), policy)
+	}
+
+	c := &entity.Config{ID: 153, Key: entity.SpamHoldScoreConfigKey, Value: strconv.Itoa(policy.HoldScore)}
+	exist, err = x.Context(ctx).Get(&entity.Config{ID: c.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+			return fmt.Errorf("update config failed: %w", err)
+		}
+		return nil
+	}
+	if _, err = x.Context(ctx).Insert(c); err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
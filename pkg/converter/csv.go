@@ -0,0 +1,17 @@
+package converter
+
+import "strings"
+
+// csvFormulaPrefixes the spreadsheet runs the cell starting with them as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// EscapeCSVFormula prefix the cells starting with = + - @ tab or carriage return with ',
+// so the exported csv can not run a formula when it is opened in a spreadsheet
+func EscapeCSVFormula(record []string) []string {
+	for i, cell := range record {
+		if len(cell) > 0 && strings.IndexByte(csvFormulaPrefixes, cell[0]) >= 0 {
+			record[i] = "'" + cell
+		}
+	}
+	return record
+}
@@ -0,0 +1,13 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeCSVFormula(t *testing.T) {
+	assert.Equal(t,
+		[]string{"'=SUM(A1)", "'+1", "'-1", "'@cmd", "'\tx", "'\rx", "alice", "", "a=b"},
+		EscapeCSVFormula([]string{"=SUM(A1)", "+1", "-1", "@cmd", "\tx", "\rx", "alice", "", "a=b"}))
+}
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+var variableRegexp = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+// ReplaceVariables replace the variables like {{name}} in the content with the values,
+// the variables without value are kept as they are.
+func ReplaceVariables(content string, values map[string]string) string {
+	return variableRegexp.ReplaceAllStringFunc(content, func(s string) string {
+		name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, "{{"), "}}"))
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return s
+	})
+}
@@ -0,0 +1,14 @@
+package converter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReplaceVariables(t *testing.T) {
+	values := map[string]string{"asker_name": "Alice", "question_tags": "tax, contract"}
+	assert.Equal(t, "Hi Alice, about tax, contract",
+		ReplaceVariables("Hi {{asker_name}}, about {{ question_tags }}", values))
+	assert.Equal(t, "Hi {{unknown}}", ReplaceVariables("Hi {{unknown}}", values))
+	assert.Equal(t, "no variable", ReplaceVariables("no variable", values))
+}
@@ -14,3 +14,41 @@ func TestFormat(t *testing.T) {
 	expected := time.Unix(sec, 0).Format("2006-01-02 15:04:05")
 	assert.Equal(t, expected, actual)
 }
+
+func TestDates(t *testing.T) {
+	dates, err := Dates("2024-02-27", "2024-03-01", time.UTC)
+	assert.NoError(t, err)
+	actual := make([]string, 0, len(dates))
+	for _, d := range dates {
+		actual = append(actual, d.Format(DateLayout))
+	}
+	assert.Equal(t, []string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01"}, actual)
+
+	dates, err = Dates("2024-03-01", "2024-03-01", time.UTC)
+	assert.NoError(t, err)
+	assert.Len(t, dates, 1)
+
+	_, err = Dates("2024-03-02", "2024-03-01", time.UTC)
+	assert.Error(t, err)
+	_, err = Dates("2024/03/01", "2024-03-01", time.UTC)
+	assert.Error(t, err)
+}
+
+func TestStreak(t *testing.T) {
+	end := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 28, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 26, 8, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, 3, Streak(times, end, time.UTC))
+	assert.Equal(t, 0, Streak(times, end.AddDate(0, 0, 1), time.UTC))
+	assert.Equal(t, 0, Streak(nil, end, time.UTC))
+
+	// 2024-02-29 23:00 UTC is 2024-03-01 in UTC+8
+	loc := time.FixedZone("UTC+8", 8*3600)
+	assert.Equal(t, 0, Streak(times[1:2], end, time.UTC))
+	assert.Equal(t, 1, Streak(times[1:2], end, loc))
+}
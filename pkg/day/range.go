@@ -0,0 +1,42 @@
+package day
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateLayout the layout of the date without time
+const DateLayout = "2006-01-02"
+
+// Dates parse the start and end date in the location, returns the beginning of every day between them,
+// both the start and end date are included.
+func Dates(startDate, endDate string, loc *time.Location) (dates []time.Time, err error) {
+	start, err := time.ParseInLocation(DateLayout, startDate, loc)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.ParseInLocation(DateLayout, endDate, loc)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", endDate, startDate)
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+	return dates, nil
+}
+
+// Streak returns the number of consecutive days ending at the day of end which have at least one of the times,
+// the days are counted in the location.
+func Streak(times []time.Time, end time.Time, loc *time.Location) (streak int) {
+	days := make(map[string]bool, len(times))
+	for _, t := range times {
+		days[t.In(loc).Format(DateLayout)] = true
+	}
+	for d := end.In(loc); days[d.Format(DateLayout)]; d = d.AddDate(0, 0, -1) {
+		streak++
+	}
+	return streak
+}
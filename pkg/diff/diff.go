@@ -0,0 +1,229 @@
+package diff
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+
+	// maxEditDistance if the texts differ more than it, the changed part is shown as deleted and inserted as a whole
+	maxEditDistance = 2000
+)
+
+// Hunk the continuous text with the same operation
+type Hunk struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Words diff the texts word by word, the whitespaces and the punctuations are kept so that
+// joining the equal and deleted hunks gets the old text and joining the equal and inserted hunks gets the new text
+func Words(oldText, newText string) []*Hunk {
+	a, b := tokenize(oldText), tokenize(newText)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	hunks := make([]*Hunk, 0)
+	hunks = appendHunk(hunks, OpEqual, a[:prefix]...)
+	hunks = append(hunks, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	hunks = appendHunk(hunks, OpEqual, a[len(a)-suffix:]...)
+	return hunks
+}
+
+// Set diff the items as sets, the order of the items is kept
+func Set(oldItems, newItems []string) (added, removed, kept []string) {
+	oldSet := make(map[string]bool, len(oldItems))
+	for _, item := range oldItems {
+		oldSet[item] = true
+	}
+	newSet := make(map[string]bool, len(newItems))
+	for _, item := range newItems {
+		newSet[item] = true
+	}
+	added, removed, kept = make([]string, 0), make([]string, 0), make([]string, 0)
+	for _, item := range newItems {
+		if oldSet[item] {
+			kept = append(kept, item)
+		} else {
+			added = append(added, item)
+		}
+	}
+	for _, item := range oldItems {
+		if !newSet[item] {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed, kept
+}
+
+// Changed whether there is any inserted or deleted hunk
+func Changed(hunks []*Hunk) bool {
+	for _, hunk := range hunks {
+		if hunk.Op != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// SideBySideHTML render the hunks as a table with the old text on the left and the new text on the right,
+// the deleted text is wrapped with del and the inserted text is wrapped with ins
+func SideBySideHTML(hunks []*Hunk) string {
+	var left, right strings.Builder
+	for _, hunk := range hunks {
+		text := html.EscapeString(hunk.Text)
+		switch hunk.Op {
+		case OpEqual:
+			left.WriteString(text)
+			right.WriteString(text)
+		case OpDelete:
+			left.WriteString("<del>" + text + "</del>")
+		case OpInsert:
+			right.WriteString("<ins>" + text + "</ins>")
+		}
+	}
+	return `<table class="diff"><tr><td class="diff-old">` + left.String() +
+		`</td><td class="diff-new">` + right.String() + `</td></tr></table>`
+}
+
+// tokenize split the text into words, whitespaces and single other characters, every Han character is a word
+func tokenize(text string) []string {
+	tokens := make([]string, 0)
+	runes := []rune(text)
+	kind := func(r rune) int {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			return 0
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		default:
+			return 0
+		}
+	}
+	for start := 0; start < len(runes); {
+		k := kind(runes[start])
+		end := start + 1
+		if k != 0 {
+			for end < len(runes) && kind(runes[end]) == k {
+				end++
+			}
+		}
+		tokens = append(tokens, string(runes[start:end]))
+		start = end
+	}
+	return tokens
+}
+
+// myers the shortest edit script of the tokens, see "An O(ND) Difference Algorithm and Its Variations"
+func myers(a, b []string) []*Hunk {
+	n, m := len(a), len(b)
+	hunks := make([]*Hunk, 0)
+	if n == 0 || m == 0 {
+		hunks = appendHunk(hunks, OpDelete, a...)
+		return appendHunk(hunks, OpInsert, b...)
+	}
+
+	max := n + m
+	if max > maxEditDistance {
+		max = maxEditDistance
+	}
+	// trace[d] is the furthest x of every diagonal k in [-d, d] after d edits, indexed by k+d
+	trace := make([][]int, 0)
+	v := []int{0}
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		next := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+d-1] < v[k+1+d-1]) {
+				x = v[k+1+d-1]
+			} else {
+				x = v[k-1+d-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			next[k+d] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		trace = append(trace, next)
+		v = next
+	}
+	if !found {
+		hunks = appendHunk(hunks, OpDelete, a...)
+		return appendHunk(hunks, OpInsert, b...)
+	}
+
+	// walk back from the end to collect the edits in reverse order
+	type edit struct {
+		op    string
+		token string
+	}
+	edits := make([]edit, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{OpEqual, a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{OpInsert, b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{OpDelete, a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, edit{OpEqual, a[x]})
+	}
+	for i := len(edits) - 1; i >= 0; i-- {
+		hunks = appendHunk(hunks, edits[i].op, edits[i].token)
+	}
+	return hunks
+}
+
+// appendHunk append the tokens to the last hunk if it has the same operation
+func appendHunk(hunks []*Hunk, op string, tokens ...string) []*Hunk {
+	if len(tokens) == 0 {
+		return hunks
+	}
+	text := strings.Join(tokens, "")
+	if len(hunks) > 0 && hunks[len(hunks)-1].Op == op {
+		hunks[len(hunks)-1].Text += text
+		return hunks
+	}
+	return append(hunks, &Hunk{Op: op, Text: text})
+}
@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func join(hunks []*Hunk, skip string) string {
+	var b strings.Builder
+	for _, hunk := range hunks {
+		if hunk.Op != skip {
+			b.WriteString(hunk.Text)
+		}
+	}
+	return b.String()
+}
+
+func TestWords(t *testing.T) {
+	hunks := Words("The tenant must pay rent monthly.", "The tenant shall pay the rent monthly.")
+	assert.Equal(t, []*Hunk{
+		{Op: OpEqual, Text: "The tenant "},
+		{Op: OpDelete, Text: "must"},
+		{Op: OpInsert, Text: "shall"},
+		{Op: OpEqual, Text: " pay"},
+		{Op: OpInsert, Text: " the"},
+		{Op: OpEqual, Text: " rent monthly."},
+	}, hunks)
+	assert.True(t, Changed(hunks))
+
+	cases := [][2]string{
+		{"", "new text"},
+		{"old text", ""},
+		{"a b c d e f", "f e d c b a"},
+		{"合同无效", "合同有效"},
+		{"## Title\n\n- item one\n- item two", "## Title\n\n- item one\n- item 2\n- item three"},
+	}
+	for _, c := range cases {
+		hunks = Words(c[0], c[1])
+		assert.Equal(t, c[0], join(hunks, OpInsert))
+		assert.Equal(t, c[1], join(hunks, OpDelete))
+	}
+
+	hunks = Words("same", "same")
+	assert.False(t, Changed(hunks))
+}
+
+func TestSet(t *testing.T) {
+	added, removed, kept := Set([]string{"tax", "contract", "labor"}, []string{"contract", "family", "tax"})
+	assert.Equal(t, []string{"family"}, added)
+	assert.Equal(t, []string{"labor"}, removed)
+	assert.Equal(t, []string{"contract", "tax"}, kept)
+}
+
+func TestSideBySideHTML(t *testing.T) {
+	hunks := Words("a <b>", "a <i>")
+	assert.Equal(t, `<table class="diff"><tr><td class="diff-old">a &lt;<del>b</del>&gt;</td>`+
+		`<td class="diff-new">a &lt;<ins>i</ins>&gt;</td></tr></table>`, SideBySideHTML(hunks))
+}
@@ -0,0 +1,167 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Feed the channel of the feed
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	Updated     time.Time
+	Items       []*Item
+}
+
+// Item the entry of the feed
+type Item struct {
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+type rss struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	DC      string      `xml:"xmlns:dc,attr"`
+	Channel *rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description,omitempty"`
+	Author      string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+}
+
+type atom struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string       `xml:"title"`
+	ID      string       `xml:"id"`
+	Link    *atomLink    `xml:"link"`
+	Updated string       `xml:"updated"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title      string          `xml:"title"`
+	ID         string          `xml:"id"`
+	Link       *atomLink       `xml:"link"`
+	Published  string          `xml:"published,omitempty"`
+	Updated    string          `xml:"updated"`
+	Author     *atomAuthor     `xml:"author,omitempty"`
+	Categories []*atomCategory `xml:"category"`
+	Summary    *atomSummary    `xml:"summary,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomSummary struct {
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// RSS encode the feed as rss 2.0
+func (f *Feed) RSS() ([]byte, error) {
+	channel := &rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Items:       make([]*rssItem, 0, len(f.Items)),
+	}
+	if !f.Updated.IsZero() {
+		channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, item := range f.Items {
+		ri := &rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			Author:      item.Author,
+			Categories:  item.Categories,
+			GUID:        item.Link,
+		}
+		if !item.Published.IsZero() {
+			ri.PubDate = item.Published.UTC().Format(time.RFC1123Z)
+		}
+		channel.Items = append(channel.Items, ri)
+	}
+	return marshal(&rss{Version: "2.0", DC: "http://purl.org/dc/elements/1.1/", Channel: channel})
+}
+
+// Atom encode the feed as atom 1.0
+func (f *Feed) Atom() ([]byte, error) {
+	doc := &atom{
+		Title:   f.Title,
+		ID:      f.Link,
+		Link:    &atomLink{Href: f.Link},
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Entries: make([]*atomEntry, 0, len(f.Items)),
+	}
+	for _, item := range f.Items {
+		updated := item.Updated
+		if updated.IsZero() {
+			updated = item.Published
+		}
+		entry := &atomEntry{
+			Title:      item.Title,
+			ID:         item.Link,
+			Link:       &atomLink{Href: item.Link},
+			Updated:    updated.UTC().Format(time.RFC3339),
+			Categories: make([]*atomCategory, 0, len(item.Categories)),
+		}
+		if !item.Published.IsZero() {
+			entry.Published = item.Published.UTC().Format(time.RFC3339)
+		}
+		if len(item.Author) > 0 {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, &atomCategory{Term: category})
+		}
+		if len(item.Description) > 0 {
+			entry.Summary = &atomSummary{Type: "text", Content: item.Description}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return marshal(doc)
+}
+
+func marshal(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,57 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFeed() *Feed {
+	published := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	return &Feed{
+		Title:       "Newest questions - Lawyer",
+		Link:        "https://example.com/questions",
+		Description: "The newest questions",
+		Updated:     published,
+		Items: []*Item{
+			{
+				Title:       "Can a landlord keep the deposit?",
+				Link:        "https://example.com/questions/10010000000000001",
+				Description: "The lease ended & the landlord <still> keeps it",
+				Author:      "Alice",
+				Categories:  []string{"lease", "deposit"},
+				Published:   published,
+			},
+		},
+	}
+}
+
+func TestFeed_RSS(t *testing.T) {
+	data, err := testFeed().RSS()
+	assert.NoError(t, err)
+	content := string(data)
+	assert.True(t, strings.HasPrefix(content, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, content, `<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">`)
+	assert.Contains(t, content, `<title>Can a landlord keep the deposit?</title>`)
+	assert.Contains(t, content, `<guid>https://example.com/questions/10010000000000001</guid>`)
+	assert.Contains(t, content, `<pubDate>Tue, 02 Jan 2024 15:04:05 +0000</pubDate>`)
+	assert.Contains(t, content, `<dc:creator>Alice</dc:creator>`)
+	assert.Contains(t, content, `<category>deposit</category>`)
+	assert.Contains(t, content, `&amp; the landlord &lt;still&gt; keeps it`)
+}
+
+func TestFeed_Atom(t *testing.T) {
+	data, err := testFeed().Atom()
+	assert.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, content, `<id>https://example.com/questions</id>`)
+	assert.Contains(t, content, `<link href="https://example.com/questions/10010000000000001"></link>`)
+	assert.Contains(t, content, `<published>2024-01-02T15:04:05Z</published>`)
+	assert.Contains(t, content, `<updated>2024-01-02T15:04:05Z</updated>`)
+	assert.Contains(t, content, `<name>Alice</name>`)
+	assert.Contains(t, content, `<category term="lease"></category>`)
+	assert.Contains(t, content, `<summary type="text">`)
+}
@@ -104,6 +104,36 @@ func FetchExcerpt(html, trimMarker string, limit int) (text string) {
 	return
 }
 
+var linkRe = regexp.MustCompile(`(?i)https?://[^\s"'<>()\[\]]+`)
+
+// FetchLinkHosts return the lower case host of every http or https link in the markdown or HTML text,
+// the repeated links are kept so that the result can be counted
+func FetchLinkHosts(text string) (hosts []string) {
+	hosts = make([]string, 0)
+	for _, link := range linkRe.FindAllString(text, -1) {
+		// the punctuation after the link is not a part of it
+		u, err := url.Parse(strings.TrimRight(link, ".,;:!?"))
+		if err != nil || len(u.Hostname()) == 0 {
+			continue
+		}
+		hosts = append(hosts, strings.ToLower(u.Hostname()))
+	}
+	return hosts
+}
+
+var imgSrcRe = regexp.MustCompile(`(?is)<img[^>]*?\ssrc\s*=\s*["']([^"']+)["']`)
+
+// FetchFirstImage return the src of the first image in the HTML, the inline data images are skipped
+func FetchFirstImage(html string) string {
+	for _, match := range imgSrcRe.FindAllStringSubmatch(html, -1) {
+		src := strings.TrimSpace(match[1])
+		if len(src) > 0 && !strings.HasPrefix(strings.ToLower(src), "data:") {
+			return src
+		}
+	}
+	return ""
+}
+
 func GetPicByUrl(Url string) string {
 	res, err := http.Get(Url)
 	if err != nil {
@@ -66,3 +66,20 @@ func TestUrlTitle(t *testing.T) {
 		fmt.Println(formatTitle)
 	}
 }
+
+func TestFetchLinkHosts(t *testing.T) {
+	hosts := FetchLinkHosts("see [docs](https://Example.com/a?b=1) and http://spam.example.org, or https://example.com")
+	assert.Equal(t, []string{"example.com", "spam.example.org", "example.com"}, hosts)
+
+	hosts = FetchLinkHosts(`<p><a href="https://example.com/">example</a> ftp://example.net</p>`)
+	assert.Equal(t, []string{"example.com"}, hosts)
+
+	assert.Empty(t, FetchLinkHosts("no link here"))
+}
+
+func TestFetchFirstImage(t *testing.T) {
+	html := `<p>contract</p><img alt="a" src="data:image/png;base64,AAAA"><IMG class="x" src='/uploads/post/a.png'><img src="https://example.com/b.png">`
+	assert.Equal(t, "/uploads/post/a.png", FetchFirstImage(html))
+	assert.Equal(t, "https://example.com/b.png", FetchFirstImage(`<p><img src="https://example.com/b.png" /></p>`))
+	assert.Equal(t, "", FetchFirstImage(`<p>no image</p>`))
+}
@@ -0,0 +1,301 @@
+// Package oidc is a minimal OpenID Connect relying party of the authorization code flow with PKCE.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// maxResponseSize the max size of the responses of the provider
+const maxResponseSize = 1 << 20
+
+// keysRefreshInterval the keys are fetched again for the unknown key id at most once in the interval
+const keysRefreshInterval = time.Minute
+
+var (
+	ErrInvalidIssuer  = errors.New("oidc: issuer of the discovery document does not match")
+	ErrInvalidIDToken = errors.New("oidc: invalid id token")
+	ErrNoUserInfo     = errors.New("oidc: userinfo endpoint is not provided")
+)
+
+// Metadata the provider metadata of the discovery document
+type Metadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	UserinfoEndpoint              string   `json:"userinfo_endpoint"`
+	JWKSURI                       string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// Provider the OpenID provider, the signing keys are cached and refreshed when an unknown key is used
+type Provider struct {
+	Metadata Metadata
+
+	client        *http.Client
+	mu            sync.Mutex
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+// AuthRequest the parameters of the authorization request
+type AuthRequest struct {
+	ClientID     string
+	RedirectURI  string
+	Scopes       []string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// Token the token response of the token endpoint
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Claims the claims of the id token or the userinfo response
+type Claims map[string]any
+
+// String get the claim as string, the numbers are formatted as well
+func (c Claims) String(name string) string {
+	switch v := c[name].(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
+
+// Bool get the claim as bool, some providers send the booleans as string
+func (c Claims) Bool(name string) (value, ok bool) {
+	switch v := c[name].(type) {
+	case bool:
+		return v, true
+	case string:
+		return strings.EqualFold(v, "true"), true
+	}
+	return false, false
+}
+
+// Discover get the discovery document of the issuer
+func Discover(ctx context.Context, client *http.Client, issuer string) (provider *Provider, err error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	provider = &Provider{client: client}
+	if err = provider.doJSON(req, &provider.Metadata); err != nil {
+		return nil, fmt.Errorf("oidc: discovery failed: %w", err)
+	}
+	if strings.TrimSuffix(provider.Metadata.Issuer, "/") != issuer {
+		return nil, ErrInvalidIssuer
+	}
+	if len(provider.Metadata.AuthorizationEndpoint) == 0 || len(provider.Metadata.TokenEndpoint) == 0 ||
+		len(provider.Metadata.JWKSURI) == 0 {
+		return nil, errors.New("oidc: discovery document misses the required endpoints")
+	}
+	return provider, nil
+}
+
+// RandomString generate a random url-safe string for the state, nonce and code verifier
+func RandomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CodeChallenge the S256 code challenge of the code verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL the url of the authorization endpoint to redirect the user to
+func (p *Provider) AuthCodeURL(req *AuthRequest) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", req.ClientID)
+	params.Set("redirect_uri", req.RedirectURI)
+	params.Set("scope", strings.Join(req.Scopes, " "))
+	params.Set("state", req.State)
+	if len(req.Nonce) > 0 {
+		params.Set("nonce", req.Nonce)
+	}
+	if len(req.CodeVerifier) > 0 {
+		params.Set("code_challenge", CodeChallenge(req.CodeVerifier))
+		params.Set("code_challenge_method", "S256")
+	}
+	sep := "?"
+	if strings.Contains(p.Metadata.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.Metadata.AuthorizationEndpoint + sep + params.Encode()
+}
+
+// Exchange exchange the authorization code for the tokens, the client authenticates with client_secret_basic
+// when the secret is set
+func (p *Provider) Exchange(ctx context.Context, clientID, clientSecret, redirectURI, code, codeVerifier string) (
+	token *Token, err error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", clientID)
+	if len(codeVerifier) > 0 {
+		form.Set("code_verifier", codeVerifier)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Metadata.TokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if len(clientSecret) > 0 {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+	token = &Token{}
+	if err = p.doJSON(req, token); err != nil {
+		return nil, fmt.Errorf("oidc: token exchange failed: %w", err)
+	}
+	if len(token.IDToken) == 0 {
+		return nil, errors.New("oidc: token response misses the id token")
+	}
+	return token, nil
+}
+
+// VerifyIDToken verify the signature, issuer, audience, expiration and nonce of the id token
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, clientID, nonce string) (claims Claims, err error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{
+		"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512",
+	}))
+	mapClaims := jwt.MapClaims{}
+	_, err = parser.ParseWithClaims(rawIDToken, mapClaims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	claims = Claims(mapClaims)
+
+	if _, ok := mapClaims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidIDToken)
+	}
+	if strings.TrimSuffix(claims.String("iss"), "/") != strings.TrimSuffix(p.Metadata.Issuer, "/") {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.String("iss"))
+	}
+	if !mapClaims.VerifyAudience(clientID, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	}
+	if aud, ok := mapClaims["aud"].([]interface{}); ok && len(aud) > 1 && claims.String("azp") != clientID {
+		return nil, fmt.Errorf("%w: unexpected authorized party", ErrInvalidIDToken)
+	}
+	if len(nonce) > 0 && claims.String("nonce") != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidIDToken)
+	}
+	if len(claims.String("sub")) == 0 {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// UserInfo get the claims from the userinfo endpoint
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (claims Claims, err error) {
+	if len(p.Metadata.UserinfoEndpoint) == 0 {
+		return nil, ErrNoUserInfo
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Metadata.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	claims = Claims{}
+	if err = p.doJSON(req, &claims); err != nil {
+		return nil, fmt.Errorf("oidc: userinfo failed: %w", err)
+	}
+	return claims, nil
+}
+
+// getKey get the signing key by the key id, the key set is fetched again when the key is unknown
+func (p *Provider) getKey(ctx context.Context, kid string) (key any, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysFetchedAt) < keysRefreshInterval {
+		return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+	}
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+}
+
+// findKey find the key by the key id, the only key is used when the token has no key id
+func (p *Provider) findKey(kid string) (key any, ok bool) {
+	if len(kid) == 0 && len(p.keys) == 1 {
+		for _, key = range p.keys {
+			return key, true
+		}
+	}
+	key, ok = p.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) (keys map[string]any, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	set := &jsonWebKeySet{}
+	if err = p.doJSON(req, set); err != nil {
+		return nil, fmt.Errorf("oidc: fetch keys failed: %w", err)
+	}
+	return set.publicKeys(), nil
+}
+
+func (p *Provider) doJSON(req *http.Request, out any) (err error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,214 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "lawyer"
+	testClientSecret = "secret"
+	testRedirectURI  = "https://lawyer.example.com/connector/redirect/oidc"
+	testCode         = "auth-code"
+)
+
+// stubIdP a local OpenID provider which issues the id token signed by its rsa key
+type stubIdP struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idp := &stubIdP{key: key}
+
+	mux := http.NewServeMux()
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	issuer := idp.server.URL
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/authorize",
+			"token_endpoint":         issuer + "/token",
+			"userinfo_endpoint":      issuer + "/userinfo",
+			"jwks_uri":               issuer + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != testClientID || secret != testClientSecret || r.FormValue("code") != testCode ||
+			r.FormValue("redirect_uri") != testRedirectURI || CodeChallenge(r.FormValue("code_verifier")) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     idp.sign(t, idp.claims),
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"sub": "user-1", "picture": "https://example.com/a.png"})
+	})
+	return idp
+}
+
+func (idp *stubIdP) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key-1"
+	raw, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+	return raw
+}
+
+func (idp *stubIdP) validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"sub":            "user-1",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "user@example.com",
+		"email_verified": true,
+	}
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp := newStubIdP(t)
+	ctx := context.Background()
+	provider, err := Discover(ctx, idp.server.Client(), idp.server.URL+"/")
+	require.NoError(t, err)
+
+	state, _ := RandomString()
+	nonce, _ := RandomString()
+	verifier, _ := RandomString()
+	authURL, err := url.Parse(provider.AuthCodeURL(&AuthRequest{
+		ClientID:     testClientID,
+		RedirectURI:  testRedirectURI,
+		Scopes:       []string{"openid", "email"},
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	}))
+	require.NoError(t, err)
+	query := authURL.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+	assert.Equal(t, state, query.Get("state"))
+	assert.Equal(t, nonce, query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	idp.challenge = query.Get("code_challenge")
+	idp.claims = idp.validClaims(nonce)
+
+	_, err = provider.Exchange(ctx, testClientID, testClientSecret, testRedirectURI, testCode, "wrong-verifier")
+	assert.Error(t, err)
+
+	token, err := provider.Exchange(ctx, testClientID, testClientSecret, testRedirectURI, testCode, verifier)
+	require.NoError(t, err)
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, testClientID, nonce)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.String("sub"))
+	assert.Equal(t, "user@example.com", claims.String("email"))
+	verified, ok := claims.Bool("email_verified")
+	assert.True(t, ok)
+	assert.True(t, verified)
+
+	info, err := provider.UserInfo(ctx, token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a.png", info.String("picture"))
+}
+
+func TestVerifyIDTokenRejects(t *testing.T) {
+	idp := newStubIdP(t)
+	ctx := context.Background()
+	provider, err := Discover(ctx, idp.server.Client(), idp.server.URL)
+	require.NoError(t, err)
+
+	cases := map[string]func(claims jwt.MapClaims){
+		"wrong nonce":    func(claims jwt.MapClaims) { claims["nonce"] = "other" },
+		"wrong audience": func(claims jwt.MapClaims) { claims["aud"] = "other-client" },
+		"wrong issuer":   func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+		"expired":        func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"missing exp":    func(claims jwt.MapClaims) { delete(claims, "exp") },
+		"missing azp": func(claims jwt.MapClaims) {
+			claims["aud"] = []string{testClientID, "other-client"}
+		},
+	}
+	for name, modify := range cases {
+		claims := idp.validClaims("nonce")
+		modify(claims)
+		_, err = provider.VerifyIDToken(ctx, idp.sign(t, claims), testClientID, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken, name)
+	}
+
+	// signed by another key
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.validClaims("nonce"))
+	token.Header["kid"] = "key-1"
+	raw, err := token.SignedString(otherKey)
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(ctx, raw, testClientID, "nonce")
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+
+	// the none algorithm is never accepted
+	raw, err = jwt.NewWithClaims(jwt.SigningMethodNone, idp.validClaims("nonce")).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(ctx, raw, testClientID, "nonce")
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	idp := newStubIdP(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	}))
+	defer server.Close()
+	_, err := Discover(context.Background(), server.Client(), server.URL)
+	assert.ErrorIs(t, err, ErrInvalidIssuer)
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// printf <verifier> | openssl dgst -sha256 -binary | base64url
+	assert.Equal(t, "7FoFquuT3i16caMX2omVsbsWt1Ogn3pdFPnBHenraIU",
+		CodeChallenge("dBjftJeZ4CVP-mB92K0uDShL-c1oZu4rkBHB5dZCw4k"))
+}
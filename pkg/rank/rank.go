@@ -0,0 +1,13 @@
+package rank
+
+// LimitMinRank limit the rank change so that the rank is not reduced to lower than the min rank.
+// The rank which is already lower than the min rank is not changed by the negative change, it is never raised.
+func LimitMinRank(currentRank, deltaRank, minRank int) int {
+	if deltaRank >= 0 || currentRank+deltaRank >= minRank {
+		return deltaRank
+	}
+	if currentRank <= minRank {
+		return 0
+	}
+	return minRank - currentRank
+}
@@ -0,0 +1,23 @@
+package rank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitMinRank(t *testing.T) {
+	// positive change is not limited
+	assert.Equal(t, 10, LimitMinRank(1, 10, 1))
+	assert.Equal(t, 10, LimitMinRank(-5, 10, 1))
+	// negative change above the min rank
+	assert.Equal(t, -2, LimitMinRank(10, -2, 1))
+	assert.Equal(t, -9, LimitMinRank(10, -9, 1))
+	// reduced to the min rank only
+	assert.Equal(t, -9, LimitMinRank(10, -20, 1))
+	assert.Equal(t, 0, LimitMinRank(1, -2, 1))
+	// the rank lower than the min rank is not raised
+	assert.Equal(t, 0, LimitMinRank(0, -2, 1))
+	assert.Equal(t, 0, LimitMinRank(-3, -2, -1))
+	assert.Equal(t, 0, LimitMinRank(1, -2, 5))
+}
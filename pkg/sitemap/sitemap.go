@@ -0,0 +1,43 @@
+package sitemap
+
+import (
+	"encoding/xml"
+)
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL the location of the page or of the sitemap
+type URL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []*URL   `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Sitemaps []*URL   `xml:"sitemap"`
+}
+
+// URLSet encode the pages as the sitemap
+func URLSet(urls []*URL) ([]byte, error) {
+	return marshal(&urlSet{Xmlns: namespace, URLs: urls})
+}
+
+// Index encode the sitemaps as the sitemap index
+func Index(sitemaps []*URL) ([]byte, error) {
+	return marshal(&sitemapIndex{Xmlns: namespace, Sitemaps: sitemaps})
+}
+
+func marshal(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
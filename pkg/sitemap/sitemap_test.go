@@ -0,0 +1,35 @@
+package sitemap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLSet(t *testing.T) {
+	data, err := URLSet([]*URL{
+		{Loc: "https://example.com/questions/10010000000000001", LastMod: "2024-01-02T15:04:05Z"},
+		{Loc: "https://example.com/questions/10010000000000002?a=1&b=2"},
+	})
+	assert.NoError(t, err)
+	content := string(data)
+	assert.True(t, strings.HasPrefix(content, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, content, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, content, `<loc>https://example.com/questions/10010000000000001</loc>`)
+	assert.Contains(t, content, `<lastmod>2024-01-02T15:04:05Z</lastmod>`)
+	assert.Contains(t, content, `<loc>https://example.com/questions/10010000000000002?a=1&amp;b=2</loc>`)
+	assert.Equal(t, 1, strings.Count(content, "<lastmod>"))
+}
+
+func TestIndex(t *testing.T) {
+	data, err := Index([]*URL{
+		{Loc: "https://example.com/sitemap/question-1.xml"},
+		{Loc: "https://example.com/sitemap/question-2.xml"},
+	})
+	assert.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Equal(t, 2, strings.Count(content, "<sitemap>"))
+	assert.Contains(t, content, `<loc>https://example.com/sitemap/question-2.xml</loc>`)
+}
@@ -0,0 +1,78 @@
+package useragent
+
+import "strings"
+
+// crawlerKeywords the keywords in the user agent of the known crawlers, http clients and headless browsers
+var crawlerKeywords = []string{
+	"bot", "crawl", "spider", "slurp", "archiver", "facebookexternalhit", "embedly", "preview",
+	"mediapartners", "bingpreview", "yandex", "baiduspider", "bytespider", "petalbot", "applebot",
+	"duckduckgo", "headlesschrome", "phantomjs", "lighthouse", "pingdom", "uptime", "monitor",
+	"curl", "wget", "python-requests", "python-urllib", "go-http-client", "java/", "okhttp",
+	"apache-httpclient", "libwww-perl", "httpie", "postman", "axios", "node-fetch",
+}
+
+// IsCrawler whether the user agent belongs to a crawler, the empty user agent is also treated as a crawler
+func IsCrawler(userAgent string) bool {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if len(userAgent) == 0 {
+		return true
+	}
+	for _, keyword := range crawlerKeywords {
+		if strings.Contains(userAgent, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// browserKeywords the keywords of the browsers, the order matters since most browsers contain the keywords of others
+var browserKeywords = []struct{ keyword, name string }{
+	{"micromessenger", "WeChat"},
+	{"edg/", "Edge"},
+	{"edga/", "Edge"},
+	{"edgios/", "Edge"},
+	{"opr/", "Opera"},
+	{"firefox/", "Firefox"},
+	{"fxios/", "Firefox"},
+	{"crios/", "Chrome"},
+	{"chrome/", "Chrome"},
+	{"safari/", "Safari"},
+}
+
+// osKeywords the keywords of the operating systems, the order matters as well
+var osKeywords = []struct{ keyword, name string }{
+	{"iphone", "iOS"},
+	{"ipad", "iPadOS"},
+	{"android", "Android"},
+	{"windows", "Windows"},
+	{"mac os x", "macOS"},
+	{"cros", "ChromeOS"},
+	{"linux", "Linux"},
+}
+
+// Device describe the device of the user agent as "browser on os", such as "Chrome on Windows".
+// The unknown part is omitted and the empty string is returned when both are unknown.
+func Device(userAgent string) string {
+	userAgent = strings.ToLower(userAgent)
+	var browser, os string
+	for _, item := range browserKeywords {
+		if strings.Contains(userAgent, item.keyword) {
+			browser = item.name
+			break
+		}
+	}
+	for _, item := range osKeywords {
+		if strings.Contains(userAgent, item.keyword) {
+			os = item.name
+			break
+		}
+	}
+	switch {
+	case len(browser) > 0 && len(os) > 0:
+		return browser + " on " + os
+	case len(browser) > 0:
+		return browser
+	default:
+		return os
+	}
+}
@@ -0,0 +1,318 @@
+// Package connector_oidc is the built-in connector of the OpenID Connect providers, such as Keycloak, Okta,
+// Auth0 and Google. It is enabled and configured by the admin like other plugins.
+package connector_oidc
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lawyer/pkg/oidc"
+	"github.com/lawyer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// loginCookieName the cookie keeps the state, nonce and code verifier of the login between the sender and receiver
+	loginCookieName = "lawyer_oidc_login"
+	loginCookieAge  = 10 * 60
+	defaultScopes   = "openid profile email"
+	httpTimeout     = 10 * time.Second
+)
+
+const logoSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">` +
+	`<path fill="#f7931e" d="M11 3.5 14 2v18.2c-5.3-.5-9-3.1-9-6.3 0-2.9 2.6-5.2 6-6V3.5z"/>` +
+	`<path fill="#b2b2b2" d="M14 8c2 .2 3.7.8 5 1.7L21 8.5V14h-6.5l2.4-1.4c-.8-.5-1.8-.8-2.9-1V8z"/></svg>`
+
+// Connector the OpenID Connect connector
+type Connector struct {
+	Config *ConnectorConfig
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// ConnectorConfig the config of the connector, the claim names can be changed for the providers
+// which do not follow the standard claims
+type ConnectorConfig struct {
+	Name                 string `json:"name"`
+	Issuer               string `json:"issuer"`
+	ClientID             string `json:"client_id"`
+	ClientSecret         string `json:"client_secret"`
+	Scopes               string `json:"scopes"`
+	ClaimExternalID      string `json:"claim_external_id"`
+	ClaimUsername        string `json:"claim_username"`
+	ClaimDisplayName     string `json:"claim_display_name"`
+	ClaimEmail           string `json:"claim_email"`
+	ClaimAvatar          string `json:"claim_avatar"`
+	TrustUnverifiedEmail bool   `json:"trust_unverified_email"`
+}
+
+// loginState the state of the login kept in the cookie of the browser, so the callback is bound to the browser
+// which starts the login
+type loginState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+func init() {
+	plugin.Register(&Connector{Config: defaultConfig()})
+}
+
+func defaultConfig() *ConnectorConfig {
+	return &ConnectorConfig{
+		Scopes:           defaultScopes,
+		ClaimExternalID:  "sub",
+		ClaimUsername:    "preferred_username",
+		ClaimDisplayName: "name",
+		ClaimEmail:       "email",
+		ClaimAvatar:      "picture",
+	}
+}
+
+func (c *Connector) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator("plugin.connector_oidc.info.name"),
+		SlugName:    "oidc_connector",
+		Description: plugin.MakeTranslator("plugin.connector_oidc.info.description"),
+		Author:      "lawyer",
+		Version:     "1.0.0",
+		Link:        "https://openid.net/specs/openid-connect-core-1_0.html",
+	}
+}
+
+func (c *Connector) ConnectorLogoSVG() string {
+	return logoSVG
+}
+
+func (c *Connector) ConnectorName() plugin.Translator {
+	if name := c.Config.Name; len(name) > 0 {
+		return plugin.Translator{Fn: func(ctx *plugin.GinContext) string { return name }}
+	}
+	return plugin.MakeTranslator("plugin.connector_oidc.info.name")
+}
+
+func (c *Connector) ConnectorSlugName() string {
+	return "oidc"
+}
+
+// ConnectorSender redirect the user to the authorization endpoint of the provider
+func (c *Connector) ConnectorSender(ctx *plugin.GinContext, receiverURL string) (redirectURL string) {
+	provider, err := c.getProvider(ctx)
+	if err != nil {
+		log.Errorf("oidc connector discovery failed: %v", err)
+		return ""
+	}
+	state := &loginState{}
+	for _, value := range []*string{&state.State, &state.Nonce, &state.CodeVerifier} {
+		if *value, err = oidc.RandomString(); err != nil {
+			log.Errorf("oidc connector generate state failed: %v", err)
+			return ""
+		}
+	}
+	content, _ := json.Marshal(state)
+	c.setLoginCookie(ctx, receiverURL, base64.RawURLEncoding.EncodeToString(content), loginCookieAge)
+
+	return provider.AuthCodeURL(&oidc.AuthRequest{
+		ClientID:     c.Config.ClientID,
+		RedirectURI:  receiverURL,
+		Scopes:       c.scopes(),
+		State:        state.State,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+	})
+}
+
+// ConnectorReceiver validate the callback of the provider and map the claims to the user info
+func (c *Connector) ConnectorReceiver(ctx *plugin.GinContext, receiverURL string) (
+	userInfo plugin.ExternalLoginUserInfo, err error) {
+	if errCode := ctx.Query("error"); len(errCode) > 0 {
+		return userInfo, fmt.Errorf("oidc provider returns error %s: %s", errCode, ctx.Query("error_description"))
+	}
+	state, err := c.getLoginState(ctx)
+	c.setLoginCookie(ctx, receiverURL, "", -1)
+	if err != nil {
+		return userInfo, err
+	}
+	if subtle.ConstantTimeCompare([]byte(state.State), []byte(ctx.Query("state"))) != 1 {
+		return userInfo, fmt.Errorf("oidc state does not match")
+	}
+
+	provider, err := c.getProvider(ctx)
+	if err != nil {
+		return userInfo, err
+	}
+	token, err := provider.Exchange(ctx, c.Config.ClientID, c.Config.ClientSecret, receiverURL,
+		ctx.Query("code"), state.CodeVerifier)
+	if err != nil {
+		return userInfo, err
+	}
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, c.Config.ClientID, state.Nonce)
+	if err != nil {
+		return userInfo, err
+	}
+	if err = c.mergeUserInfo(ctx, provider, token.AccessToken, claims); err != nil {
+		return userInfo, err
+	}
+	return c.mapUserInfo(claims), nil
+}
+
+// mergeUserInfo add the claims of the userinfo endpoint which are not in the id token, the providers usually
+// keep the id token small
+func (c *Connector) mergeUserInfo(ctx *plugin.GinContext, provider *oidc.Provider, accessToken string,
+	claims oidc.Claims) (err error) {
+	if len(provider.Metadata.UserinfoEndpoint) == 0 || len(accessToken) == 0 {
+		return nil
+	}
+	info, err := provider.UserInfo(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if info.String("sub") != claims.String("sub") {
+		return fmt.Errorf("oidc userinfo sub does not match the id token")
+	}
+	for name, value := range info {
+		if _, ok := claims[name]; !ok {
+			claims[name] = value
+		}
+	}
+	return nil
+}
+
+// mapUserInfo map the claims to the user info. The unverified email is dropped unless the admin trusts the provider,
+// so the user has to confirm the email before it is bound to an existing account.
+func (c *Connector) mapUserInfo(claims oidc.Claims) (userInfo plugin.ExternalLoginUserInfo) {
+	userInfo.ExternalID = claims.String(c.Config.ClaimExternalID)
+	userInfo.Username = claims.String(c.Config.ClaimUsername)
+	userInfo.DisplayName = claims.String(c.Config.ClaimDisplayName)
+	userInfo.Avatar = claims.String(c.Config.ClaimAvatar)
+	email := claims.String(c.Config.ClaimEmail)
+	if verified, ok := claims.Bool("email_verified"); (ok && verified) || c.Config.TrustUnverifiedEmail {
+		userInfo.Email = email
+	}
+	metaInfo, _ := json.Marshal(claims)
+	userInfo.MetaInfo = string(metaInfo)
+	return userInfo
+}
+
+func (c *Connector) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		c.inputField("name", c.Config.Name, false, plugin.InputTypeText),
+		c.inputField("issuer", c.Config.Issuer, true, plugin.InputTypeUrl),
+		c.inputField("client_id", c.Config.ClientID, true, plugin.InputTypeText),
+		c.inputField("client_secret", c.Config.ClientSecret, false, plugin.InputTypePassword),
+		c.inputField("scopes", c.Config.Scopes, false, plugin.InputTypeText),
+		c.inputField("claim_external_id", c.Config.ClaimExternalID, false, plugin.InputTypeText),
+		c.inputField("claim_username", c.Config.ClaimUsername, false, plugin.InputTypeText),
+		c.inputField("claim_display_name", c.Config.ClaimDisplayName, false, plugin.InputTypeText),
+		c.inputField("claim_email", c.Config.ClaimEmail, false, plugin.InputTypeText),
+		c.inputField("claim_avatar", c.Config.ClaimAvatar, false, plugin.InputTypeText),
+		{
+			Name:        "trust_unverified_email",
+			Type:        plugin.ConfigTypeSwitch,
+			Title:       plugin.MakeTranslator("plugin.connector_oidc.config.trust_unverified_email.title"),
+			Description: plugin.MakeTranslator("plugin.connector_oidc.config.trust_unverified_email.description"),
+			Value:       c.Config.TrustUnverifiedEmail,
+			UIOptions: plugin.ConfigFieldUIOptions{
+				Label: plugin.MakeTranslator("plugin.connector_oidc.config.trust_unverified_email.label"),
+			},
+		},
+	}
+}
+
+func (c *Connector) ConfigReceiver(config []byte) error {
+	conf := defaultConfig()
+	if err := json.Unmarshal(config, conf); err != nil {
+		return err
+	}
+	defaults := defaultConfig()
+	for _, field := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&conf.Scopes, defaults.Scopes},
+		{&conf.ClaimExternalID, defaults.ClaimExternalID},
+		{&conf.ClaimUsername, defaults.ClaimUsername},
+		{&conf.ClaimDisplayName, defaults.ClaimDisplayName},
+		{&conf.ClaimEmail, defaults.ClaimEmail},
+		{&conf.ClaimAvatar, defaults.ClaimAvatar},
+	} {
+		if *field.value = strings.TrimSpace(*field.value); len(*field.value) == 0 {
+			*field.value = field.fallback
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Config = conf
+	c.provider = nil
+	return nil
+}
+
+func (c *Connector) inputField(name string, value any, required bool, inputType plugin.InputType) plugin.ConfigField {
+	prefix := "plugin.connector_oidc.config." + name
+	return plugin.ConfigField{
+		Name:        name,
+		Type:        plugin.ConfigTypeInput,
+		Title:       plugin.MakeTranslator(prefix + ".title"),
+		Description: plugin.MakeTranslator(prefix + ".description"),
+		Required:    required,
+		Value:       value,
+		UIOptions:   plugin.ConfigFieldUIOptions{InputType: inputType},
+	}
+}
+
+// getProvider get the provider of the configured issuer, the discovery document is cached until the config changes
+func (c *Connector) getProvider(ctx *plugin.GinContext) (provider *oidc.Provider, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.provider != nil {
+		return c.provider, nil
+	}
+	if len(c.Config.Issuer) == 0 || len(c.Config.ClientID) == 0 {
+		return nil, fmt.Errorf("oidc connector is not configured")
+	}
+	c.provider, err = oidc.Discover(ctx, &http.Client{Timeout: httpTimeout}, c.Config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return c.provider, nil
+}
+
+// scopes the scopes of the authorization request, openid is always requested
+func (c *Connector) scopes() []string {
+	scopes := strings.Fields(c.Config.Scopes)
+	for _, scope := range scopes {
+		if scope == "openid" {
+			return scopes
+		}
+	}
+	return append([]string{"openid"}, scopes...)
+}
+
+func (c *Connector) setLoginCookie(ctx *plugin.GinContext, receiverURL, value string, maxAge int) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(loginCookieName, value, maxAge, "/", "", strings.HasPrefix(receiverURL, "https://"), true)
+}
+
+func (c *Connector) getLoginState(ctx *plugin.GinContext) (state *loginState, err error) {
+	value, err := ctx.Cookie(loginCookieName)
+	if err != nil {
+		return nil, fmt.Errorf("oidc login state is missing or expired")
+	}
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("oidc login state is invalid")
+	}
+	state = &loginState{}
+	if err = json.Unmarshal(content, state); err != nil || len(state.State) == 0 {
+		return nil, fmt.Errorf("oidc login state is invalid")
+	}
+	return state, nil
+}
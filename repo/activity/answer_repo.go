@@ -7,6 +7,7 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/repoCommon"
 
 	"github.com/redis/go-redis/v9"
@@ -36,6 +37,11 @@ func NewAnswerActivityRepo() *AnswerActivityRepo {
 
 func (ar *AnswerActivityRepo) SaveAcceptAnswerActivity(ctx context.Context, op *schema.AcceptAnswerOperationInfo) (
 	err error) {
+	policy, err := repoCommon.NewReputationPolicyRepo().GetReputationPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
 	// save activity
 	_, err = ar.DB.Transaction(func(session *xorm.Session) (result any, err error) {
 		session = session.Context(ctx)
@@ -45,6 +51,11 @@ func (ar *AnswerActivityRepo) SaveAcceptAnswerActivity(ctx context.Context, op *
 			return nil, err
 		}
 
+		err = ar.setActivityRankToZeroIfUserReachLimit(ctx, session, op, policy)
+		if err != nil {
+			return nil, err
+		}
+
 		err = ar.saveActivitiesAvailable(session, op)
 		if err != nil {
 			return nil, err
@@ -127,6 +138,33 @@ func (ar *AnswerActivityRepo) acquireUserInfo(session *xorm.Session, userIDs []s
 	return users, nil
 }
 
+// setActivityRankToZeroIfUserReachLimit the positive rank is ignored if the user reached the daily cap,
+// unless the activity type is excluded by the reputation policy
+func (ar *AnswerActivityRepo) setActivityRankToZeroIfUserReachLimit(ctx context.Context, session *xorm.Session,
+	op *schema.AcceptAnswerOperationInfo, policy *schema.ReputationPolicy) (err error) {
+	for _, act := range op.Activities {
+		if act.Rank <= 0 {
+			continue
+		}
+		cfg, err := utils.GetConfigByID(ctx, act.ActivityType)
+		if err != nil {
+			return err
+		}
+		if policy.IsDailyCapExcluded(cfg.Key) {
+			continue
+		}
+		reach, err := repoCommon.NewUserRankRepo().CheckReachLimit(ctx, session, act.ActivityUserID, policy.DailyCap)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		if reach {
+			act.Rank = 0
+		}
+	}
+	return nil
+}
+
 // saveActivitiesAvailable save activities
 // If activity not exist it will be created or else will be updated
 // If this activity is already exist, set activity rank to 0
@@ -150,12 +188,13 @@ func (ar *AnswerActivityRepo) saveActivitiesAvailable(session *xorm.Session, op
 		}
 		if exist {
 			bean := &entity.Activity{
-				Cancelled: entity.ActivityAvailable,
-				Rank:      act.Rank,
-				HasRank:   act.HasRank(),
+				Cancelled:   entity.ActivityAvailable,
+				Rank:        act.Rank,
+				HasRank:     act.HasRank(),
+				RuleVersion: act.RuleVersion,
 			}
 			session.Where("id = ?", existsActivity.ID)
-			if _, err = session.Cols("`cancelled`", "`rank`", "`has_rank`").Update(bean); err != nil {
+			if _, err = session.Cols("`cancelled`", "`rank`", "`has_rank`", "`rule_version`").Update(bean); err != nil {
 				return err
 			}
 		} else {
@@ -168,6 +207,7 @@ func (ar *AnswerActivityRepo) saveActivitiesAvailable(session *xorm.Session, op
 				Rank:             act.Rank,
 				HasRank:          act.HasRank(),
 				Cancelled:        entity.ActivityAvailable,
+				RuleVersion:      act.RuleVersion,
 			}
 			_, err = session.Insert(&insertActivity)
 			if err != nil {
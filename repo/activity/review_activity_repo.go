@@ -0,0 +1,85 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/repoCommon"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// ReviewActivityRepo the reputation of reviewing the suggested edits
+type ReviewActivityRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewReviewActivityRepo new repository
+func NewReviewActivityRepo() *ReviewActivityRepo {
+	return &ReviewActivityRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// AddReviewActivity add the reviewing activity of the user, the reviewer only gets the reputation once per revision,
+// the rank is ignored if the reviewer reached the daily cap
+func (ar *ReviewActivityRepo) AddReviewActivity(ctx context.Context, activity *entity.Activity,
+	policy *schema.ReputationPolicy, dailyCapExcluded bool) (err error) {
+	_, err = ar.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		user := &entity.User{}
+		exist, err := session.ID(activity.UserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("user not exist")
+		}
+		existsActivity := &entity.Activity{}
+		exist, err = session.
+			And(builder.Eq{"user_id": activity.UserID}).
+			And(builder.Eq{"activity_type": activity.ActivityType}).
+			And(builder.Eq{"revision_id": activity.RevisionID}).
+			Get(existsActivity)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, nil
+		}
+		if activity.Rank > 0 && !dailyCapExcluded {
+			reach, err := repoCommon.NewUserRankRepo().CheckReachLimit(ctx, session, activity.UserID, policy.DailyCap)
+			if err != nil {
+				return nil, err
+			}
+			if reach {
+				activity.Rank = 0
+			}
+		}
+		if activity.Rank != 0 {
+			activity.HasRank = 1
+		}
+		err = repoCommon.NewUserRankRepo().ChangeUserRank(ctx, session, activity.UserID, user.Rank, activity.Rank)
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(activity)
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
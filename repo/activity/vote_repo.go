@@ -16,6 +16,7 @@ import (
 	"github.com/lawyer/commons/utils/pager"
 	"github.com/lawyer/pkg/converter"
 	"github.com/lawyer/pkg/obj"
+	"github.com/lawyer/pkg/rank"
 	"xorm.io/builder"
 
 	"github.com/lawyer/commons/schema"
@@ -71,7 +72,7 @@ func (vr *VoteRepo) Vote(ctx context.Context, op *schema.VoteOperationInfo) (err
 	}
 
 	sendInboxNotification := false
-	maxDailyRank, err := repoCommon.NewUserRankRepo().GetMaxDailyRank(ctx)
+	policy, err := repoCommon.NewReputationPolicyRepo().GetReputationPolicy(ctx)
 	if err != nil {
 		return err
 	}
@@ -88,7 +89,7 @@ func (vr *VoteRepo) Vote(ctx context.Context, op *schema.VoteOperationInfo) (err
 			return nil, err
 		}
 
-		err = vr.setActivityRankToZeroIfUserReachLimit(ctx, session, op, userInfoMapping, maxDailyRank)
+		err = vr.setActivityRankToZeroIfUserReachLimit(ctx, session, op, userInfoMapping, policy)
 		if err != nil {
 			return nil, err
 		}
@@ -227,12 +228,12 @@ func (vr *VoteRepo) acquireUserInfo(session *xorm.Session, userIDs []string) (ma
 }
 
 func (vr *VoteRepo) setActivityRankToZeroIfUserReachLimit(ctx context.Context, session *xorm.Session,
-	op *schema.VoteOperationInfo, userInfoMapping map[string]*entity.User, maxDailyRank int) (err error) {
+	op *schema.VoteOperationInfo, userInfoMapping map[string]*entity.User, policy *schema.ReputationPolicy) (err error) {
 	// check if user reach daily rank limit
 	for _, activity := range op.Activities {
 		if activity.Rank > 0 {
 			// check if reach max daily rank
-			reach, err := repoCommon.NewUserRankRepo().CheckReachLimit(ctx, session, activity.ActivityUserID, maxDailyRank)
+			reach, err := repoCommon.NewUserRankRepo().CheckReachLimit(ctx, session, activity.ActivityUserID, policy.DailyCap)
 			if err != nil {
 				glog.Slog.Error(err)
 				return err
@@ -242,11 +243,9 @@ func (vr *VoteRepo) setActivityRankToZeroIfUserReachLimit(ctx context.Context, s
 				continue
 			}
 		} else {
-			// If user rank is lower than 1 after this action, then user rank will be set to 1 only.
+			// If user rank is lower than the min rank after this action, then user rank will be set to the min rank only.
 			userCurrentScore := userInfoMapping[activity.ActivityUserID].Rank
-			if userCurrentScore+activity.Rank < 1 {
-				activity.Rank = 1 - userCurrentScore
-			}
+			activity.Rank = rank.LimitMinRank(userCurrentScore, activity.Rank, policy.MinRank)
 		}
 	}
 	return nil
@@ -314,12 +313,13 @@ func (vr *VoteRepo) saveActivitiesAvailable(session *xorm.Session, op *schema.Vo
 		}
 		if exist {
 			bean := &entity.Activity{
-				Cancelled: entity.ActivityAvailable,
-				Rank:      activity.Rank,
-				HasRank:   activity.HasRank(),
+				Cancelled:   entity.ActivityAvailable,
+				Rank:        activity.Rank,
+				HasRank:     activity.HasRank(),
+				RuleVersion: activity.RuleVersion,
 			}
 			session.Where("id = ?", existsActivity.ID)
-			if _, err = session.Cols("`cancelled`", "`rank`", "`has_rank`").
+			if _, err = session.Cols("`cancelled`", "`rank`", "`has_rank`", "`rule_version`").
 				Update(bean); err != nil {
 				return false, err
 			}
@@ -333,6 +333,7 @@ func (vr *VoteRepo) saveActivitiesAvailable(session *xorm.Session, op *schema.Vo
 				Rank:             activity.Rank,
 				HasRank:          activity.HasRank(),
 				Cancelled:        entity.ActivityAvailable,
+				RuleVersion:      activity.RuleVersion,
 			}
 			_, err = session.Insert(&insertActivity)
 			if err != nil {
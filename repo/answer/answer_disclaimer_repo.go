@@ -0,0 +1,45 @@
+package answer
+
+import (
+	"context"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// AnswerDisclaimerRepo answer disclaimer repository
+type AnswerDisclaimerRepo struct {
+	DB *xorm.Engine
+}
+
+// NewAnswerDisclaimerRepo new repository
+func NewAnswerDisclaimerRepo() *AnswerDisclaimerRepo {
+	return &AnswerDisclaimerRepo{
+		DB: handler.Engine,
+	}
+}
+
+// AddAck add the disclaimer acknowledgement of the answer
+func (ar *AnswerDisclaimerRepo) AddAck(ctx context.Context, ack *entity.AnswerDisclaimerAck) (err error) {
+	ack.AnswerID = uid.DeShortID(ack.AnswerID)
+	_, err = ar.DB.Context(ctx).Insert(ack)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAckByAnswerID get the disclaimer acknowledgement of the answer
+func (ar *AnswerDisclaimerRepo) GetAckByAnswerID(ctx context.Context, answerID string) (
+	ack *entity.AnswerDisclaimerAck, exist bool, err error) {
+	ack = &entity.AnswerDisclaimerAck{}
+	exist, err = ar.DB.Context(ctx).Where("answer_id = ?", uid.DeShortID(answerID)).Get(ack)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
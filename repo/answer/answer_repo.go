@@ -430,3 +430,21 @@ func (ar *AnswerRepo) updateSearch(ctx context.Context, answerID string) (err er
 	err = s.UpdateContent(ctx, content)
 	return
 }
+
+// GetPendingAnswerPage get the answers waiting for the approval, the oldest first
+func (ar *AnswerRepo) GetPendingAnswerPage(ctx context.Context, page, pageSize int) (
+	answerList []*entity.Answer, total int64, err error) {
+	answerList = make([]*entity.Answer, 0)
+	session := ar.DB.Context(ctx).Where("status = ?", entity.AnswerStatusPending).OrderBy("created_at ASC")
+	total, err = pager.Help(page, pageSize, &answerList, &entity.Answer{}, session)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if utils.GetEnableShortID(ctx) {
+		for _, item := range answerList {
+			item.ID = uid.EnShortID(item.ID)
+			item.QuestionID = uid.EnShortID(item.QuestionID)
+		}
+	}
+	return answerList, total, nil
+}
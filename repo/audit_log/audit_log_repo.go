@@ -0,0 +1,85 @@
+package audit_log
+
+import (
+	"context"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils/pager"
+	"github.com/redis/go-redis/v9"
+	"time"
+
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// AuditLogRepo audit log repository
+type AuditLogRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewAuditLogRepo new repository
+func NewAuditLogRepo() *AuditLogRepo {
+	return &AuditLogRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// AddAuditLog add audit log
+func (ar *AuditLogRepo) AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error) {
+	_, err = ar.DB.Context(ctx).Insert(auditLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogPage get audit log page
+func (ar *AuditLogRepo) GetAuditLogPage(ctx context.Context, cond *schema.GetAuditLogPageReq) (
+	auditLogList []*entity.AuditLog, total int64, err error) {
+	auditLogList = make([]*entity.AuditLog, 0)
+	session := ar.buildAuditLogSession(ctx, cond)
+	total, err = pager.Help(cond.Page, cond.PageSize, &auditLogList, &entity.AuditLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogList get audit log list with the limit
+func (ar *AuditLogRepo) GetAuditLogList(ctx context.Context, cond *schema.GetAuditLogPageReq, limit int) (
+	auditLogList []*entity.AuditLog, err error) {
+	auditLogList = make([]*entity.AuditLog, 0)
+	err = ar.buildAuditLogSession(ctx, cond).Limit(limit).Find(&auditLogList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (ar *AuditLogRepo) buildAuditLogSession(ctx context.Context, cond *schema.GetAuditLogPageReq) *xorm.Session {
+	session := ar.DB.Context(ctx)
+	if len(cond.UserID) > 0 {
+		session.Where(builder.Eq{"user_id": cond.UserID})
+	}
+	if len(cond.Action) > 0 {
+		session.Where(builder.Eq{"action": cond.Action})
+	}
+	if len(cond.ObjectType) > 0 {
+		session.Where(builder.Eq{"object_type": cond.ObjectType})
+	}
+	if len(cond.ObjectID) > 0 {
+		session.Where(builder.Eq{"object_id": cond.ObjectID})
+	}
+	if cond.StartTime > 0 {
+		session.Where(builder.Gte{"created_at": time.Unix(cond.StartTime, 0)})
+	}
+	if cond.EndTime > 0 {
+		session.Where(builder.Lt{"created_at": time.Unix(cond.EndTime, 0)})
+	}
+	return session.Desc("id")
+}
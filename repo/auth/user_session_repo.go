@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SetUserSession save the new login session and add it to the session list of the user
+func (ar *AuthRepo) SetUserSession(ctx context.Context, session *entity.UserSession) (err error) {
+	content, _ := json.Marshal(session)
+	listKey := constant.UserSessionListCacheKeyPrefix + session.UserID
+	_, err = ar.Cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, constant.UserSessionCacheKeyPrefix+session.ID, string(content), constant.UserSessionCacheTime)
+		pipe.SAdd(ctx, listKey, session.ID)
+		pipe.Expire(ctx, listKey, constant.UserSessionCacheTime)
+		return nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserSession get the login session
+func (ar *AuthRepo) GetUserSession(ctx context.Context, sessionID string) (
+	session *entity.UserSession, exist bool, err error) {
+	content, err := ar.Cache.Get(ctx, constant.UserSessionCacheKeyPrefix+sessionID).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	session = &entity.UserSession{}
+	if err = json.Unmarshal([]byte(content), session); err != nil {
+		return nil, false, nil
+	}
+	return session, true, nil
+}
+
+// GetUserSessionList get the login sessions of the user, the expired ones are removed from the list
+func (ar *AuthRepo) GetUserSessionList(ctx context.Context, userID string) (
+	sessionList []*entity.UserSession, err error) {
+	sessionList = make([]*entity.UserSession, 0)
+	listKey := constant.UserSessionListCacheKeyPrefix + userID
+	sessionIDs, err := ar.Cache.SMembers(ctx, listKey).Result()
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(sessionIDs) == 0 {
+		return sessionList, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, constant.UserSessionCacheKeyPrefix+sessionID)
+	}
+	contents, err := ar.Cache.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	expired := make([]interface{}, 0)
+	for i, content := range contents {
+		str, ok := content.(string)
+		if !ok {
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+		session := &entity.UserSession{}
+		if err = json.Unmarshal([]byte(str), session); err != nil {
+			continue
+		}
+		sessionList = append(sessionList, session)
+	}
+	if len(expired) > 0 {
+		if err = ar.Cache.SRem(ctx, listKey, expired...).Err(); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return sessionList, nil
+}
+
+// TouchUserSession update the last seen time and ip of the session, nothing happens if the session is revoked
+func (ar *AuthRepo) TouchUserSession(ctx context.Context, sessionID, ip string, lastSeenAt int64) (err error) {
+	_, _, err = ar.updateUserSession(ctx, sessionID, redis.KeepTTL, func(session *entity.UserSession) bool {
+		session.IP = ip
+		session.LastSeenAt = lastSeenAt
+		return true
+	})
+	return err
+}
+
+// RotateUserSessionRefreshToken replace the refresh token of the session when the old one is still the current one,
+// the expiration of the session is renewed.
+func (ar *AuthRepo) RotateUserSessionRefreshToken(ctx context.Context, sessionID, oldHash, newHash string) (
+	session *entity.UserSession, ok bool, err error) {
+	session, ok, err = ar.updateUserSession(ctx, sessionID, constant.UserSessionCacheTime,
+		func(session *entity.UserSession) bool {
+			if session.RefreshTokenHash != oldHash {
+				return false
+			}
+			session.PrevRefreshTokenHash = oldHash
+			session.RefreshTokenHash = newHash
+			return true
+		})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	err = ar.Cache.Expire(ctx, constant.UserSessionListCacheKeyPrefix+session.UserID,
+		constant.UserSessionCacheTime).Err()
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return session, true, nil
+}
+
+// RemoveUserSession remove the login session of the user
+func (ar *AuthRepo) RemoveUserSession(ctx context.Context, userID, sessionID string) (err error) {
+	_, err = ar.Cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, constant.UserSessionCacheKeyPrefix+sessionID)
+		pipe.SRem(ctx, constant.UserSessionListCacheKeyPrefix+userID, sessionID)
+		return nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserSessions remove all the login sessions of the user except the given one, keep it empty to remove all
+func (ar *AuthRepo) RemoveUserSessions(ctx context.Context, userID, exceptSessionID string) (err error) {
+	listKey := constant.UserSessionListCacheKeyPrefix + userID
+	sessionIDs, err := ar.Cache.SMembers(ctx, listKey).Result()
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	keys := make([]string, 0, len(sessionIDs))
+	members := make([]interface{}, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		if sessionID == exceptSessionID {
+			continue
+		}
+		keys = append(keys, constant.UserSessionCacheKeyPrefix+sessionID)
+		members = append(members, sessionID)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err = ar.Cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.SRem(ctx, listKey, members...)
+		return nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// updateUserSession update the session in an optimistic transaction, so the concurrent updates such as
+// the refresh and the revocation are not overwritten. The update is skipped if the session is removed
+// or the modifier returns false.
+func (ar *AuthRepo) updateUserSession(ctx context.Context, sessionID string, expiration time.Duration,
+	modify func(session *entity.UserSession) bool) (session *entity.UserSession, ok bool, err error) {
+	key := constant.UserSessionCacheKeyPrefix + sessionID
+	err = ar.Cache.Watch(ctx, func(tx *redis.Tx) error {
+		content, err := tx.Get(ctx, key).Result()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		session = &entity.UserSession{}
+		if err = json.Unmarshal([]byte(content), session); err != nil || !modify(session) {
+			return nil
+		}
+		newContent, _ := json.Marshal(session)
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, string(newContent), expiration)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		ok = true
+		return nil
+	}, key)
+	if err == redis.TxFailedErr {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return session, true, nil
+}
+
+// SetLoginExchange save the tokens of the login redirected to the frontend, they are got once by the code
+func (ar *AuthRepo) SetLoginExchange(ctx context.Context, code, content string) (err error) {
+	err = ar.Cache.Set(ctx, constant.UserLoginExchangeCacheKeyPrefix+code, content,
+		constant.UserLoginExchangeCacheTime).Err()
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UseLoginExchange get and remove the tokens of the code, empty if the code is used or expired
+func (ar *AuthRepo) UseLoginExchange(ctx context.Context, code string) (content string, err error) {
+	content, err = ar.Cache.GetDel(ctx, constant.UserLoginExchangeCacheKeyPrefix+code).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return content, nil
+}
@@ -0,0 +1,216 @@
+package badge
+
+import (
+	"context"
+	"time"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// BadgeRepo badge repository
+type BadgeRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewBadgeRepo new repository
+func NewBadgeRepo() *BadgeRepo {
+	return &BadgeRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// AddBadge add badge
+func (br *BadgeRepo) AddBadge(ctx context.Context, badge *entity.Badge) (err error) {
+	_, err = br.DB.Context(ctx).Insert(badge)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateBadge update badge
+func (br *BadgeRepo) UpdateBadge(ctx context.Context, badge *entity.Badge) (err error) {
+	_, err = br.DB.Context(ctx).ID(badge.ID).
+		Cols("name", "description", "icon", "level", "rule_type", "threshold", "tag_id", "per_tag", "status").
+		Update(badge)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBadge get badge by id
+func (br *BadgeRepo) GetBadge(ctx context.Context, badgeID int) (badge *entity.Badge, exist bool, err error) {
+	badge = &entity.Badge{}
+	exist, err = br.DB.Context(ctx).ID(badgeID).Get(badge)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBadgeList get badge list, status 0 means all
+func (br *BadgeRepo) GetBadgeList(ctx context.Context, status int) (badgeList []*entity.Badge, err error) {
+	badgeList = make([]*entity.Badge, 0)
+	session := br.DB.Context(ctx).Asc("level", "id")
+	if status > 0 {
+		session.Where(builder.Eq{"status": status})
+	}
+	err = session.Find(&badgeList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEnabledBadgeListByRuleTypes get the enabled badges with the rule types
+func (br *BadgeRepo) GetEnabledBadgeListByRuleTypes(ctx context.Context, ruleTypes []string) (
+	badgeList []*entity.Badge, err error) {
+	badgeList = make([]*entity.Badge, 0)
+	err = br.DB.Context(ctx).Where(builder.Eq{"status": entity.BadgeStatusEnabled}).
+		And(builder.In("rule_type", ruleTypes)).Find(&badgeList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddBadgeAward award the badge to the user and increase the badge count of the user,
+// added is false if the user already has the badge
+func (br *BadgeRepo) AddBadgeAward(ctx context.Context, award *entity.BadgeAward) (added bool, err error) {
+	_, err = br.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		exist, err := session.Exist(&entity.BadgeAward{UserID: award.UserID, BadgeID: award.BadgeID, TagID: award.TagID})
+		if err != nil || exist {
+			return nil, err
+		}
+		if _, err = session.Insert(award); err != nil {
+			return nil, err
+		}
+		if _, err = session.ID(award.UserID).Incr("badge_count", 1).Update(&entity.User{}); err != nil {
+			return nil, err
+		}
+		added = true
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return added, nil
+}
+
+// ExistBadgeAward whether the user has the badge
+func (br *BadgeRepo) ExistBadgeAward(ctx context.Context, userID string, badgeID int, tagID string) (
+	exist bool, err error) {
+	exist, err = br.DB.Context(ctx).Exist(&entity.BadgeAward{UserID: userID, BadgeID: badgeID, TagID: tagID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserBadgeAwardList get the badges of the user, the newest first
+func (br *BadgeRepo) GetUserBadgeAwardList(ctx context.Context, userID string) (
+	awardList []*entity.BadgeAward, err error) {
+	awardList = make([]*entity.BadgeAward, 0)
+	err = br.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Desc("created_at").Find(&awardList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountBadgeAwardGroupByBadge count how many times each badge is awarded
+func (br *BadgeRepo) CountBadgeAwardGroupByBadge(ctx context.Context) (counts map[int]int64, err error) {
+	type badgeAwardCount struct {
+		BadgeID int   `xorm:"badge_id"`
+		Count   int64 `xorm:"award_count"`
+	}
+	list := make([]*badgeAwardCount, 0)
+	err = br.DB.Context(ctx).Table(entity.BadgeAward{}.TableName()).
+		Select("badge_id, count(*) AS award_count").GroupBy("badge_id").Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	counts = make(map[int]int64, len(list))
+	for _, item := range list {
+		counts[item.BadgeID] = item.Count
+	}
+	return counts, nil
+}
+
+// CountAcceptedAnswers count the accepted answers of the user, in the tag if tag id is not empty
+func (br *BadgeRepo) CountAcceptedAnswers(ctx context.Context, userID, tagID string) (count int64, err error) {
+	cond := builder.Eq{"user_id": userID, "status": entity.AnswerStatusAvailable, "adopted": schema.AnswerAcceptedEnable}
+	count, err = br.DB.Context(ctx).Where(cond).And(br.answerInTagCond(tagID)).Count(&entity.Answer{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountHelpfulAnswers count the answers of the user which are accepted or upvoted, in the tag if tag id is not empty
+func (br *BadgeRepo) CountHelpfulAnswers(ctx context.Context, userID, tagID string) (count int64, err error) {
+	cond := builder.Eq{"user_id": userID, "status": entity.AnswerStatusAvailable}.
+		And(builder.Eq{"adopted": schema.AnswerAcceptedEnable}.Or(builder.Gt{"vote_count": 0}))
+	count, err = br.DB.Context(ctx).Where(cond).And(br.answerInTagCond(tagID)).Count(&entity.Answer{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUpvotes count the upvotes the questions and answers of the user received, in the tag if tag id is not empty
+func (br *BadgeRepo) CountUpvotes(ctx context.Context, userID, tagID string, activityTypes []int) (
+	count int64, err error) {
+	session := br.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "cancelled": entity.ActivityAvailable}).
+		And(builder.In("activity_type", activityTypes))
+	if len(tagID) > 0 && tagID != "0" {
+		questionIDs := br.questionInTagQuery(tagID)
+		session.And(builder.In("object_id", questionIDs).
+			Or(builder.In("object_id", builder.Select("id").From(entity.Answer{}.TableName()).
+				Where(builder.In("question_id", questionIDs)))))
+	}
+	count, err = session.Count(&entity.Activity{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswerCreatedAtList get the time of the answers the user posted after the time
+func (br *BadgeRepo) GetAnswerCreatedAtList(ctx context.Context, userID string, after time.Time) (
+	createdAtList []time.Time, err error) {
+	answerList := make([]*entity.Answer, 0)
+	err = br.DB.Context(ctx).Cols("created_at").
+		Where(builder.Eq{"user_id": userID, "status": entity.AnswerStatusAvailable}).
+		And(builder.Gte{"created_at": after}).Find(&answerList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, answer := range answerList {
+		createdAtList = append(createdAtList, answer.CreatedAt)
+	}
+	return createdAtList, nil
+}
+
+func (br *BadgeRepo) answerInTagCond(tagID string) builder.Cond {
+	if len(tagID) == 0 || tagID == "0" {
+		return builder.NewCond()
+	}
+	return builder.In("question_id", br.questionInTagQuery(tagID))
+}
+
+func (br *BadgeRepo) questionInTagQuery(tagID string) *builder.Builder {
+	return builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.Eq{"tag_id": tagID, "status": entity.TagRelStatusAvailable})
+}
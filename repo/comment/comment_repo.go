@@ -72,6 +72,15 @@ func (cr *CommentRepo) UpdateCommentContent(
 	return
 }
 
+// UpdateCommentStatus update comment status
+func (cr *CommentRepo) UpdateCommentStatus(ctx context.Context, commentID string, status int) (err error) {
+	_, err = cr.DB.Context(ctx).ID(commentID).Cols("status").Update(&entity.Comment{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetComment get comment one
 func (cr *CommentRepo) GetComment(ctx context.Context, commentID string) (
 	comment *entity.Comment, exist bool, err error) {
@@ -110,6 +119,18 @@ func (cr *CommentRepo) GetCommentPage(ctx context.Context, commentQuery *utils.C
 	return
 }
 
+// GetPendingCommentPage get the comments held for review, the oldest first
+func (cr *CommentRepo) GetPendingCommentPage(ctx context.Context, page, pageSize int) (
+	commentList []*entity.Comment, total int64, err error) {
+	commentList = make([]*entity.Comment, 0)
+	session := cr.DB.Context(ctx).Where("status = ?", entity.CommentStatusPending).OrderBy("created_at ASC")
+	total, err = pager.Help(page, pageSize, &commentList, &entity.Comment{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // RemoveAllUserComment remove all user comment
 func (cr *CommentRepo) RemoveAllUserComment(ctx context.Context, userID string) (err error) {
 	session := cr.DB.Context(ctx).Where("user_id = ?", userID)
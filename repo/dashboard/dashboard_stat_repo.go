@@ -0,0 +1,175 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// DashboardStatRepo dashboard statistics repository
+type DashboardStatRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewDashboardStatRepo new repository
+func NewDashboardStatRepo() *DashboardStatRepo {
+	return &DashboardStatRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// GetQuestionListByCreatedAt get the questions asked in [start, end)
+func (dr *DashboardStatRepo) GetQuestionListByCreatedAt(ctx context.Context, start, end time.Time) (
+	questionList []*entity.Question, err error) {
+	questionList = make([]*entity.Question, 0)
+	err = dr.DB.Context(ctx).Cols("id", "user_id", "created_at", "answer_count", "accepted_answer_id").
+		Where(builder.Gte{"created_at": start}.And(builder.Lt{"created_at": end})).
+		In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		Find(&questionList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswerListByCreatedAt get the answers created in [start, end)
+func (dr *DashboardStatRepo) GetAnswerListByCreatedAt(ctx context.Context, start, end time.Time) (
+	answerList []*entity.Answer, err error) {
+	answerList = make([]*entity.Answer, 0)
+	err = dr.DB.Context(ctx).Cols("id", "question_id", "user_id", "created_at", "adopted").
+		Where(builder.Gte{"created_at": start}.And(builder.Lt{"created_at": end})).
+		Where(builder.Eq{"status": entity.AnswerStatusAvailable}).
+		Find(&answerList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswerListByQuestionIDs get the answers of the questions
+func (dr *DashboardStatRepo) GetAnswerListByQuestionIDs(ctx context.Context, questionIDs []string) (
+	answerList []*entity.Answer, err error) {
+	answerList = make([]*entity.Answer, 0)
+	if len(questionIDs) == 0 {
+		return
+	}
+	err = dr.DB.Context(ctx).Cols("id", "question_id", "created_at").
+		In("question_id", questionIDs).
+		Where(builder.Eq{"status": entity.AnswerStatusAvailable}).
+		Find(&answerList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserByCreatedAt count the users registered in [start, end)
+func (dr *DashboardStatRepo) CountUserByCreatedAt(ctx context.Context, start, end time.Time) (count int64, err error) {
+	count, err = dr.DB.Context(ctx).
+		Where(builder.Gte{"created_at": start}.And(builder.Lt{"created_at": end})).
+		Count(&entity.User{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveDailyStat save the statistics of the day, the old statistics of the day will be replaced
+func (dr *DashboardStatRepo) SaveDailyStat(ctx context.Context, stat *entity.DashboardDailyStat,
+	tagStatList []*entity.DashboardDailyTagStat, userStatList []*entity.DashboardDailyUserStat) (err error) {
+	_, err = dr.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		old := &entity.DashboardDailyStat{}
+		exist, err := session.Where(builder.Eq{"date": stat.Date}).Get(old)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			_, err = session.ID(old.ID).AllCols().Omit("id", "created_at").Update(stat)
+		} else {
+			_, err = session.Insert(stat)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = session.Where(builder.Eq{"date": stat.Date}).Delete(&entity.DashboardDailyTagStat{})
+		if err != nil {
+			return nil, err
+		}
+		if len(tagStatList) > 0 {
+			if _, err = session.Insert(tagStatList); err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.Where(builder.Eq{"date": stat.Date}).Delete(&entity.DashboardDailyUserStat{})
+		if err != nil {
+			return nil, err
+		}
+		if len(userStatList) > 0 {
+			if _, err = session.Insert(userStatList); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDailyStatList get the daily statistics between the dates, both dates are included
+func (dr *DashboardStatRepo) GetDailyStatList(ctx context.Context, startDate, endDate string) (
+	statList []*entity.DashboardDailyStat, err error) {
+	statList = make([]*entity.DashboardDailyStat, 0)
+	err = dr.DB.Context(ctx).Where(builder.Between{Col: "date", LessVal: startDate, MoreVal: endDate}).
+		Asc("date").Find(&statList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagStatSummary get the most active tags between the dates
+func (dr *DashboardStatRepo) GetTagStatSummary(ctx context.Context, startDate, endDate string, limit int) (
+	statList []*entity.DashboardDailyTagStat, err error) {
+	statList = make([]*entity.DashboardDailyTagStat, 0)
+	err = dr.DB.Context(ctx).Table(entity.DashboardDailyTagStat{}.TableName()).
+		Select("tag_id, SUM(question_count) AS question_count, SUM(answer_count) AS answer_count").
+		Where(builder.Between{Col: "date", LessVal: startDate, MoreVal: endDate}).
+		GroupBy("tag_id").
+		OrderBy("SUM(question_count) + SUM(answer_count) DESC").
+		Limit(limit).Find(&statList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserStatSummary get the top contributors between the dates
+func (dr *DashboardStatRepo) GetUserStatSummary(ctx context.Context, startDate, endDate string, limit int) (
+	statList []*entity.DashboardDailyUserStat, err error) {
+	statList = make([]*entity.DashboardDailyUserStat, 0)
+	err = dr.DB.Context(ctx).Table(entity.DashboardDailyUserStat{}.TableName()).
+		Select("user_id, SUM(question_count) AS question_count, SUM(answer_count) AS answer_count, " +
+			"SUM(accepted_count) AS accepted_count").
+		Where(builder.Between{Col: "date", LessVal: startDate, MoreVal: endDate}).
+		GroupBy("user_id").
+		OrderBy("SUM(answer_count) DESC, SUM(accepted_count) DESC").
+		Limit(limit).Find(&statList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -39,3 +39,29 @@ func (e *EmailRepo) VerifyCode(ctx context.Context, code string) (content string
 	content = e.Cache.Get(ctx, code).Val()
 	return content, nil
 }
+
+// UseCode get the content and remove the code at once, so the code can only be used once
+func (e *EmailRepo) UseCode(ctx context.Context, code string) (content string, err error) {
+	content, err = e.Cache.GetDel(ctx, code).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return content, nil
+}
+
+// IncrCodeAttempts count the failed verifications of the code, the counter expires with the code
+func (e *EmailRepo) IncrCodeAttempts(ctx context.Context, code string, duration time.Duration) (
+	attempts int64, err error) {
+	key := code + ":attempts"
+	attempts, err = e.Cache.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if attempts == 1 {
+		e.Cache.Expire(ctx, key, duration)
+	}
+	return attempts, nil
+}
@@ -4,10 +4,13 @@ import (
 	"github.com/lawyer/repo/activity"
 	"github.com/lawyer/repo/activity_common"
 	"github.com/lawyer/repo/answer"
+	"github.com/lawyer/repo/audit_log"
 	"github.com/lawyer/repo/auth"
+	"github.com/lawyer/repo/badge"
 	"github.com/lawyer/repo/captcha"
 	"github.com/lawyer/repo/collection"
 	"github.com/lawyer/repo/comment"
+	"github.com/lawyer/repo/dashboard"
 	"github.com/lawyer/repo/export"
 	"github.com/lawyer/repo/meta"
 	"github.com/lawyer/repo/notification"
@@ -15,9 +18,12 @@ import (
 	"github.com/lawyer/repo/question"
 	"github.com/lawyer/repo/reason"
 	"github.com/lawyer/repo/report"
+	"github.com/lawyer/repo/response_template"
 	"github.com/lawyer/repo/revision"
 	"github.com/lawyer/repo/role"
 	"github.com/lawyer/repo/search_common"
+	"github.com/lawyer/repo/site_info"
+	"github.com/lawyer/repo/spam"
 	"github.com/lawyer/repo/tag"
 	"github.com/lawyer/repo/user"
 	"github.com/lawyer/repo/user_external_login"
@@ -34,16 +40,28 @@ var (
 	UserRoleRelRepo            *role.UserRoleRelRepo
 	RoleRepo                   *role.RoleRepo
 	UserExternalLoginRepo      *user_external_login.UserExternalLoginRepo
+	UserTwoFactorRepo          *user.UserTwoFactorRepo
+	UserDataRepo               *user.UserDataRepo
 	UserNotificationConfigRepo *user_notification_config.UserNotificationConfigRepo
 	CaptchaRepo                *captcha.CaptchaRepo
 	CommentRepo                *comment.CommentRepo
 	AnswerRepo                 *answer.AnswerRepo
+	AnswerDisclaimerRepo       *answer.AnswerDisclaimerRepo
 	CommentCommonRepo          *comment.CommentRepo
 	QuestionRepo               *question.QuestionRepo
+	QuestionViewRepo           *question.QuestionViewRepo
 	TagRepo                    *tag.TagRepo
 	TagRelRepo                 *tag.TagRelRepo
 	RevisionRepo               *revision.RevisionRepo
 	RolePowerRelRepo           *role.RolePowerRelRepo
+	PowerRepo                  *role.PowerRepo
+	UserRoleScopeRelRepo       *role.UserRoleScopeRelRepo
+	AuditLogRepo               *audit_log.AuditLogRepo
+	BadgeRepo                  *badge.BadgeRepo
+	ResponseTemplateRepo       *response_template.ResponseTemplateRepo
+	SpamScoreRepo              *spam.SpamScoreRepo
+	SiteInfoHistoryRepo        *site_info.SiteInfoHistoryRepo
+	DashboardStatRepo          *dashboard.DashboardStatRepo
 	//LimitRepo                  *limit.LimitRepo
 	ReportRepo           *report.ReportRepo
 	FollowRepo           *activity_common.FollowRepo
@@ -53,6 +71,7 @@ var (
 	MetaRepo             *meta.MetaRepo
 	AnswerActivityRepo   *activity.AnswerActivityRepo
 	VoteRepo             *activity.VoteRepo
+	ReviewActivityRepo   *activity.ReviewActivityRepo
 	SearchRepo           *search_common.SearchRepo
 	UserAdminRepo        *user.UserAdminRepo
 	ReasonRepo           *reason.ReasonRepo
@@ -65,6 +84,8 @@ func InitRepo() {
 
 	AuthRepo = auth.NewAuthRepo()
 	UserRepo = user.NewUserRepo()
+	UserTwoFactorRepo = user.NewUserTwoFactorRepo()
+	UserDataRepo = user.NewUserDataRepo()
 	//ActivityRepo = repoCommon.NewActivityRepo()
 	//UserRankRepo = repoCommon.NewUserRankRepo()
 	UserActiveActivityRepo = activity.NewUserActiveActivityRepo()
@@ -79,11 +100,21 @@ func InitRepo() {
 	CommentRepo = comment.NewCommentRepo()
 	CommentCommonRepo = comment.NewCommentCommonRepo()
 	AnswerRepo = answer.NewAnswerRepo()
+	AnswerDisclaimerRepo = answer.NewAnswerDisclaimerRepo()
 	QuestionRepo = question.NewQuestionRepo()
+	QuestionViewRepo = question.NewQuestionViewRepo()
 	TagRepo = tag.NewTagRepo()
 	TagRelRepo = tag.NewTagRelRepo()
 	RevisionRepo = revision.NewRevisionRepo()
 	RolePowerRelRepo = role.NewRolePowerRelRepo()
+	PowerRepo = role.NewPowerRepo()
+	UserRoleScopeRelRepo = role.NewUserRoleScopeRelRepo()
+	AuditLogRepo = audit_log.NewAuditLogRepo()
+	BadgeRepo = badge.NewBadgeRepo()
+	ResponseTemplateRepo = response_template.NewResponseTemplateRepo()
+	SpamScoreRepo = spam.NewSpamScoreRepo()
+	SiteInfoHistoryRepo = site_info.NewSiteInfoHistoryRepo()
+	DashboardStatRepo = dashboard.NewDashboardStatRepo()
 	//LimitRepo = limit.NewRateLimitRepo()
 	ReportRepo = report.NewReportRepo()
 	FollowRepo = activity_common.NewFollowRepo()
@@ -93,6 +124,7 @@ func InitRepo() {
 	MetaRepo = meta.NewMetaRepo()
 	AnswerActivityRepo = activity.NewAnswerActivityRepo()
 	VoteRepo = activity.NewVoteRepo()
+	ReviewActivityRepo = activity.NewReviewActivityRepo()
 	SearchRepo = search_common.NewSearchRepo()
 	UserAdminRepo = user.NewUserAdminRepo()
 	ReasonRepo = reason.NewReasonRepo()
@@ -2,6 +2,7 @@ package meta
 
 import (
 	"context"
+	"github.com/lawyer/commons/constant"
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/handler"
@@ -35,6 +36,29 @@ func (mr *MetaRepo) AddMeta(ctx context.Context, meta *entity.Meta) (err error)
 	return
 }
 
+// AddMetaIfAbsent add meta if there is no meta with the same object id and key, added is false if it exists.
+// The concurrent adding of the same meta is serialized by the lock, the one which can not get the lock is not added.
+func (mr *MetaRepo) AddMetaIfAbsent(ctx context.Context, meta *entity.Meta) (added bool, err error) {
+	lockKey := constant.MetaLockCacheKeyPrefix + meta.ObjectID + ":" + meta.Key
+	locked, err := mr.Cache.SetNX(ctx, lockKey, 1, constant.MetaLockCacheTime).Result()
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !locked {
+		return false, nil
+	}
+	defer mr.Cache.Del(ctx, lockKey)
+
+	_, exist, err := mr.GetMetaByObjectIdAndKey(ctx, meta.ObjectID, meta.Key)
+	if err != nil || exist {
+		return false, err
+	}
+	if err = mr.AddMeta(ctx, meta); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // RemoveMeta delete meta
 func (mr *MetaRepo) RemoveMeta(ctx context.Context, id int) (err error) {
 	_, err = mr.DB.Context(ctx).ID(id).Delete(&entity.Meta{})
@@ -77,14 +77,16 @@ func (qr *QuestionRepo) UpdateQuestion(ctx context.Context, question *entity.Que
 	return
 }
 
-func (qr *QuestionRepo) UpdatePvCount(ctx context.Context, questionID string) (err error) {
+// IncrViewCount add the views and unique views flushed from the cache to the question
+func (qr *QuestionRepo) IncrViewCount(ctx context.Context, questionID string, views, uniqueViews int64) (err error) {
 	questionID = uid.DeShortID(questionID)
 	question := &entity.Question{}
-	_, err = qr.DB.Context(ctx).Where("id =?", questionID).Incr("view_count", 1).Update(question)
+	_, err = qr.DB.Context(ctx).Where("id =?", questionID).
+		Incr("view_count", views).Incr("unique_view_count", uniqueViews).Update(question)
 	if err != nil {
 		return err
 	}
-	_ = qr.updateSearch(ctx, question.ID)
+	_ = qr.updateSearch(ctx, questionID)
 	return nil
 }
 
@@ -201,6 +203,7 @@ func (qr *QuestionRepo) GetQuestionsByTitle(ctx context.Context, title string, p
 	questionList = make([]*entity.Question, 0)
 	session := qr.DB.Context(ctx)
 	session.Where("status != ?", entity.QuestionStatusDeleted)
+	session.Where("status != ?", entity.QuestionStatusPending)
 	session.Where("title like ?", "%"+title+"%")
 	session.Limit(pageSize)
 	err = session.Find(&questionList)
@@ -270,11 +273,14 @@ func (qr *QuestionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 
 	// try to get sitemap data from cache
 	cacheKey := fmt.Sprintf(constant.SiteMapQuestionCacheKeyPrefix, page)
-	cacheData := qr.Cache.Get(ctx, cacheKey).String()
-	if cacheData != "" {
-		_ = json.Unmarshal([]byte(cacheData), &questionIDList)
+	cacheData, err := qr.Cache.Get(ctx, cacheKey).Result()
+	if err != nil && err != redis.Nil {
+		glog.Slog.Error(err)
+	}
+	if len(cacheData) > 0 && json.Unmarshal([]byte(cacheData), &questionIDList) == nil {
 		return questionIDList, nil
 	}
+	questionIDList = make([]*schema.SiteMapQuestionInfo, 0)
 
 	// get sitemap data from db
 	rows := make([]*entity.Question, 0)
@@ -312,6 +318,39 @@ func (qr *QuestionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 	return questionIDList, nil
 }
 
+// GetOpenQuestionList get the available questions without accepted answer created before the time,
+// ordered by id and only the questions after the last id
+func (qr *QuestionRepo) GetOpenQuestionList(ctx context.Context, before time.Time, lastID string, limit int) (
+	questionList []*entity.Question, err error) {
+	questionList = make([]*entity.Question, 0)
+	session := qr.DB.Context(ctx).
+		Cols("id", "user_id", "title", "created_at", "answer_count", "post_update_time").
+		Where(builder.Eq{"status": entity.QuestionStatusAvailable, "accepted_answer_id": 0}).
+		And(builder.Lte{"created_at": before})
+	if len(lastID) > 0 {
+		session.And(builder.Gt{"id": lastID})
+	}
+	err = session.Asc("id").Limit(limit).Find(&questionList)
+	return questionList, err
+}
+
+// GetPendingQuestionPage get the questions waiting for the approval, the oldest first
+func (qr *QuestionRepo) GetPendingQuestionPage(ctx context.Context, page, pageSize int) (
+	questionList []*entity.Question, total int64, err error) {
+	questionList = make([]*entity.Question, 0)
+	session := qr.DB.Context(ctx).Where("status = ?", entity.QuestionStatusPending).OrderBy("created_at ASC")
+	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
+	if err != nil {
+		return nil, 0, err
+	}
+	if utils.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return questionList, total, nil
+}
+
 // GetQuestionPage query question page
 func (qr *QuestionRepo) GetQuestionPage(ctx context.Context, page, pageSize int, userID, tagID, orderCond string, inDays int) (
 	questionList []*entity.Question, total int64, err error) {
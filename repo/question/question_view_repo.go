@@ -0,0 +1,193 @@
+package question
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/pkg/day"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// QuestionViewRepo question view repository, the views are counted in the cache and flushed to the database
+type QuestionViewRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewQuestionViewRepo new repository
+func NewQuestionViewRepo() *QuestionViewRepo {
+	return &QuestionViewRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// AddView count the view of the visitor, the view is dropped when the visitor has viewed the question
+// within the dedup window. The unique visitors are counted by HyperLogLog of the day, so the unique views of
+// every question are the sum of its daily unique visitors.
+func (vr *QuestionViewRepo) AddView(ctx context.Context, questionID, visitor, date string) (added bool, err error) {
+	dedupKey := constant.QuestionViewDedupCacheKeyPrefix + questionID + ":" + visitor
+	added, err = vr.Cache.SetNX(ctx, dedupKey, 1, constant.QuestionViewDedupCacheTime).Result()
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !added {
+		return false, nil
+	}
+
+	dailyKey := constant.QuestionDailyViewCacheKeyPrefix + date
+	dailyVisitorKey := constant.QuestionDailyViewVisitorCacheKeyPrefix + date + ":" + questionID
+	// the keys of the day expire at the same time however often the question is viewed
+	dayStart, err := time.ParseInLocation(day.DateLayout, date, time.Local)
+	if err != nil {
+		return false, errors.BadRequest(reason.RequestFormatError).WithError(err)
+	}
+	expireAt := dayStart.Add(24 * time.Hour).Add(constant.QuestionDailyViewCacheTime)
+	var newVisitor *redis.IntCmd
+	_, err = vr.Cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, constant.QuestionViewPendingCacheKey, questionID, 1)
+		pipe.HIncrBy(ctx, dailyKey, questionID, 1)
+		pipe.ExpireAt(ctx, dailyKey, expireAt)
+		newVisitor = pipe.PFAdd(ctx, dailyVisitorKey, visitor)
+		pipe.ExpireAt(ctx, dailyVisitorKey, expireAt)
+		return nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if newVisitor.Val() == 1 {
+		err = vr.Cache.HIncrBy(ctx, constant.QuestionUniqueViewPendingCacheKey, questionID, 1).Err()
+		if err != nil {
+			return true, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return true, nil
+}
+
+// TakePendingViews take the views and unique views which are not flushed yet, the pending counters are reset
+func (vr *QuestionViewRepo) TakePendingViews(ctx context.Context) (views, uniqueViews map[string]int64, err error) {
+	var viewsCmd, uniqueViewsCmd *redis.MapStringStringCmd
+	_, err = vr.Cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		viewsCmd = pipe.HGetAll(ctx, constant.QuestionViewPendingCacheKey)
+		uniqueViewsCmd = pipe.HGetAll(ctx, constant.QuestionUniqueViewPendingCacheKey)
+		pipe.Del(ctx, constant.QuestionViewPendingCacheKey, constant.QuestionUniqueViewPendingCacheKey)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return parseViewCounter(viewsCmd.Val()), parseViewCounter(uniqueViewsCmd.Val()), nil
+}
+
+// RestorePendingViews put back the views which are failed to flush
+func (vr *QuestionViewRepo) RestorePendingViews(ctx context.Context, questionID string, views, uniqueViews int64) (
+	err error) {
+	_, err = vr.Cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if views > 0 {
+			pipe.HIncrBy(ctx, constant.QuestionViewPendingCacheKey, questionID, views)
+		}
+		if uniqueViews > 0 {
+			pipe.HIncrBy(ctx, constant.QuestionUniqueViewPendingCacheKey, questionID, uniqueViews)
+		}
+		return nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDailyViews get the views and unique views of each question in the day from the cache
+func (vr *QuestionViewRepo) GetDailyViews(ctx context.Context, date string) (
+	statList []*entity.QuestionDailyViewStat, err error) {
+	statList = make([]*entity.QuestionDailyViewStat, 0)
+	views, err := vr.Cache.HGetAll(ctx, constant.QuestionDailyViewCacheKeyPrefix+date).Result()
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(views) == 0 {
+		return statList, nil
+	}
+
+	uniqueViewsCmd := make(map[string]*redis.IntCmd, len(views))
+	_, err = vr.Cache.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for questionID := range views {
+			uniqueViewsCmd[questionID] = pipe.PFCount(ctx,
+				constant.QuestionDailyViewVisitorCacheKeyPrefix+date+":"+questionID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for questionID, count := range parseViewCounter(views) {
+		statList = append(statList, &entity.QuestionDailyViewStat{
+			Date:            date,
+			QuestionID:      questionID,
+			ViewCount:       count,
+			UniqueViewCount: uniqueViewsCmd[questionID].Val(),
+		})
+	}
+	return statList, nil
+}
+
+// SaveDailyViewStat save the daily views of the questions, the old views of the day will be replaced
+func (vr *QuestionViewRepo) SaveDailyViewStat(ctx context.Context, statList []*entity.QuestionDailyViewStat) (
+	err error) {
+	_, err = vr.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		for _, stat := range statList {
+			old := &entity.QuestionDailyViewStat{}
+			exist, err := session.Where(builder.Eq{"date": stat.Date, "question_id": stat.QuestionID}).Get(old)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				_, err = session.ID(old.ID).Cols("view_count", "unique_view_count").Update(stat)
+			} else {
+				_, err = session.Insert(stat)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDailyViewStatList get the daily views of the question between the dates, both dates are included
+func (vr *QuestionViewRepo) GetDailyViewStatList(ctx context.Context, questionID, startDate, endDate string) (
+	statList []*entity.QuestionDailyViewStat, err error) {
+	statList = make([]*entity.QuestionDailyViewStat, 0)
+	err = vr.DB.Context(ctx).Where(builder.Eq{"question_id": questionID}).
+		And(builder.Between{Col: "date", LessVal: startDate, MoreVal: endDate}).
+		Asc("date").Find(&statList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func parseViewCounter(counter map[string]string) map[string]int64 {
+	result := make(map[string]int64, len(counter))
+	for questionID, value := range counter {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count <= 0 {
+			continue
+		}
+		result[questionID] = count
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/lawyer/commons/handler"
 	"github.com/lawyer/commons/utils"
 	"github.com/redis/go-redis/v9"
+	"xorm.io/builder"
 	"xorm.io/xorm"
 
 	"github.com/lawyer/commons/schema"
@@ -65,6 +66,17 @@ func (rr *ReportRepo) GetReportListPage(ctx context.Context, dto schema.GetRepor
 		cond.ObjectType = objectType
 	}
 
+	// limit to the reports of the questions with the scope tags, including their answers and comments
+	if dto.ScopeTagIDs != nil {
+		questionIDs := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+			Where(builder.In("tag_id", dto.ScopeTagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable}))
+		session.Where(builder.In("object_id", questionIDs).
+			Or(builder.In("object_id", builder.Select("id").From(entity.Answer{}.TableName()).
+				Where(builder.In("question_id", questionIDs)))).
+			Or(builder.In("object_id", builder.Select("id").From((&entity.Comment{}).TableName()).
+				Where(builder.In("question_id", questionIDs)))))
+	}
+
 	// order
 	session.OrderBy("updated_at desc")
 
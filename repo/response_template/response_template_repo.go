@@ -0,0 +1,100 @@
+package response_template
+
+import (
+	"context"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// ResponseTemplateRepo response template repository
+type ResponseTemplateRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewResponseTemplateRepo new repository
+func NewResponseTemplateRepo() *ResponseTemplateRepo {
+	return &ResponseTemplateRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// AddTemplate add template
+func (rr *ResponseTemplateRepo) AddTemplate(ctx context.Context, template *entity.ResponseTemplate) (err error) {
+	_, err = rr.DB.Context(ctx).Insert(template)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTemplate update the title, content and scope of the template
+func (rr *ResponseTemplateRepo) UpdateTemplate(ctx context.Context, template *entity.ResponseTemplate) (err error) {
+	_, err = rr.DB.Context(ctx).ID(template.ID).Cols("title", "content", "scope").Update(template)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTemplate delete template
+func (rr *ResponseTemplateRepo) RemoveTemplate(ctx context.Context, templateID int64) (err error) {
+	_, err = rr.DB.Context(ctx).ID(templateID).Cols("status").
+		Update(&entity.ResponseTemplate{Status: entity.ResponseTemplateStatusDeleted})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTemplate get the available template by id
+func (rr *ResponseTemplateRepo) GetTemplate(ctx context.Context, templateID int64) (
+	template *entity.ResponseTemplate, exist bool, err error) {
+	template = &entity.ResponseTemplate{}
+	exist, err = rr.DB.Context(ctx).ID(templateID).
+		Where(builder.Eq{"status": entity.ResponseTemplateStatusAvailable}).Get(template)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTemplateList get the site-wide templates and the templates of the user,
+// only the templates for the scope if scope is not empty, the most used first
+func (rr *ResponseTemplateRepo) GetTemplateList(ctx context.Context, userID, scope string) (
+	templateList []*entity.ResponseTemplate, err error) {
+	templateList = make([]*entity.ResponseTemplate, 0)
+	session := rr.DB.Context(ctx).Where(builder.Eq{"status": entity.ResponseTemplateStatusAvailable}).
+		And(builder.In("user_id", "0", userID))
+	if len(scope) > 0 {
+		session.And(builder.In("scope", "", scope))
+	}
+	err = session.Desc("use_count", "id").Find(&templateList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddTemplateUse record the template is used and increase the use count of the template
+func (rr *ResponseTemplateRepo) AddTemplateUse(ctx context.Context, use *entity.ResponseTemplateUse) (err error) {
+	_, err = rr.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Insert(use); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(use.TemplateID).Incr("use_count", 1).Update(&entity.ResponseTemplate{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
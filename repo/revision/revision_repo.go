@@ -7,6 +7,7 @@ import (
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/handler"
 	"github.com/redis/go-redis/v9"
+	"time"
 
 	"github.com/lawyer/commons/utils/pager"
 	"github.com/lawyer/pkg/converter"
@@ -81,20 +82,23 @@ func (rr *RevisionRepo) UpdateObjectRevisionId(ctx context.Context, revision *en
 	return nil
 }
 
-// UpdateStatus update revision status
-func (rr *RevisionRepo) UpdateStatus(ctx context.Context, id string, status int, reviewUserID string) (err error) {
+// UpdateStatus update the revision status only when it is still in the fromStatus, so a revision is decided once
+// even if the votes or the expiration race. The updated is false when the revision has been decided by others.
+func (rr *RevisionRepo) UpdateStatus(ctx context.Context, id string, fromStatus, status int, reviewUserID string) (
+	updated bool, err error) {
 	if id == "" {
-		return nil
+		return false, nil
 	}
 	var data entity.Revision
 	data.ID = id
 	data.Status = status
 	data.ReviewUserID = converter.StringToInt64(reviewUserID)
-	_, err = rr.DB.Context(ctx).Where("id =?", id).Cols("status", "review_user_id").Update(&data)
+	affected, err := rr.DB.Context(ctx).Where("id = ?", id).And("status = ?", fromStatus).
+		Cols("status", "review_user_id").Update(&data)
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	return nil
+	return affected == 1, nil
 }
 
 // GetRevision get revision one
@@ -142,6 +146,19 @@ func (rr *RevisionRepo) GetLastRevisionByObjectID(ctx context.Context, objectID
 	return
 }
 
+// GetPreviousApprovedRevision get the last approved revision of the object before the revision
+func (rr *RevisionRepo) GetPreviousApprovedRevision(ctx context.Context, objectID, revisionID string) (
+	revision *entity.Revision, exist bool, err error) {
+	revision = &entity.Revision{}
+	exist, err = rr.DB.Context(ctx).Where("object_id = ?", objectID).And("id < ?", revisionID).
+		In("status", []int{entity.RevisioNnormalStatus, entity.RevisionReviewPassStatus}).
+		OrderBy("id DESC").Get(revision)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetRevisionList get revision list all
 func (rr *RevisionRepo) GetRevisionList(ctx context.Context, revision *entity.Revision) (revisionList []entity.Revision, err error) {
 	revisionList = []entity.Revision{}
@@ -169,8 +186,9 @@ func (rr *RevisionRepo) allowRecord(objectType int) (ok bool) {
 }
 
 // GetUnreviewedRevisionPage get unreviewed revision page
+// the revisions created or already reviewed by the reviewer are excluded
 func (rr *RevisionRepo) GetUnreviewedRevisionPage(ctx context.Context, page int, pageSize int,
-	objectTypeList []int) (revisionList []*entity.Revision, total int64, err error) {
+	objectTypeList []int, reviewerID string) (revisionList []*entity.Revision, total int64, err error) {
 	revisionList = make([]*entity.Revision, 0)
 	if len(objectTypeList) == 0 {
 		return revisionList, 0, nil
@@ -178,6 +196,11 @@ func (rr *RevisionRepo) GetUnreviewedRevisionPage(ctx context.Context, page int,
 	session := rr.DB.Context(ctx)
 	session = session.And("status = ?", entity.RevisionUnreviewedStatus)
 	session = session.In("object_type", objectTypeList)
+	if len(reviewerID) > 0 {
+		session = session.And("user_id != ?", reviewerID)
+		session = session.NotIn("id", builder.Select("revision_id").From("revision_review").
+			Where(builder.Eq{"user_id": reviewerID}))
+	}
 	session = session.OrderBy("created_at asc")
 
 	total, err = pager.Help(page, pageSize, &revisionList, &entity.Revision{}, session)
@@ -186,3 +209,43 @@ func (rr *RevisionRepo) GetUnreviewedRevisionPage(ctx context.Context, page int,
 	}
 	return
 }
+
+// GetExpiredUnreviewedRevisionList get the unreviewed revisions created before the time
+func (rr *RevisionRepo) GetExpiredUnreviewedRevisionList(ctx context.Context, before time.Time, limit int) (
+	revisionList []*entity.Revision, err error) {
+	revisionList = make([]*entity.Revision, 0)
+	err = rr.DB.Context(ctx).Where("status = ?", entity.RevisionUnreviewedStatus).
+		And("created_at < ?", before).OrderBy("id ASC").Limit(limit).Find(&revisionList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddReview add the vote of the reviewer, exist is true if the reviewer has voted on the revision
+func (rr *RevisionRepo) AddReview(ctx context.Context, review *entity.RevisionReview) (exist bool, err error) {
+	exist, err = rr.DB.Context(ctx).Where("revision_id = ?", review.RevisionID).
+		And("user_id = ?", review.UserID).Exist(&entity.RevisionReview{})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return true, nil
+	}
+	_, err = rr.DB.Context(ctx).Insert(review)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return false, nil
+}
+
+// GetReviewList get the votes of the revision
+func (rr *RevisionRepo) GetReviewList(ctx context.Context, revisionID string) (
+	reviewList []*entity.RevisionReview, err error) {
+	reviewList = make([]*entity.RevisionReview, 0)
+	err = rr.DB.Context(ctx).Where("revision_id = ?", revisionID).OrderBy("id ASC").Find(&reviewList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -4,30 +4,31 @@ import (
 	"context"
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
 	"github.com/redis/go-redis/v9"
 	"xorm.io/xorm"
 
 	"github.com/segmentfault/pacman/errors"
 )
 
-// powerRepo power repository
-type powerRepo struct {
+// PowerRepo power repository
+type PowerRepo struct {
 	DB    *xorm.Engine
 	Cache *redis.Client
 }
 
 // NewPowerRepo new repository
-func NewPowerRepo(DB *xorm.Engine, Cache *redis.Client) *powerRepo {
-	return &powerRepo{
-		DB:    DB,
-		Cache: Cache,
+func NewPowerRepo() *PowerRepo {
+	return &PowerRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
 	}
 }
 
 // GetPowerList get  list all
-func (pr *powerRepo) GetPowerList(ctx context.Context, power *entity.Power) (powerList []*entity.Power, err error) {
+func (pr *PowerRepo) GetPowerList(ctx context.Context, power *entity.Power) (powerList []*entity.Power, err error) {
 	powerList = make([]*entity.Power, 0)
-	err = pr.DB.Context(ctx).Find(powerList, power)
+	err = pr.DB.Context(ctx).OrderBy("id ASC").Find(&powerList, power)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -3,6 +3,7 @@ package role
 import (
 	"context"
 	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/handler"
 	"github.com/redis/go-redis/v9"
 	"xorm.io/xorm"
@@ -35,3 +36,37 @@ func (rr *RolePowerRelRepo) GetRolePowerTypeList(ctx context.Context, roleID int
 	}
 	return
 }
+
+// SaveRolePowerTypeList replace all powers of the role
+func (rr *RolePowerRelRepo) SaveRolePowerTypeList(ctx context.Context, roleID int, powerTypes []string) (err error) {
+	_, err = rr.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		if len(powerTypes) == 0 {
+			return nil, nil
+		}
+		rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+		for _, powerType := range powerTypes {
+			rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: powerType})
+		}
+		_, err := session.Insert(rels)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRoleIDListByPowerType get the ids of roles which have any of the power types
+func (rr *RolePowerRelRepo) GetRoleIDListByPowerType(ctx context.Context, powerTypes []string) (roleIDs []int, err error) {
+	roleIDs = make([]int, 0)
+	err = rr.DB.Context(ctx).Table("role_power_rel").Distinct("role_id").
+		In("power_type", powerTypes).Find(&roleIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -9,6 +9,7 @@ import (
 	"xorm.io/xorm"
 
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // RoleRepo role repository
@@ -47,3 +48,71 @@ func (rr *RoleRepo) GetRoleAllMapping(ctx context.Context) (roleMapping map[int]
 	}
 	return roleMapping, nil
 }
+
+// GetRole get role by id
+func (rr *RoleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRoleByName get role by name
+func (rr *RoleRepo) GetRoleByName(ctx context.Context, name string) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.DB.Context(ctx).Where(builder.Eq{"name": name}).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddRole add role with its powers
+func (rr *RoleRepo) AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error) {
+	_, err = rr.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Insert(role); err != nil {
+			return nil, err
+		}
+		if len(powerTypes) == 0 {
+			return nil, nil
+		}
+		rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+		for _, powerType := range powerTypes {
+			rels = append(rels, &entity.RolePowerRel{RoleID: role.ID, PowerType: powerType})
+		}
+		_, err := session.Insert(rels)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update role name and description
+func (rr *RoleRepo) UpdateRole(ctx context.Context, role *entity.Role) (err error) {
+	_, err = rr.DB.Context(ctx).ID(role.ID).Cols("name", "description").Update(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole remove role and its powers
+func (rr *RoleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		_, err := session.ID(roleID).Delete(&entity.Role{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -84,3 +84,12 @@ func (ur *UserRoleRelRepo) GetUserRoleRel(ctx context.Context, userID string) (
 	}
 	return
 }
+
+// CountUserRoleRelByRoleID count the users who have the role
+func (ur *UserRoleRelRepo) CountUserRoleRelByRoleID(ctx context.Context, roleID int) (count int64, err error) {
+	count, err = ur.DB.Context(ctx).Where(builder.Eq{"role_id": roleID}).Count(&entity.UserRoleRel{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package role
+
+import (
+	"context"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// UserRoleScopeRelRepo user role scope rel repository
+type UserRoleScopeRelRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewUserRoleScopeRelRepo new repository
+func NewUserRoleScopeRelRepo() *UserRoleScopeRelRepo {
+	return &UserRoleScopeRelRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// SaveUserRoleScopeRel save user role scope rel, update the role if the scope already exists
+func (ur *UserRoleScopeRelRepo) SaveUserRoleScopeRel(ctx context.Context, rel *entity.UserRoleScopeRel) (err error) {
+	_, err = ur.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		item := &entity.UserRoleScopeRel{UserID: rel.UserID, ScopeType: rel.ScopeType, ScopeID: rel.ScopeID}
+		exist, err := session.Get(item)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			item.RoleID = rel.RoleID
+			_, err = session.ID(item.ID).Cols("role_id").Update(item)
+		} else {
+			_, err = session.Insert(rel)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserRoleScopeRel remove user role scope rel
+func (ur *UserRoleScopeRelRepo) RemoveUserRoleScopeRel(ctx context.Context, userID, scopeType, scopeID string) (err error) {
+	_, err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "scope_type": scopeType, "scope_id": scopeID}).
+		Delete(&entity.UserRoleScopeRel{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserRoleScopeRelList get user role scope rel list, all scopes will be returned if scopeIDs is empty
+func (ur *UserRoleScopeRelRepo) GetUserRoleScopeRelList(ctx context.Context, userID, scopeType string, scopeIDs []string) (
+	relList []*entity.UserRoleScopeRel, err error) {
+	relList = make([]*entity.UserRoleScopeRel, 0)
+	session := ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "scope_type": scopeType})
+	if len(scopeIDs) > 0 {
+		session.In("scope_id", scopeIDs)
+	}
+	err = session.Find(&relList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserRoleScopeRelListByScope get all user role scope rel of the scope
+func (ur *UserRoleScopeRelRepo) GetUserRoleScopeRelListByScope(ctx context.Context, scopeType, scopeID string) (
+	relList []*entity.UserRoleScopeRel, err error) {
+	relList = make([]*entity.UserRoleScopeRel, 0)
+	err = ur.DB.Context(ctx).Where(builder.Eq{"scope_type": scopeType, "scope_id": scopeID}).
+		OrderBy("id ASC").Find(&relList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserRoleScopeRelByRoleID count the scoped relations which use the role
+func (ur *UserRoleScopeRelRepo) CountUserRoleScopeRelByRoleID(ctx context.Context, roleID int) (count int64, err error) {
+	count, err = ur.DB.Context(ctx).Where(builder.Eq{"role_id": roleID}).Count(&entity.UserRoleScopeRel{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
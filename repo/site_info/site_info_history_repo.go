@@ -0,0 +1,78 @@
+package site_info
+
+import (
+	"context"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/commons/utils/pager"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// SiteInfoHistoryRepo site info history repository
+type SiteInfoHistoryRepo struct {
+	DB *xorm.Engine
+}
+
+// NewSiteInfoHistoryRepo new repository
+func NewSiteInfoHistoryRepo() *SiteInfoHistoryRepo {
+	return &SiteInfoHistoryRepo{
+		DB: handler.Engine,
+	}
+}
+
+// AddHistory add the next version of the site settings type, the version is set to the latest version + 1
+func (sr *SiteInfoHistoryRepo) AddHistory(ctx context.Context, history *entity.SiteInfoHistory) (err error) {
+	_, err = sr.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		latest := &entity.SiteInfoHistory{}
+		exist, err := session.Where("site_type = ?", history.SiteType).Desc("version").ForUpdate().Get(latest)
+		if err != nil {
+			return nil, err
+		}
+		history.Version = 1
+		if exist {
+			history.Version = latest.Version + 1
+		}
+		_, err = session.Insert(history)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetHistory get the version of the site settings type
+func (sr *SiteInfoHistoryRepo) GetHistory(ctx context.Context, siteType string, version int) (
+	history *entity.SiteInfoHistory, exist bool, err error) {
+	history = &entity.SiteInfoHistory{}
+	exist, err = sr.DB.Context(ctx).Where("site_type = ?", siteType).And("version = ?", version).Get(history)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountHistory count the versions of the site settings type
+func (sr *SiteInfoHistoryRepo) CountHistory(ctx context.Context, siteType string) (count int64, err error) {
+	count, err = sr.DB.Context(ctx).Where("site_type = ?", siteType).Count(&entity.SiteInfoHistory{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetHistoryPage get the versions of the site settings type, the latest first
+func (sr *SiteInfoHistoryRepo) GetHistoryPage(ctx context.Context, siteType string, page, pageSize int) (
+	historyList []*entity.SiteInfoHistory, total int64, err error) {
+	historyList = make([]*entity.SiteInfoHistory, 0)
+	session := sr.DB.Context(ctx).Where("site_type = ?", siteType).Desc("version")
+	total, err = pager.Help(page, pageSize, &historyList, &entity.SiteInfoHistory{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/pkg/uid"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+// SpamScoreRepo spam score repository
+type SpamScoreRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewSpamScoreRepo new repository
+func NewSpamScoreRepo() *SpamScoreRepo {
+	return &SpamScoreRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// AddScore add the spam score of the post
+func (sr *SpamScoreRepo) AddScore(ctx context.Context, score *entity.SpamScore) (err error) {
+	score.ObjectID = uid.DeShortID(score.ObjectID)
+	_, err = sr.DB.Context(ctx).Insert(score)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetScoreByObjectID get the spam score of the post
+func (sr *SpamScoreRepo) GetScoreByObjectID(ctx context.Context, objectID string) (
+	score *entity.SpamScore, exist bool, err error) {
+	score = &entity.SpamScore{}
+	exist, err = sr.DB.Context(ctx).Where("object_id = ?", uid.DeShortID(objectID)).Get(score)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountContentHashSince count the posts with the same content posted after the time
+func (sr *SpamScoreRepo) CountContentHashSince(ctx context.Context, contentHash string, since time.Time) (
+	count int64, err error) {
+	count, err = sr.DB.Context(ctx).Where("content_hash = ?", contentHash).
+		And("created_at >= ?", since).Count(&entity.SpamScore{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDecision update the review decision of the held post
+func (sr *SpamScoreRepo) UpdateDecision(ctx context.Context, id int64, decision string) (err error) {
+	_, err = sr.DB.Context(ctx).ID(id).Cols("decision").Update(&entity.SpamScore{Decision: decision})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateHoldScore change the hold score only when it is still the old one, so the concurrent adjustments and the
+// change of the admin are not overwritten. The updated is false when the hold score has been changed by others.
+func (sr *SpamScoreRepo) UpdateHoldScore(ctx context.Context, oldScore, newScore int) (updated bool, err error) {
+	cfg := &entity.Config{}
+	exist, err := sr.DB.Context(ctx).Where("`key` = ?", entity.SpamHoldScoreConfigKey).Get(cfg)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return false, nil
+	}
+	affected, err := sr.DB.Context(ctx).ID(cfg.ID).And("value = ?", strconv.Itoa(oldScore)).
+		Cols("value").Update(&entity.Config{Value: strconv.Itoa(newScore)})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	err = sr.Cache.Del(ctx, constant.ConfigKEY2ContentCacheKeyPrefix+entity.SpamHoldScoreConfigKey,
+		fmt.Sprintf("%s%d", constant.ConfigID2KEYCacheKeyPrefix, cfg.ID)).Err()
+	if err != nil {
+		log.Error(err)
+	}
+	return true, nil
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"encoding/json"
+	"github.com/lawyer/commons/constant"
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/handler"
@@ -80,6 +81,30 @@ func (ur *UserAdminRepo) AddUsers(ctx context.Context, users []*entity.User) (er
 	return
 }
 
+// ImportUsers add the users and their roles in one transaction, no user is added when any insert fails.
+// The role of the user is the one in roleIDs at the same index, the user with the role id 0 gets the default role.
+func (ur *UserAdminRepo) ImportUsers(ctx context.Context, users []*entity.User, roleIDs []int) (err error) {
+	_, err = ur.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		for i, user := range users {
+			if _, err := session.Insert(user); err != nil {
+				return nil, err
+			}
+			if roleIDs[i] == 0 {
+				continue
+			}
+			if _, err := session.Insert(&entity.UserRoleRel{UserID: user.ID, RoleID: roleIDs[i]}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // UpdateUserPassword update user password
 func (ur *UserAdminRepo) UpdateUserPassword(ctx context.Context, userID string, password string) (err error) {
 	_, err = ur.DB.Context(ctx).ID(userID).Update(&entity.User{Pass: password})
@@ -157,3 +182,24 @@ func (ur *UserAdminRepo) GetUserPage(ctx context.Context, page, pageSize int, us
 	tryToDecorateUserListFromUserCenter(ctx, ur.DB, users)
 	return
 }
+
+// SetImportJob save the progress and the report of the user import job
+func (ur *UserAdminRepo) SetImportJob(ctx context.Context, jobID, content string) (err error) {
+	err = ur.Cache.Set(ctx, constant.UserImportJobCacheKeyPrefix+jobID, content, constant.UserImportJobCacheTime).Err()
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetImportJob get the progress and the report of the user import job
+func (ur *UserAdminRepo) GetImportJob(ctx context.Context, jobID string) (content string, exist bool, err error) {
+	content, err = ur.Cache.Get(ctx, constant.UserImportJobCacheKeyPrefix+jobID).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return content, true, nil
+}
@@ -0,0 +1,200 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// UserDataRepo the personal data export and the account deletion of the user
+type UserDataRepo struct {
+	DB *xorm.Engine
+}
+
+// NewUserDataRepo new repository
+func NewUserDataRepo() *UserDataRepo {
+	return &UserDataRepo{
+		DB: handler.Engine,
+	}
+}
+
+// GetUserQuestions get all the questions created by the user
+func (ur *UserDataRepo) GetUserQuestions(ctx context.Context, userID string) (questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("created_at").Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserAnswers get all the answers created by the user
+func (ur *UserDataRepo) GetUserAnswers(ctx context.Context, userID string) (answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("created_at").Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserComments get all the comments created by the user
+func (ur *UserDataRepo) GetUserComments(ctx context.Context, userID string) (comments []*entity.Comment, err error) {
+	comments = make([]*entity.Comment, 0)
+	err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("created_at").Find(&comments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserVotes get the votes of the user which are not cancelled
+func (ur *UserDataRepo) GetUserVotes(ctx context.Context, userID string, activityTypes []int) (
+	votes []*entity.Activity, err error) {
+	votes = make([]*entity.Activity, 0)
+	if len(activityTypes) == 0 {
+		return votes, nil
+	}
+	cond := builder.And(
+		builder.Eq{"user_id": userID},
+		builder.Eq{"cancelled": entity.ActivityAvailable},
+		builder.In("activity_type", activityTypes),
+	)
+	err = ur.DB.Context(ctx).Where(cond).Asc("created_at").Find(&votes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserCollections get the collected objects of the user
+func (ur *UserDataRepo) GetUserCollections(ctx context.Context, userID string) (
+	collections []*entity.Collection, err error) {
+	collections = make([]*entity.Collection, 0)
+	err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("created_at").Find(&collections)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserNotifications get the notifications received by the user
+func (ur *UserDataRepo) GetUserNotifications(ctx context.Context, userID string) (
+	notifications []*entity.Notification, err error) {
+	notifications = make([]*entity.Notification, 0)
+	err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("created_at").Find(&notifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeletion get the account deletion of the user
+func (ur *UserDataRepo) GetDeletion(ctx context.Context, userID string) (
+	deletion *entity.UserDeletion, exist bool, err error) {
+	deletion = &entity.UserDeletion{}
+	exist, err = ur.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Get(deletion)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveDeletion schedule the account deletion, the canceled deletion of the user is scheduled again
+func (ur *UserDataRepo) SaveDeletion(ctx context.Context, userID string, scheduledAt time.Time) (err error) {
+	deletion := &entity.UserDeletion{
+		UserID:      userID,
+		Status:      entity.UserDeletionStatusPending,
+		ScheduledAt: scheduledAt,
+	}
+	_, err = ur.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		old := &entity.UserDeletion{}
+		exist, err := session.Where(builder.Eq{"user_id": userID}).Get(old)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			_, err = session.ID(old.ID).Cols("status", "scheduled_at").Update(deletion)
+		} else {
+			_, err = session.Insert(deletion)
+		}
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CancelDeletion cancel the pending account deletion, ok is false when there is no pending deletion
+func (ur *UserDataRepo) CancelDeletion(ctx context.Context, userID string) (ok bool, err error) {
+	affected, err := ur.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID, "status": entity.UserDeletionStatusPending}).
+		Cols("status").Update(&entity.UserDeletion{Status: entity.UserDeletionStatusCanceled})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// GetDueDeletions get the pending account deletions whose grace period ended
+func (ur *UserDataRepo) GetDueDeletions(ctx context.Context, now time.Time, limit int) (
+	deletions []*entity.UserDeletion, err error) {
+	deletions = make([]*entity.UserDeletion, 0)
+	err = ur.DB.Context(ctx).
+		Where(builder.Eq{"status": entity.UserDeletionStatusPending}.And(builder.Lte{"scheduled_at": now})).
+		Asc("scheduled_at").Limit(limit).Find(&deletions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AnonymizeUser erase the personal data of the user and complete the deletion in one transaction. The user row is
+// kept with the anonymized profile, so the questions, answers and comments stay in the threads. ok is false when the
+// deletion is no longer pending.
+func (ur *UserDataRepo) AnonymizeUser(ctx context.Context, userInfo *entity.User) (ok bool, err error) {
+	_, err = ur.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		// the deletion may be canceled after it was picked up
+		affected, err := session.Where(builder.Eq{"user_id": userInfo.ID, "status": entity.UserDeletionStatusPending}).
+			Cols("status").Update(&entity.UserDeletion{Status: entity.UserDeletionStatusCompleted})
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, nil
+		}
+		ok = true
+		_, err = session.ID(userInfo.ID).
+			Cols("username", "pass", "e_mail", "mail_status", "status", "deleted_at", "display_name", "avatar",
+				"mobile", "bio", "bio_html", "website", "location", "ip_info", "language").
+			Update(userInfo)
+		if err != nil {
+			return nil, err
+		}
+		cond := builder.Eq{"user_id": userInfo.ID}
+		for _, bean := range []interface{}{
+			&entity.UserExternalLogin{},
+			&entity.UserTwoFactor{},
+			&entity.UserNotificationConfig{},
+			&entity.Notification{},
+		} {
+			if _, err = session.Where(cond).Delete(bean); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return ok, nil
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// UserTwoFactorRepo user two-factor authentication repository
+type UserTwoFactorRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewUserTwoFactorRepo new repository
+func NewUserTwoFactorRepo() *UserTwoFactorRepo {
+	return &UserTwoFactorRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// GetByUserID get the two-factor authentication of the user
+func (tr *UserTwoFactorRepo) GetByUserID(ctx context.Context, userID string) (
+	twoFactor *entity.UserTwoFactor, exist bool, err error) {
+	twoFactor = &entity.UserTwoFactor{}
+	exist, err = tr.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Get(twoFactor)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SavePendingSecret save the new secret which is waiting for the confirmation, the old one is replaced
+func (tr *UserTwoFactorRepo) SavePendingSecret(ctx context.Context, userID, secret string) (err error) {
+	twoFactor := &entity.UserTwoFactor{
+		UserID:        userID,
+		Secret:        secret,
+		Status:        entity.TwoFactorStatusPending,
+		RecoveryCodes: "[]",
+	}
+	_, err = tr.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		old := &entity.UserTwoFactor{}
+		exist, err := session.Where(builder.Eq{"user_id": userID}).Get(old)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			_, err = session.ID(old.ID).Cols("secret", "status", "recovery_codes", "last_used_step").Update(twoFactor)
+		} else {
+			_, err = session.Insert(twoFactor)
+		}
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// Enable enable the two-factor authentication with the hashed recovery codes
+func (tr *UserTwoFactorRepo) Enable(ctx context.Context, userID, recoveryCodes string, step int64) (err error) {
+	_, err = tr.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		Cols("status", "recovery_codes", "last_used_step").
+		Update(&entity.UserTwoFactor{
+			Status:        entity.TwoFactorStatusEnabled,
+			RecoveryCodes: recoveryCodes,
+			LastUsedStep:  step,
+		})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UseStep mark the time step as used, false if the step or a later one has been used
+func (tr *UserTwoFactorRepo) UseStep(ctx context.Context, userID string, step int64) (ok bool, err error) {
+	affected, err := tr.DB.Context(ctx).Where(builder.Eq{"user_id": userID}.And(builder.Lt{"last_used_step": step})).
+		Cols("last_used_step").Update(&entity.UserTwoFactor{LastUsedStep: step})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// ReplaceRecoveryCodes replace the recovery codes, false if they have been changed by others
+func (tr *UserTwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID, oldCodes, newCodes string) (
+	ok bool, err error) {
+	affected, err := tr.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "recovery_codes": oldCodes}).
+		Cols("recovery_codes").Update(&entity.UserTwoFactor{RecoveryCodes: newCodes})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// RemoveByUserID remove the two-factor authentication of the user
+func (tr *UserTwoFactorRepo) RemoveByUserID(ctx context.Context, userID string) (err error) {
+	_, err = tr.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Delete(&entity.UserTwoFactor{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SetChallenge set the login challenge
+func (tr *UserTwoFactorRepo) SetChallenge(ctx context.Context, token string, challenge *entity.TwoFactorChallenge) (
+	err error) {
+	content, _ := json.Marshal(challenge)
+	err = tr.Cache.Set(ctx, constant.UserTwoFactorChallengeCacheKeyPrefix+token, string(content),
+		constant.UserTwoFactorChallengeCacheTime).Err()
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IncrChallengeAttempts count the verifications of the login challenge, the counter expires with the challenge
+func (tr *UserTwoFactorRepo) IncrChallengeAttempts(ctx context.Context, token string) (attempts int64, err error) {
+	key := constant.UserTwoFactorChallengeCacheKeyPrefix + token + ":attempts"
+	attempts, err = tr.Cache.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if attempts == 1 {
+		tr.Cache.Expire(ctx, key, constant.UserTwoFactorChallengeCacheTime)
+	}
+	return attempts, nil
+}
+
+// GetChallenge get the login challenge
+func (tr *UserTwoFactorRepo) GetChallenge(ctx context.Context, token string) (
+	challenge *entity.TwoFactorChallenge, exist bool, err error) {
+	content, err := tr.Cache.Get(ctx, constant.UserTwoFactorChallengeCacheKeyPrefix+token).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	challenge = &entity.TwoFactorChallenge{}
+	if err = json.Unmarshal([]byte(content), challenge); err != nil {
+		return nil, false, nil
+	}
+	return challenge, true, nil
+}
+
+// RemoveChallenge remove the login challenge
+func (tr *UserTwoFactorRepo) RemoveChallenge(ctx context.Context, token string) (err error) {
+	err = tr.Cache.Del(ctx, constant.UserTwoFactorChallengeCacheKeyPrefix+token,
+		constant.UserTwoFactorChallengeCacheKeyPrefix+token+":attempts").Err()
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
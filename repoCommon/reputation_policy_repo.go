@@ -0,0 +1,106 @@
+package repoCommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+// ReputationPolicyRepo reputation policy repository
+type ReputationPolicyRepo struct {
+	DB    *xorm.Engine
+	Cache *redis.Client
+}
+
+// NewReputationPolicyRepo new repository
+func NewReputationPolicyRepo() *ReputationPolicyRepo {
+	return &ReputationPolicyRepo{
+		DB:    handler.Engine,
+		Cache: handler.RedisClient,
+	}
+}
+
+// GetReputationPolicy get the current reputation policy
+// If the policy has never been saved, it is built from the legacy config daily_rank_limit and daily_rank_limit.exclude
+func (pr *ReputationPolicyRepo) GetReputationPolicy(ctx context.Context) (policy *schema.ReputationPolicy, err error) {
+	policy = &schema.ReputationPolicy{}
+	cfg, err := utils.GetConfigByKey(ctx, entity.ReputationPolicyConfigKey)
+	if err == nil && len(cfg.Value) > 0 {
+		if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+			return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+		}
+		return policy, nil
+	}
+
+	policy.MinRank = 1
+	policy.DailyCap, err = utils.GetIntValue(ctx, "daily_rank_limit")
+	if err != nil {
+		return nil, err
+	}
+	policy.DailyCapExclude, _ = utils.GetArrayStringValue(ctx, "daily_rank_limit.exclude")
+	return policy, nil
+}
+
+// SaveReputationPolicy save the policy as a new version and make it current. The config row is locked while the
+// version is read, so the concurrent saves get their own versions, and the history and the config change together.
+func (pr *ReputationPolicyRepo) SaveReputationPolicy(ctx context.Context, policy *schema.ReputationPolicy, userID string) (
+	err error) {
+	cfg := &entity.Config{}
+	_, err = pr.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		exist, err := session.Where("`key` = ?", entity.ReputationPolicyConfigKey).ForUpdate().Get(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("config not found by key: %s", entity.ReputationPolicyConfigKey)
+		}
+		current := &schema.ReputationPolicy{}
+		if len(cfg.Value) > 0 {
+			if err = json.Unmarshal([]byte(cfg.Value), current); err != nil {
+				return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+			}
+		}
+		policy.Version = current.Version + 1
+		content, err := json.Marshal(policy)
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.ReputationPolicy{
+			Version: policy.Version,
+			UserID:  userID,
+			Content: string(content),
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(cfg.ID).Cols("value").Update(&entity.Config{Value: string(content)})
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
+	err = pr.Cache.Del(ctx, constant.ConfigKEY2ContentCacheKeyPrefix+entity.ReputationPolicyConfigKey,
+		fmt.Sprintf("%s%d", constant.ConfigID2KEYCacheKeyPrefix, cfg.ID)).Err()
+	if err != nil {
+		log.Error(err)
+	}
+	return nil
+}
+
+// GetReputationPolicyVersionList get all saved versions of the policy, the newest first
+func (pr *ReputationPolicyRepo) GetReputationPolicyVersionList(ctx context.Context) (
+	policyList []*entity.ReputationPolicy, err error) {
+	policyList = make([]*entity.ReputationPolicy, 0)
+	err = pr.DB.Context(ctx).Desc("version").Find(&policyList)
+	return policyList, err
+}
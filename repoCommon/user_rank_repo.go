@@ -9,6 +9,7 @@ import (
 
 	"github.com/jinzhu/now"
 	"github.com/lawyer/commons/utils/pager"
+	"github.com/lawyer/pkg/rank"
 	"github.com/lawyer/plugin"
 	"github.com/segmentfault/pacman/log"
 	"xorm.io/builder"
@@ -30,12 +31,23 @@ func NewUserRankRepo() *UserRankRepo {
 	}
 }
 
+// GetMaxDailyRank get the daily cap of the reputation policy
 func (ur *UserRankRepo) GetMaxDailyRank(ctx context.Context) (maxDailyRank int, err error) {
-	maxDailyRank, err = utils.GetIntValue(ctx, "daily_rank_limit")
+	policy, err := NewReputationPolicyRepo().GetReputationPolicy(ctx)
 	if err != nil {
 		return 0, err
 	}
-	return maxDailyRank, nil
+	return policy.DailyCap, nil
+}
+
+// GetMinRank get the minimum rank floor of the reputation policy
+func (ur *UserRankRepo) GetMinRank(ctx context.Context) (minRank int) {
+	policy, err := NewReputationPolicyRepo().GetReputationPolicy(ctx)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+	return policy.MinRank
 }
 
 func (ur *UserRankRepo) CheckReachLimit(ctx context.Context, session *xorm.Session,
@@ -71,9 +83,12 @@ func (ur *UserRankRepo) ChangeUserRank(
 		return nil
 	}
 
-	// If user rank is lower than 1 after this action, then user rank will be set to 1 only.
-	if deltaRank < 0 && userCurrentScore+deltaRank < 1 {
-		deltaRank = 1 - userCurrentScore
+	// If user rank is lower than the min rank after this action, then user rank will be set to the min rank only.
+	if deltaRank < 0 {
+		deltaRank = rank.LimitMinRank(userCurrentScore, deltaRank, ur.GetMinRank(ctx))
+	}
+	if deltaRank == 0 {
+		return nil
 	}
 
 	_, err = session.ID(userID).Incr("`rank`", deltaRank).Update(&entity.User{})
@@ -95,19 +110,17 @@ func (ur *UserRankRepo) TriggerUserRank(ctx context.Context,
 	}
 
 	if deltaRank < 0 {
-		// if user rank is lower than 1 after this action, then user rank will be set to 1 only.
-		var isReachMin bool
-		isReachMin, err = ur.checkUserMinRank(ctx, session, userID, deltaRank)
+		// if user rank is lower than the min rank after this action, then user rank will be set to the min rank only.
+		var limitedRank int
+		limitedRank, err = ur.limitUserMinRank(ctx, session, userID, deltaRank, ur.GetMinRank(ctx))
 		if err != nil {
 			return false, err
 		}
-		if isReachMin {
-			_, err = session.Where(builder.Eq{"id": userID}).Update(&entity.User{Rank: 1})
-			if err != nil {
-				return false, err
-			}
-			return true, nil
+		isReachStandard = limitedRank != deltaRank
+		if limitedRank == 0 {
+			return isReachStandard, nil
 		}
+		deltaRank = limitedRank
 	} else {
 		isReachStandard, err = ur.checkUserTodayRank(ctx, session, userID, activityType)
 		if err != nil {
@@ -121,30 +134,35 @@ func (ur *UserRankRepo) TriggerUserRank(ctx context.Context,
 	if err != nil {
 		return false, err
 	}
-	return false, nil
+	return isReachStandard, nil
 }
 
-func (ur *UserRankRepo) checkUserMinRank(ctx context.Context, session *xorm.Session, userID string, deltaRank int) (
-	isReachStandard bool, err error,
+// limitUserMinRank limit the negative rank change by the current rank of the user and the min rank
+func (ur *UserRankRepo) limitUserMinRank(ctx context.Context, session *xorm.Session, userID string,
+	deltaRank, minRank int) (
+	limitedRank int, err error,
 ) {
 	bean := &entity.User{ID: userID}
 	_, err = session.Select("`rank`").Get(bean)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
-	if bean.Rank+deltaRank < 1 {
+	limitedRank = rank.LimitMinRank(bean.Rank, deltaRank, minRank)
+	if limitedRank != deltaRank {
 		log.Infof("user %s is rank %d out of range before rank operation", userID, deltaRank)
-		return true, nil
 	}
-	return
+	return limitedRank, nil
 }
 
 func (ur *UserRankRepo) checkUserTodayRank(ctx context.Context,
 	session *xorm.Session, userID string, activityType int,
 ) (isReachStandard bool, err error) {
 	// exclude daily rank
-	exclude, _ := utils.GetArrayStringValue(ctx, "daily_rank_limit.exclude")
-	for _, item := range exclude {
+	policy, err := NewReputationPolicyRepo().GetReputationPolicy(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, item := range policy.DailyCapExclude {
 		cfg, err := utils.GetConfigByKey(ctx, item)
 		if err != nil {
 			return false, err
@@ -169,11 +187,7 @@ func (ur *UserRankRepo) checkUserTodayRank(ctx context.Context,
 	}
 
 	// max rank
-	maxDailyRank, err := utils.GetIntValue(ctx, "daily_rank_limit")
-	if err != nil {
-		return false, err
-	}
-
+	maxDailyRank := policy.DailyCap
 	if int(earned) < maxDailyRank {
 		return false, nil
 	}
@@ -193,3 +207,31 @@ func (ur *UserRankRepo) UserRankPage(ctx context.Context, userID string, page, p
 	total, err = pager.Help(page, pageSize, &rankPage, cond, session)
 	return
 }
+
+// GetUserRankActivityList get all available activities which changed the rank of the user, order by the time they happened
+func (ur *UserRankRepo) GetUserRankActivityList(ctx context.Context, userID string) (
+	activityList []*entity.Activity, err error) {
+	activityList = make([]*entity.Activity, 0)
+	err = ur.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID}).
+		And(builder.Eq{"has_rank": 1}).
+		And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+		Asc("created_at", "id").
+		Find(&activityList)
+	return activityList, err
+}
+
+// GetUserRankList get the rank of users by page, order by user id
+func (ur *UserRankRepo) GetUserRankList(ctx context.Context, page, pageSize int) (
+	userList []*entity.User, err error) {
+	userList = make([]*entity.User, 0)
+	err = ur.DB.Context(ctx).Cols("id", "username", "`rank`", "mail_status").
+		Asc("id").Limit(pageSize, (page-1)*pageSize).Find(&userList)
+	return userList, err
+}
+
+// SetUserRank set the rank of the user directly, only used for fixing the rank by recalculation
+func (ur *UserRankRepo) SetUserRank(ctx context.Context, userID string, rank int) (err error) {
+	_, err = ur.DB.Context(ctx).ID(userID).Cols("`rank`").Update(&entity.User{Rank: rank})
+	return err
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/lawyer/commons/base/server"
 	glog "github.com/lawyer/commons/logger"
 	"github.com/lawyer/middleware"
 	"github.com/lawyer/router/routes"
@@ -17,6 +18,7 @@ func NewHTTPServer(debug bool) *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
+	server.LoadTemplate(r)
 	r.GET("/heartBeat", heartBeats)
 	InitRoutes(r)
 	return r
@@ -29,10 +31,19 @@ func InitRoutes(r *gin.Engine) {
 	r.Use(middleware.TraceId())
 	r.Use(middleware.RecoverPanic())
 
+	// the server side rendered pages are served from the root for the crawlers
+	routes.RegisterTemplateApi(&r.RouterGroup)
+
 	router := r.Group("/lawyer")
 
 	routes.RegisterUserApi(router)
 	routes.RegisterQuestionApi(router)
+	routes.RegisterBadgeApi(router)
+	routes.RegisterResponseTemplateApi(router)
+	routes.RegisterAnswerDisclaimerApi(router)
+	routes.RegisterRevisionReviewApi(router)
+	routes.RegisterSiteInfoApi(router)
+	routes.RegisterConnectorApi(router)
 	//routes.RegisterQuestionApi(router)
 	//
 	//routes.RegisterOtherApi(router)
@@ -43,13 +54,27 @@ func InitRoutes(r *gin.Engine) {
 	//
 	//routes.RegisterNotificationApi(router)
 	//
-	//routes.RegisterReportApi(router)
+	routes.RegisterReportApi(router)
 	//
 	//routes.RegisterTagApi(router)
 	//
 	//routes.RegisterRevisionApi(router)
 	//管理员用的后台接口
 	//routes.RegisterAdminUserApi(router)
+	routes.RegisterAdminRoleApi(router)
+	routes.RegisterAdminAuditLogApi(router)
+	routes.RegisterAdminDashboardApi(router)
+	routes.RegisterAdminReputationApi(router)
+	routes.RegisterAdminBadgeApi(router)
+	routes.RegisterAdminQuestionLifecycleApi(router)
+	routes.RegisterAdminAnswerDisclaimerApi(router)
+	routes.RegisterAdminRevisionReviewApi(router)
+	routes.RegisterAdminPreModerationApi(router)
+	routes.RegisterAdminSpamApi(router)
+	routes.RegisterAdminTwoFactorApi(router)
+	routes.RegisterAdminUserSessionApi(router)
+	routes.RegisterAdminUserImportApi(router)
+	routes.RegisterAdminPluginApi(router)
 
 }
 
@@ -3,6 +3,8 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/lawyer/controller"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
 )
 
 func RegisterAnswerApi(r *gin.RouterGroup) {
@@ -19,3 +21,19 @@ func RegisterAnswerApi(r *gin.RouterGroup) {
 	r.PUT("/answer/status", c.AdminUpdateAnswerStatus)
 
 }
+
+// RegisterAnswerDisclaimerApi the disclaimer shown when answering, need login
+func RegisterAnswerDisclaimerApi(r *gin.RouterGroup) {
+	c := &controller.AnswerController{}
+	rg := r.Group("/answer", middleware.AccessToken())
+	rg.GET("/disclaimer", c.GetDisclaimer)
+}
+
+// RegisterAdminAnswerDisclaimerApi the answer disclaimers and the acknowledgements, only for admin
+func RegisterAdminAnswerDisclaimerApi(r *gin.RouterGroup) {
+	c := controller_admin.NewAnswerDisclaimerController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/answer/disclaimer", c.GetAnswerDisclaimerPolicy)
+	rg.PUT("/answer/disclaimer", c.UpdateAnswerDisclaimerPolicy)
+	rg.GET("/answer/disclaimer/ack", c.GetAnswerDisclaimerAck)
+}
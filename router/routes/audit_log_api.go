@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterAdminAuditLogApi the audit log of the admin and moderator actions, only for admin
+func RegisterAdminAuditLogApi(r *gin.RouterGroup) {
+	c := controller_admin.NewAuditLogController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/audit-logs/page", c.GetAuditLogPage)
+	rg.GET("/audit-logs/export", c.ExportAuditLog)
+}
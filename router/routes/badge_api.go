@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterBadgeApi the badges and the badges of the user, no need login
+func RegisterBadgeApi(r *gin.RouterGroup) {
+	c := controller.NewBadgeController()
+	r.GET("/badges", c.GetBadgeList)
+	r.GET("/user/badges", c.GetUserBadgeList)
+}
+
+// RegisterAdminBadgeApi the badge rules, only for admin
+func RegisterAdminBadgeApi(r *gin.RouterGroup) {
+	c := controller_admin.NewBadgeController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/badges", c.GetBadgeList)
+	rg.POST("/badge", c.AddBadge)
+	rg.PUT("/badge", c.UpdateBadge)
+}
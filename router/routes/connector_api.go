@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+	"github.com/lawyer/service"
+)
+
+// RegisterConnectorApi the external login by the connector plugins, such as the built-in OpenID Connect connector
+func RegisterConnectorApi(r *gin.RouterGroup) {
+	c := controller.NewConnectorController(service.EmailServicer, service.UserExternalLoginServicer)
+	rg := r.Group("/connector")
+	rg.GET("/login/:name", c.ConnectorLoginDispatcher)
+	rg.GET("/redirect/:name", c.ConnectorRedirectDispatcher)
+	rg.GET("/info", c.ConnectorsInfo)
+	rg.POST("/binding/email", c.ExternalLoginBindingUserSendEmail)
+
+	loginRoute := rg.Group("", middleware.AccessToken())
+	loginRoute.GET("/user/info", c.ConnectorsUserInfo)
+	loginRoute.DELETE("/user/unbinding", c.ExternalLoginUnbinding)
+}
+
+// RegisterAdminPluginApi enable and configure the plugins, only for admin
+func RegisterAdminPluginApi(r *gin.RouterGroup) {
+	c := controller_admin.NewPluginController()
+	r.GET("/plugin/status", c.GetAllPluginStatus)
+
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/plugins", c.GetPluginList)
+	rg.PUT("/plugin/status", c.UpdatePluginStatus)
+	rg.GET("/plugin/config", c.GetPluginConfig)
+	rg.PUT("/plugin/config", c.UpdatePluginConfig)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterAdminDashboardApi the time series statistics of the site, only for admin
+func RegisterAdminDashboardApi(r *gin.RouterGroup) {
+	c := controller_admin.NewDashboardController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/dashboard/stats", c.GetStat)
+	rg.GET("/dashboard/stats/export", c.ExportStat)
+	rg.POST("/dashboard/stats/rollup", c.RollupStat)
+}
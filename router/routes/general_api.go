@@ -19,36 +19,41 @@ func RegisterLanguageApi(r *gin.RouterGroup) {
 
 // siteinfo
 func RegisterSiteInfoApi(r *gin.RouterGroup) {
-	//c := &controller.SiteInfoController{}
-	//r.GET("/siteinfo", c.GetSiteInfo)
-	//r.GET("/siteinfo/legal", c.GetSiteLegalInfo)
-	//
-	//ac := &controller_admin.SiteInfoController{}
-	//r.GET("/siteinfo/general", ac.GetGeneral)
-	//r.PUT("/siteinfo/general", ac.UpdateGeneral)
-	//r.GET("/siteinfo/interface", ac.GetInterface)
-	//r.PUT("/siteinfo/interface", ac.UpdateInterface)
-	//r.GET("/siteinfo/branding", ac.GetSiteBranding)
-	//r.PUT("/siteinfo/branding", ac.UpdateBranding)
-	//r.GET("/siteinfo/write", ac.GetSiteWrite)
-	//r.PUT("/siteinfo/write", ac.UpdateSiteWrite)
-	//r.GET("/siteinfo/legal", ac.GetSiteLegal)
-	//r.PUT("/siteinfo/legal", ac.UpdateSiteLegal)
-	//r.GET("/siteinfo/seo", ac.GetSeo)
-	//r.PUT("/siteinfo/seo", ac.UpdateSeo)
-	//r.GET("/siteinfo/login", ac.GetSiteLogin)
-	//r.PUT("/siteinfo/login", ac.UpdateSiteLogin)
-	//r.GET("/siteinfo/custom-css-html", ac.GetSiteCustomCssHTML)
-	//r.PUT("/siteinfo/custom-css-html", ac.UpdateSiteCustomCssHTML)
-	//r.GET("/siteinfo/theme", ac.GetSiteTheme)
-	//r.PUT("/siteinfo/theme", ac.SaveSiteTheme)
-	//r.GET("/siteinfo/users", ac.GetSiteUsers)
-	//r.PUT("/siteinfo/users", ac.UpdateSiteUsers)
-	//r.GET("/setting/smtp", ac.GetSMTPConfig)
-	//r.PUT("/setting/smtp", ac.UpdateSMTPConfig)
-	//r.GET("/setting/privileges", ac.GetPrivilegesConfig)
-	//r.PUT("/setting/privileges", ac.UpdatePrivilegesConfig)
+	c := controller.NewSiteInfoController()
+	r.GET("/siteinfo", c.GetSiteInfo)
+	r.GET("/siteinfo/legal", c.GetSiteLegalInfo)
+	r.GET("/manifest.json", c.GetManifestJson)
 
+	ac := controller_admin.NewSiteInfoController()
+	r.GET("/robots.txt", ac.GetRobots)
+	r.GET("/custom.css", ac.GetCss)
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/siteinfo/general", ac.GetGeneral)
+	rg.PUT("/siteinfo/general", ac.UpdateGeneral)
+	rg.GET("/siteinfo/interface", ac.GetInterface)
+	rg.PUT("/siteinfo/interface", ac.UpdateInterface)
+	rg.GET("/siteinfo/branding", ac.GetSiteBranding)
+	rg.PUT("/siteinfo/branding", ac.UpdateBranding)
+	rg.GET("/siteinfo/write", ac.GetSiteWrite)
+	rg.PUT("/siteinfo/write", ac.UpdateSiteWrite)
+	rg.GET("/siteinfo/legal", ac.GetSiteLegal)
+	rg.PUT("/siteinfo/legal", ac.UpdateSiteLegal)
+	rg.GET("/siteinfo/seo", ac.GetSeo)
+	rg.PUT("/siteinfo/seo", ac.UpdateSeo)
+	rg.GET("/siteinfo/login", ac.GetSiteLogin)
+	rg.PUT("/siteinfo/login", ac.UpdateSiteLogin)
+	rg.GET("/siteinfo/custom-css-html", ac.GetSiteCustomCssHTML)
+	rg.PUT("/siteinfo/custom-css-html", ac.UpdateSiteCustomCssHTML)
+	rg.GET("/siteinfo/theme", ac.GetSiteTheme)
+	rg.PUT("/siteinfo/theme", ac.SaveSiteTheme)
+	rg.GET("/siteinfo/users", ac.GetSiteUsers)
+	rg.PUT("/siteinfo/users", ac.UpdateSiteUsers)
+	rg.GET("/siteinfo/history/page", ac.GetSiteInfoHistoryPage)
+	rg.PUT("/siteinfo/rollback", ac.RollbackSiteInfo)
+	rg.GET("/setting/smtp", ac.GetSMTPConfig)
+	rg.PUT("/setting/smtp", ac.UpdateSMTPConfig)
+	rg.GET("/setting/privileges", ac.GetPrivilegesConfig)
+	rg.PUT("/setting/privileges", ac.UpdatePrivilegesConfig)
 }
 
 func RegisterVoteApi(r *gin.RouterGroup) {
@@ -63,11 +68,12 @@ func RegisterVoteApi(r *gin.RouterGroup) {
 func RegisterReportApi(r *gin.RouterGroup) {
 	// report
 	c := controller.NewReportController()
-	r.POST("/report", c.AddReport)
-	//管理员用接口
+	rg := r.Group("", middleware.AccessToken())
+	rg.POST("/report", c.AddReport)
+	//管理员用接口, the admins and the moderators scoped to the tags are checked in the service
 	ac := controller_admin.NewReportController()
-	r.GET("/reports/page", ac.ListReportPage)
-	r.PUT("/report", ac.Handle)
+	rg.GET("/reports/page", ac.ListReportPage)
+	rg.PUT("/report", ac.Handle)
 }
 
 func RegisterOtherApi(r *gin.RouterGroup) {
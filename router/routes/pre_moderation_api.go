@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterAdminPreModerationApi the pre-moderation rules and the queue of the pending posts, only for admin
+func RegisterAdminPreModerationApi(r *gin.RouterGroup) {
+	c := controller_admin.NewPreModerationController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/moderation/policy", c.GetPreModerationPolicy)
+	rg.PUT("/moderation/policy", c.UpdatePreModerationPolicy)
+	rg.GET("/moderation/pending/page", c.GetPendingPostPage)
+	rg.PUT("/moderation/pending/review", c.ReviewPendingPost)
+}
+
+// RegisterAdminSpamApi the spam scoring rules and the spam scores of the posts, only for admin
+func RegisterAdminSpamApi(r *gin.RouterGroup) {
+	c := controller_admin.NewSpamController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/moderation/spam/policy", c.GetSpamPolicy)
+	rg.PUT("/moderation/spam/policy", c.UpdateSpamPolicy)
+	rg.GET("/moderation/spam/score", c.GetSpamScore)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/lawyer/controller"
+	"github.com/lawyer/controller_admin"
 	"github.com/lawyer/middleware"
 )
 
@@ -12,6 +13,7 @@ func RegisterQuestionApi(rg *gin.RouterGroup) {
 	r := rg.Group("/question", middleware.AccessToken())
 	// question
 	r.GET("/info", c.GetQuestion)
+	r.GET("/view/trend", c.GetQuestionViewTrend)
 	r.GET("/invite", c.GetQuestionInviteUserInfo)
 	r.GET("/page", c.QuestionPage)
 	r.POST("/add", c.AddQuestion)
@@ -36,3 +38,11 @@ func RegisterQuestionApi(rg *gin.RouterGroup) {
 	r.PUT("/question/status", c.AdminUpdateQuestionStatus)
 	//r.GET("/page", c.AdminQuestionPage)
 }
+
+// RegisterAdminQuestionLifecycleApi the question lifecycle rules, only for admin
+func RegisterAdminQuestionLifecycleApi(r *gin.RouterGroup) {
+	c := controller_admin.NewQuestionLifecycleController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/question/lifecycle", c.GetQuestionLifecyclePolicy)
+	rg.PUT("/question/lifecycle", c.UpdateQuestionLifecyclePolicy)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterAdminReputationApi the reputation policy, ledger and recalculation, only for admin
+func RegisterAdminReputationApi(r *gin.RouterGroup) {
+	c := controller_admin.NewReputationController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/reputation/ledger", c.GetReputationLedger)
+	rg.POST("/reputation/recalculate", c.RecalculateReputation)
+	rg.GET("/reputation/policy", c.GetReputationPolicy)
+	rg.PUT("/reputation/policy", c.UpdateReputationPolicy)
+	rg.GET("/reputation/policy/versions", c.GetReputationPolicyVersionList)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterResponseTemplateApi the canned responses used when answering and commenting, need login
+func RegisterResponseTemplateApi(r *gin.RouterGroup) {
+	c := controller.NewResponseTemplateController()
+	rg := r.Group("/response", middleware.AccessToken())
+	rg.GET("/templates", c.GetTemplateList)
+	rg.POST("/template", c.AddTemplate)
+	rg.PUT("/template", c.UpdateTemplate)
+	rg.DELETE("/template", c.RemoveTemplate)
+	rg.GET("/template/render", c.RenderTemplate)
+}
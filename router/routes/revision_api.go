@@ -3,6 +3,9 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/lawyer/controller"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+	"github.com/lawyer/service"
 )
 
 // revision
@@ -10,7 +13,23 @@ func RegisterRevisionApi(r *gin.RouterGroup) {
 	c := controller.NewRevisionController(nil, nil)
 	r.GET("/revisions", c.GetRevisionList)
 	r.GET("/revisions/unreviewed", c.GetUnreviewedRevisionList)
-	r.PUT("/revisions/audit", c.RevisionAudit)
 	r.GET("/revisions/edit/check", c.CheckCanUpdateRevision)
 
 }
+
+// RegisterRevisionReviewApi the diff, the review and the rollback of the revisions, need login
+func RegisterRevisionReviewApi(r *gin.RouterGroup) {
+	c := controller.NewRevisionController(service.RevisionServicer, service.RankServicer)
+	rg := r.Group("/revisions", middleware.AccessToken())
+	rg.GET("/diff", c.GetRevisionDiff)
+	rg.PUT("/audit", c.RevisionAudit)
+	rg.POST("/rollback", c.RollbackRevision)
+}
+
+// RegisterAdminRevisionReviewApi the suggested edits review rules, only for admin
+func RegisterAdminRevisionReviewApi(r *gin.RouterGroup) {
+	c := controller_admin.NewRevisionReviewController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/revision/review", c.GetRevisionReviewPolicy)
+	rg.PUT("/revision/review", c.UpdateRevisionReviewPolicy)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller_admin"
+	"github.com/lawyer/middleware"
+)
+
+// RegisterAdminRoleApi custom roles and their powers, only for admin
+func RegisterAdminRoleApi(r *gin.RouterGroup) {
+	c := controller_admin.NewRoleController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/roles", c.GetRoleList)
+	rg.POST("/role", c.AddRole)
+	rg.PUT("/role", c.UpdateRole)
+	rg.DELETE("/role", c.RemoveRole)
+	rg.GET("/role/powers", c.GetRolePowerList)
+	rg.PUT("/role/powers", c.UpdateRolePowerList)
+	rg.GET("/powers", c.GetPowerList)
+	rg.GET("/user/scoped-roles", c.GetUserScopedRoleList)
+	rg.PUT("/user/scoped-role", c.SaveUserScopedRole)
+	rg.DELETE("/user/scoped-role", c.RemoveUserScopedRole)
+}
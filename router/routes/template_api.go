@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lawyer/controller"
+)
+
+// RegisterTemplateApi the server side rendered pages, sitemap and feeds for the crawlers
+func RegisterTemplateApi(r *gin.RouterGroup) {
+	c := controller.NewTemplateController()
+	r.GET("/", c.Index)
+	r.GET("/questions", c.QuestionList)
+	r.GET("/questions/:id", c.QuestionInfo)
+	r.GET("/questions/:id/:title", c.QuestionInfo)
+	r.GET("/questions/:id/:title/:answerid", c.QuestionInfo)
+	r.GET("/tags", c.TagList)
+	r.GET("/tags/:tag", c.TagInfo)
+	r.GET("/users/:username", c.UserInfo)
+
+	r.GET("/sitemap.xml", c.Sitemap)
+	r.GET("/sitemap/:page", c.SitemapPage)
+
+	// format: rss or atom
+	r.GET("/feeds/:format", c.QuestionFeed)
+	r.GET("/feeds/:format/tags/:tag", c.TagFeed)
+	r.GET("/feeds/:format/users/:username", c.UserFeed)
+}
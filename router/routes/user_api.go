@@ -16,7 +16,7 @@ func RegisterUserApi(r *gin.RouterGroup) {
 	rg.GET("/register/captcha", c.UserRegisterCaptcha)     //done
 	rg.POST("/email/verification", c.UserVerifyEmail)      //done
 	rg.POST("/login/email", c.UserEmailLogin)              //done
-	rg.GET("/logout", c.UserLogout)                        //本地把token删除就行了，其实服务端不需要干啥 done
+	rg.GET("/logout", c.UserLogout)                        //删除当前token的登录会话 done
 	rg.GET("/personal/info", c.GetOtherUserInfoByUsername) //done
 	rg.GET("/info/search", c.SearchUserListByName)         //done
 	/*
@@ -30,6 +30,20 @@ func RegisterUserApi(r *gin.RouterGroup) {
 	rg.POST("/password/reset", c.RetrievePassWord)          //done
 	rg.POST("/password/replacement", c.UserReplacePassWord) //done
 
+	// the second step of the login for the users with two-factor authentication
+	tc := controller.NewUserTwoFactorController()
+	rg.POST("/login/two-factor", tc.TwoFactorLogin)
+	rg.POST("/login/two-factor/setup", tc.TwoFactorLoginSetup)
+
+	// the passwordless login by the link or the one-time code sent to the email
+	mc := controller.NewUserMagicLinkController()
+	rg.POST("/login/magic-link", mc.SendMagicLink)
+	rg.POST("/login/magic-link/verification", mc.MagicLinkLogin)
+
+	sc := controller.NewUserSessionController()
+	rg.POST("/token/refresh", sc.RefreshToken)
+	rg.POST("/login/exchange", sc.ExchangeLogin)
+
 	loginRoute := rg.Group("", middleware.AccessToken())
 	loginRoute.PUT("/update/info", c.UserUpdateInfo)         //need login done
 	loginRoute.GET("/getUserInfo", c.GetUserInfoByUserID)    //need login done
@@ -43,6 +57,22 @@ func RegisterUserApi(r *gin.RouterGroup) {
 
 	loginRoute.GET("/action/record", c.ActionRecord) //need login    done
 
+	loginRoute.GET("/two-factor", tc.GetTwoFactorStatus)
+	loginRoute.POST("/two-factor/enroll", tc.EnrollTwoFactor)
+	loginRoute.POST("/two-factor/confirm", tc.ConfirmTwoFactor)
+	loginRoute.DELETE("/two-factor", tc.DisableTwoFactor)
+	loginRoute.POST("/two-factor/recovery-codes", tc.RegenerateRecoveryCodes)
+
+	loginRoute.GET("/sessions", sc.GetSessionList)
+	loginRoute.DELETE("/session", sc.RevokeSession)
+	loginRoute.DELETE("/sessions", sc.RevokeOtherSessions)
+
+	dc := controller.NewUserDataController()
+	loginRoute.GET("/data/export", dc.ExportUserData)
+	loginRoute.GET("/deletion", dc.GetUserDeletion)
+	loginRoute.POST("/deletion", dc.RequestUserDeletion)
+	loginRoute.DELETE("/deletion", dc.CancelUserDeletion)
+
 	//todo
 	// user
 	//rg.POST("/user/email/change/code", middleware.BanAPIForUserCenter, c.UserChangeEmailSendCode)//need login
@@ -70,3 +100,28 @@ func RegisterAdminUserApi(r *gin.RouterGroup) {
 	r.POST("/users", ac.AddUsers)
 	r.PUT("/user/password", ac.UpdateUserPassword)
 }
+
+// RegisterAdminTwoFactorApi the two-factor authentication policy and reset, only for admin
+func RegisterAdminTwoFactorApi(r *gin.RouterGroup) {
+	c := controller_admin.NewUserTwoFactorController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.GET("/user/two-factor/policy", c.GetTwoFactorPolicy)
+	rg.PUT("/user/two-factor/policy", c.UpdateTwoFactorPolicy)
+	rg.DELETE("/user/two-factor", c.ResetTwoFactor)
+}
+
+// RegisterAdminUserSessionApi force logout the users, only for admin
+func RegisterAdminUserSessionApi(r *gin.RouterGroup) {
+	c := controller_admin.NewUserSessionController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.DELETE("/user/sessions", c.ForceLogoutUser)
+}
+
+// RegisterAdminUserImportApi the bulk user import and export in csv, only for admin
+func RegisterAdminUserImportApi(r *gin.RouterGroup) {
+	c := controller_admin.NewUserImportController()
+	rg := r.Group("/admin", middleware.AccessToken(), middleware.AdminAccess())
+	rg.POST("/users/import", c.ImportUsers)
+	rg.GET("/users/import", c.GetUserImportJob)
+	rg.GET("/users/export", c.ExportUsers)
+}
@@ -39,6 +39,9 @@ func NewActivityCommon() *ActivityCommon {
 
 // HandleActivity handle activity message
 func (ac *ActivityCommon) HandleActivity(ctx context.Context, msg *schema.ActivityMsg) error {
+	if msg.HasRecorded {
+		return nil
+	}
 	activityType, err := repoCommon.NewActivityRepo().GetActivityTypeByConfigKey(ctx, string(msg.ActivityTypeKey))
 	if err != nil {
 		log.Errorf("error getting activity type %s, activity type is %d", err, activityType)
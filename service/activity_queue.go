@@ -13,29 +13,32 @@ type ActivityQueueService interface {
 }
 
 type activityQueueService struct {
-	Queue   chan *schema.ActivityMsg
-	Handler func(ctx context.Context, msg *schema.ActivityMsg) error
+	Queue    chan *schema.ActivityMsg
+	Handlers []func(ctx context.Context, msg *schema.ActivityMsg) error
 }
 
 func (ns *activityQueueService) Send(ctx context.Context, msg *schema.ActivityMsg) {
 	ns.Queue <- msg
 }
 
+// RegisterHandler register the handler, every activity will be handled by all handlers in the order of registration
 func (ns *activityQueueService) RegisterHandler(
 	handler func(ctx context.Context, msg *schema.ActivityMsg) error) {
-	ns.Handler = handler
+	ns.Handlers = append(ns.Handlers, handler)
 }
 
 func (ns *activityQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
 			glog.Slog.Debugf("received activity %+v", msg)
-			if ns.Handler == nil {
+			if len(ns.Handlers) == 0 {
 				glog.Slog.Warnf("no handler for activity")
 				continue
 			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				glog.Slog.Error(err)
+			for _, handler := range ns.Handlers {
+				if err := handler(context.Background(), msg); err != nil {
+					glog.Slog.Error(err)
+				}
 			}
 		}
 	}()
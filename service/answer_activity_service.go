@@ -6,6 +6,7 @@ import (
 	glog "github.com/lawyer/commons/logger"
 	"github.com/lawyer/commons/schema"
 	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/converter"
 	"github.com/lawyer/repo"
 )
 
@@ -32,7 +33,20 @@ func (as *AnswerActivityService) AcceptAnswer(ctx context.Context,
 		questionObjID, questionUserID)
 	operationInfo := as.createAcceptAnswerOperationInfo(ctx, loginUserID,
 		answerObjID, questionObjID, questionUserID, answerUserID, isSelf)
-	return repo.AnswerActivityRepo.SaveAcceptAnswerActivity(ctx, operationInfo)
+	if err = repo.AnswerActivityRepo.SaveAcceptAnswerActivity(ctx, operationInfo); err != nil {
+		return err
+	}
+	if !isSelf {
+		// the activity is saved above, only notify the other activity handlers such as badges
+		ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
+			UserID:          answerUserID,
+			TriggerUserID:   converter.StringToInt64(loginUserID),
+			ObjectID:        answerObjID,
+			ActivityTypeKey: constant.ActAnswerAccepted,
+			HasRecorded:     true,
+		})
+	}
+	return nil
 }
 
 // CancelAcceptAnswer cancel accept answer change activity
@@ -65,6 +79,11 @@ func (as *AnswerActivityService) getActivities(ctx context.Context, op *schema.A
 	activities []*schema.AcceptAnswerActivity) {
 	activities = make([]*schema.AcceptAnswerActivity, 0)
 
+	policy, err := ReputationPolicyServicer.GetReputationPolicy(ctx)
+	if err != nil {
+		glog.Slog.Warnf("get reputation policy error: %v", err)
+		policy = &schema.ReputationPolicy{}
+	}
 	for _, action := range []string{constant.AnswerAccept, constant.AnswerAccepted} {
 		t := &schema.AcceptAnswerActivity{}
 		cfg, err := utils.GetConfigByKey(ctx, action)
@@ -72,7 +91,8 @@ func (as *AnswerActivityService) getActivities(ctx context.Context, op *schema.A
 			glog.Slog.Warnf("get config by key error: %v", err)
 			continue
 		}
-		t.ActivityType, t.Rank = cfg.ID, cfg.GetIntValue()
+		t.ActivityType, t.RuleVersion = cfg.ID, policy.Version
+		t.Rank = ReputationPolicyServicer.GetActivityRank(ctx, policy, action, cfg.GetIntValue(), op.AnswerObjectID)
 
 		if action == constant.AnswerAccept {
 			t.ActivityUserID = op.QuestionUserID
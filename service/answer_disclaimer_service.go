@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// AnswerDisclaimerRepo answer disclaimer repository
+type AnswerDisclaimerRepo interface {
+	AddAck(ctx context.Context, ack *entity.AnswerDisclaimerAck) (err error)
+	GetAckByAnswerID(ctx context.Context, answerID string) (ack *entity.AnswerDisclaimerAck, exist bool, err error)
+}
+
+// AnswerDisclaimerService the disclaimer blocks shown below the answers and the acknowledgement of the answerers
+type AnswerDisclaimerService struct {
+}
+
+// NewAnswerDisclaimerService new answer disclaimer service
+func NewAnswerDisclaimerService() *AnswerDisclaimerService {
+	return &AnswerDisclaimerService{}
+}
+
+// GetAnswerDisclaimerPolicy get the answer disclaimer policy
+func (ds *AnswerDisclaimerService) GetAnswerDisclaimerPolicy(ctx context.Context) (
+	policy *schema.AnswerDisclaimerPolicy, err error) {
+	policy = &schema.AnswerDisclaimerPolicy{
+		Tags: make(map[string]*schema.AnswerDisclaimer),
+	}
+	cfg, err := utils.GetConfigByKey(ctx, entity.AnswerDisclaimerConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Value) == 0 {
+		return policy, nil
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+		return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
+	return policy, nil
+}
+
+// UpdateAnswerDisclaimerPolicy update the answer disclaimer policy, the version is increased
+func (ds *AnswerDisclaimerService) UpdateAnswerDisclaimerPolicy(ctx context.Context,
+	req *schema.UpdateAnswerDisclaimerPolicyReq) (err error) {
+	if len(req.Tags) > 0 {
+		tagIDs := make([]string, 0, len(req.Tags))
+		for tagID := range req.Tags {
+			tagIDs = append(tagIDs, tagID)
+		}
+		tagList, err := repo.TagRepo.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return err
+		}
+		if len(tagList) != len(tagIDs) {
+			return errors.BadRequest(reason.TagNotFound)
+		}
+	}
+
+	oldPolicy, err := ds.GetAnswerDisclaimerPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &schema.AnswerDisclaimerPolicy{
+		Version:                oldPolicy.Version + 1,
+		Enabled:                req.Enabled,
+		RequireAcknowledgement: req.RequireAcknowledgement,
+		Default:                req.Default,
+		Tags:                   req.Tags,
+	}
+	content, _ := json.Marshal(policy)
+	if err = utils.UpdateConfig(ctx, entity.AnswerDisclaimerConfigKey, string(content)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionAnswerDisclaimer,
+		ObjectType: "answer_disclaimer",
+		ObjectID:   entity.AnswerDisclaimerConfigKey,
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// GetAnswerDisclaimer get the disclaimer shown in the answer form of the question
+func (ds *AnswerDisclaimerService) GetAnswerDisclaimer(ctx context.Context, req *schema.GetAnswerDisclaimerReq) (
+	resp *schema.GetAnswerDisclaimerResp, err error) {
+	policy, disclaimer, _, err := ds.getQuestionDisclaimer(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetAnswerDisclaimerResp{}
+	if disclaimer != nil {
+		resp.Disclaimer = ds.formatDisclaimer(policy, disclaimer)
+		resp.RequireAcknowledgement = policy.RequireAcknowledgement
+	}
+	return resp, nil
+}
+
+// GetQuestionDisclaimer get the disclaimer rendered in the answers of the question, nil if there is none
+func (ds *AnswerDisclaimerService) GetQuestionDisclaimer(ctx context.Context, questionID string) (
+	info *schema.AnswerDisclaimerInfo) {
+	policy, disclaimer, _, err := ds.getQuestionDisclaimer(ctx, questionID)
+	if err != nil {
+		glog.Slog.Errorf("get disclaimer of question %s failed: %v", questionID, err)
+		return nil
+	}
+	if disclaimer == nil {
+		return nil
+	}
+	return ds.formatDisclaimer(policy, disclaimer)
+}
+
+// CheckAcknowledgement the answerer must acknowledge the disclaimer of the question if it is required
+func (ds *AnswerDisclaimerService) CheckAcknowledgement(ctx context.Context, questionID string, acknowledged bool) (
+	err error) {
+	if acknowledged {
+		return nil
+	}
+	policy, disclaimer, _, err := ds.getQuestionDisclaimer(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if disclaimer != nil && policy.RequireAcknowledgement {
+		return errors.BadRequest(reason.AnswerDisclaimerNotAcknowledged)
+	}
+	return nil
+}
+
+// RecordAcknowledgement record the disclaimer the answer is posted with and whether the answerer acknowledged it
+func (ds *AnswerDisclaimerService) RecordAcknowledgement(ctx context.Context, questionID, answerID, userID string,
+	acknowledged bool) {
+	policy, disclaimer, tagID, err := ds.getQuestionDisclaimer(ctx, questionID)
+	if err != nil {
+		glog.Slog.Errorf("get disclaimer of question %s failed: %v", questionID, err)
+		return
+	}
+	if disclaimer == nil {
+		return
+	}
+	err = repo.AnswerDisclaimerRepo.AddAck(ctx, &entity.AnswerDisclaimerAck{
+		AnswerID:      answerID,
+		UserID:        userID,
+		PolicyVersion: policy.Version,
+		TagID:         tagID,
+		Content:       disclaimer.Content,
+		Acknowledged:  acknowledged,
+	})
+	if err != nil {
+		glog.Slog.Error(err)
+	}
+}
+
+// GetAnswerDisclaimerAck get the disclaimer acknowledgement of the answer
+func (ds *AnswerDisclaimerService) GetAnswerDisclaimerAck(ctx context.Context, req *schema.GetAnswerDisclaimerAckReq) (
+	resp *schema.GetAnswerDisclaimerAckResp, err error) {
+	ack, exist, err := repo.AnswerDisclaimerRepo.GetAckByAnswerID(ctx, req.AnswerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.AnswerDisclaimerAckNotFound)
+	}
+	resp = &schema.GetAnswerDisclaimerAckResp{
+		AnswerID:      req.AnswerID,
+		PolicyVersion: ack.PolicyVersion,
+		Content:       ack.Content,
+		Acknowledged:  ack.Acknowledged,
+		CreatedAt:     ack.CreatedAt.Unix(),
+	}
+	if ack.TagID != "0" {
+		resp.TagID = ack.TagID
+	}
+	userInfo, exist, err := UserCommonServicer.GetUserBasicInfoByID(ctx, ack.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		resp.UserInfo = userInfo
+	}
+	return resp, nil
+}
+
+// getQuestionDisclaimer get the policy and the disclaimer of the question with its tags
+func (ds *AnswerDisclaimerService) getQuestionDisclaimer(ctx context.Context, questionID string) (
+	policy *schema.AnswerDisclaimerPolicy, disclaimer *schema.AnswerDisclaimer, tagID string, err error) {
+	policy, err = ds.GetAnswerDisclaimerPolicy(ctx)
+	if err != nil || !policy.Enabled {
+		return policy, nil, "", err
+	}
+	tagRelList, err := repo.TagRelRepo.GetObjectTagRelList(ctx, questionID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	tagIDs := make([]string, 0, len(tagRelList))
+	for _, rel := range tagRelList {
+		tagIDs = append(tagIDs, rel.TagID)
+	}
+	disclaimer, tagID = policy.GetDisclaimer(tagIDs)
+	return policy, disclaimer, tagID, nil
+}
+
+func (ds *AnswerDisclaimerService) formatDisclaimer(policy *schema.AnswerDisclaimerPolicy,
+	disclaimer *schema.AnswerDisclaimer) *schema.AnswerDisclaimerInfo {
+	return &schema.AnswerDisclaimerInfo{
+		Title:   disclaimer.Title,
+		Content: disclaimer.Content,
+		HTML:    converter.Markdown2HTML(disclaimer.Content),
+		Version: policy.Version,
+	}
+}
@@ -39,11 +39,7 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 	if answerInfo.Status == entity.AnswerStatusDeleted {
 		return nil
 	}
-	roleID, err := UserRoleRelServicer.GetUserRole(ctx, req.UserID)
-	if err != nil {
-		return err
-	}
-	if roleID != RoleAdminID && roleID != RoleModeratorID {
+	if !RankServicer.CheckUserPower(ctx, req.UserID, permission.AnswerDelete) {
 		if answerInfo.UserID != req.UserID {
 			return errors.BadRequest(reason.AnswerCannotDeleted)
 		}
@@ -142,10 +138,14 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	if !exist {
 		return "", errors.BadRequest(reason.QuestionNotFound)
 	}
-	if questionInfo.Status == entity.QuestionStatusClosed || questionInfo.Status == entity.QuestionStatusDeleted {
+	if questionInfo.Status == entity.QuestionStatusClosed || questionInfo.Status == entity.QuestionStatusDeleted ||
+		questionInfo.Status == entity.QuestionStatusPending {
 		err = errors.BadRequest(reason.AnswerCannotAddByClosedQuestion)
 		return "", err
 	}
+	if err = AnswerDisclaimerServicer.CheckAcknowledgement(ctx, req.QuestionID, req.DisclaimerAcknowledged); err != nil {
+		return "", err
+	}
 	insertData := new(entity.Answer)
 	insertData.UserID = req.UserID
 	insertData.OriginalText = req.Content
@@ -155,59 +155,101 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	insertData.RevisionID = "0"
 	insertData.LastEditUserID = "0"
 	insertData.Status = entity.AnswerStatusAvailable
+	spamResult := SpamServicer.Score(ctx, &schema.SpamCheckReq{
+		UserID:     req.UserID,
+		ObjectType: constant.AnswerObjectType,
+		ObjectID:   req.QuestionID,
+		Content:    req.Content,
+	})
+	if spamResult.Hold || PreModerationServicer.NeedPreModeration(ctx, req.UserID, req.QuestionID) {
+		insertData.Status = entity.AnswerStatusPending
+	}
 	//insertData.UpdatedAt = now
 	if err = repo.AnswerRepo.AddAnswer(ctx, insertData); err != nil {
 		return "", err
 	}
-	err = QuestionCommonServicer.UpdateAnswerCount(ctx, req.QuestionID)
+	SpamServicer.Record(ctx, insertData.ID, constant.AnswerObjectType, insertData.UserID, spamResult)
+	AnswerDisclaimerServicer.RecordAcknowledgement(ctx, req.QuestionID, insertData.ID, req.UserID,
+		req.DisclaimerAcknowledged)
+
+	revisionDTO := &schema.AddRevisionDTO{
+		UserID:   insertData.UserID,
+		ObjectID: insertData.ID,
+		Title:    "",
+	}
+	infoJSON, _ := json.Marshal(insertData)
+	revisionDTO.Content = string(infoJSON)
+	revisionID, err := RevisionComServicer.AddRevision(ctx, revisionDTO, true)
+	if err != nil {
+		return insertData.ID, err
+	}
+	ResponseTemplateServicer.RecordTemplateUse(ctx, req.TemplateID, req.UserID, insertData.ID, constant.AnswerObjectType)
+
+	// the pending answer is published when it is approved
+	if insertData.Status == entity.AnswerStatusAvailable {
+		if err = as.publishAnswer(ctx, questionInfo, insertData, revisionID); err != nil {
+			return insertData.ID, err
+		}
+	}
+	return insertData.ID, nil
+}
+
+// publishAnswer update the counts of the question and the answerer, notify the asker and send the activities
+func (as *AnswerService) publishAnswer(ctx context.Context, questionInfo *entity.Question, answer *entity.Answer,
+	revisionID string) (err error) {
+	err = QuestionCommonServicer.UpdateAnswerCount(ctx, questionInfo.ID)
 	if err != nil {
 		glog.Slog.Error("IncreaseAnswerCount error", err.Error())
 	}
-	err = QuestionCommonServicer.UpdateLastAnswer(ctx, req.QuestionID, uid.DeShortID(insertData.ID))
+	err = QuestionCommonServicer.UpdateLastAnswer(ctx, questionInfo.ID, uid.DeShortID(answer.ID))
 	if err != nil {
 		glog.Slog.Error("UpdateLastAnswer error", err.Error())
 	}
-	err = QuestionCommonServicer.UpdatePostTime(ctx, req.QuestionID)
+	err = QuestionCommonServicer.UpdatePostTime(ctx, questionInfo.ID)
 	if err != nil {
-		return insertData.ID, err
+		return err
 	}
-	userAnswerCount, err := repo.AnswerRepo.GetCountByUserID(ctx, req.UserID)
+	userAnswerCount, err := repo.AnswerRepo.GetCountByUserID(ctx, answer.UserID)
 	if err != nil {
 		glog.Slog.Error("GetCountByUserID error", err.Error())
 	}
-	err = UserCommonServicer.UpdateAnswerCount(ctx, req.UserID, int(userAnswerCount))
+	err = UserCommonServicer.UpdateAnswerCount(ctx, answer.UserID, int(userAnswerCount))
 	if err != nil {
 		glog.Slog.Error("user IncreaseAnswerCount error", err.Error())
 	}
 
-	revisionDTO := &schema.AddRevisionDTO{
-		UserID:   insertData.UserID,
-		ObjectID: insertData.ID,
-		Title:    "",
-	}
-	infoJSON, _ := json.Marshal(insertData)
-	revisionDTO.Content = string(infoJSON)
-	revisionID, err := RevisionComServicer.AddRevision(ctx, revisionDTO, true)
-	if err != nil {
-		return insertData.ID, err
-	}
-	as.notificationAnswerTheQuestion(ctx, questionInfo.UserID, questionInfo.ID, insertData.ID, req.UserID, questionInfo.Title,
-		insertData.OriginalText)
+	as.notificationAnswerTheQuestion(ctx, questionInfo.UserID, questionInfo.ID, answer.ID, answer.UserID, questionInfo.Title,
+		answer.OriginalText)
 
 	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
-		UserID:           insertData.UserID,
-		ObjectID:         insertData.ID,
-		OriginalObjectID: insertData.ID,
+		UserID:           answer.UserID,
+		ObjectID:         answer.ID,
+		OriginalObjectID: answer.ID,
 		ActivityTypeKey:  constant.ActAnswerAnswered,
 		RevisionID:       revisionID,
 	})
 	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
-		UserID:           insertData.UserID,
-		ObjectID:         insertData.ID,
+		UserID:           answer.UserID,
+		ObjectID:         answer.ID,
 		OriginalObjectID: questionInfo.ID,
 		ActivityTypeKey:  constant.ActQuestionAnswered,
 	})
-	return insertData.ID, nil
+	return nil
+}
+
+// PublishPendingAnswer publish the answer approved by the moderator
+func (as *AnswerService) PublishPendingAnswer(ctx context.Context, answer *entity.Answer) (err error) {
+	questionInfo, exist, err := repo.QuestionRepo.GetQuestion(ctx, answer.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	if err = repo.AnswerRepo.UpdateAnswerStatus(ctx, answer.ID, entity.AnswerStatusAvailable); err != nil {
+		return err
+	}
+	return as.publishAnswer(ctx, questionInfo, answer, answer.RevisionID)
 }
 
 func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq) (string, error) {
@@ -377,7 +419,12 @@ func (as *AnswerService) Get(ctx context.Context, answerID, loginUserID string)
 	if err != nil {
 		return nil, nil, has, err
 	}
-	info := as.ShowFormat(ctx, answerInfo)
+	// If the answer is pending, only the moderators and the author can view it
+	if answerInfo.Status == entity.AnswerStatusPending && answerInfo.UserID != loginUserID &&
+		!PreModerationServicer.CanViewPendingPost(ctx, loginUserID, answerInfo.ID) {
+		return nil, nil, false, nil
+	}
+	info := as.ShowFormat(ctx, answerInfo, AnswerDisclaimerServicer.GetQuestionDisclaimer(ctx, answerInfo.QuestionID))
 	// todo questionFunc
 	questionInfo, err := QuestionCommonServicer.Info(ctx, answerInfo.QuestionID, loginUserID)
 	if err != nil {
@@ -439,6 +486,14 @@ func (as *AnswerService) AdminSetAnswerStatus(ctx context.Context, req *schema.A
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionAnswerUpdateStatus,
+		ObjectType: constant.AnswerObjectType,
+		ObjectID:   answerInfo.ID,
+		Before:     &schema.ObjectStatusSnapshot{Status: answerInfo.Status},
+		After:      &schema.ObjectStatusSnapshot{Status: setStatus},
+	})
 
 	if setStatus == entity.AnswerStatusDeleted {
 		// #2372 In order to simplify the process and complexity, as well as to consider if it is in-house,
@@ -503,8 +558,14 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 	list := make([]*schema.AnswerInfo, 0)
 	objectIDs := make([]string, 0)
 	userIDs := make([]string, 0)
+	disclaimers := make(map[string]*schema.AnswerDisclaimerInfo)
 	for _, info := range answers {
-		item := as.ShowFormat(ctx, info)
+		disclaimer, ok := disclaimers[info.QuestionID]
+		if !ok {
+			disclaimer = AnswerDisclaimerServicer.GetQuestionDisclaimer(ctx, info.QuestionID)
+			disclaimers[info.QuestionID] = disclaimer
+		}
+		item := as.ShowFormat(ctx, info, disclaimer)
 		list = append(list, item)
 		objectIDs = append(objectIDs, info.ID)
 		userIDs = append(userIDs, info.UserID, info.LastEditUserID)
@@ -540,8 +601,13 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 	return list, nil
 }
 
-func (as *AnswerService) ShowFormat(ctx context.Context, data *entity.Answer) *schema.AnswerInfo {
-	return AnswerCommonServicer.ShowFormat(ctx, data)
+// ShowFormat format the answer with the disclaimer of its question, the disclaimer is got once by the caller
+// for all the answers of the question
+func (as *AnswerService) ShowFormat(ctx context.Context, data *entity.Answer,
+	disclaimer *schema.AnswerDisclaimerInfo) *schema.AnswerInfo {
+	info := AnswerCommonServicer.ShowFormat(ctx, data)
+	info.Disclaimer = disclaimer
+	return info
 }
 
 func (as *AnswerService) notificationUpdateAnswer(ctx context.Context, questionUserID, answerID, answerUserID string) {
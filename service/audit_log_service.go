@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/commons/utils/pager"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/repo"
+)
+
+const (
+	// auditLogExportLimit the max number of audit logs exported at once
+	auditLogExportLimit = 10000
+)
+
+// AuditLogRepo audit log repository
+type AuditLogRepo interface {
+	AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error)
+	GetAuditLogPage(ctx context.Context, cond *schema.GetAuditLogPageReq) (
+		auditLogList []*entity.AuditLog, total int64, err error)
+	GetAuditLogList(ctx context.Context, cond *schema.GetAuditLogPageReq, limit int) (
+		auditLogList []*entity.AuditLog, err error)
+}
+
+// AuditLogService audit log service
+type AuditLogService struct {
+}
+
+// NewAuditLogService new audit log service
+func NewAuditLogService() *AuditLogService {
+	return &AuditLogService{}
+}
+
+// Record append the action to the audit log, the failure will not interrupt the action
+func (as *AuditLogService) Record(ctx context.Context, msg *schema.AuditLogMsg) {
+	auditLog := &entity.AuditLog{
+		UserID:     msg.UserID,
+		Action:     msg.Action,
+		ObjectType: msg.ObjectType,
+		ObjectID:   msg.ObjectID,
+		Before:     as.marshalSnapshot(msg.Before),
+		After:      as.marshalSnapshot(msg.After),
+		IP:         utils.GetClientIPByCtx(ctx),
+		TraceID:    utils.GetTraceIDByCtx(ctx),
+	}
+	if err := repo.AuditLogRepo.AddAuditLog(ctx, auditLog); err != nil {
+		glog.Slog.Errorf("add audit log %s of %s failed: %v", msg.Action, msg.ObjectID, err)
+	}
+}
+
+// GetAuditLogPage get audit log page
+func (as *AuditLogService) GetAuditLogPage(ctx context.Context, req *schema.GetAuditLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	auditLogList, total, err := repo.AuditLogRepo.GetAuditLogPage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(auditLogList))
+	for _, auditLog := range auditLogList {
+		userIDs = append(userIDs, auditLog.UserID)
+	}
+	userInfoMapping, err := UserCommonServicer.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.GetAuditLogResp, 0, len(auditLogList))
+	for _, auditLog := range auditLogList {
+		resp = append(resp, &schema.GetAuditLogResp{
+			ID:         auditLog.ID,
+			CreatedAt:  auditLog.CreatedAt.Unix(),
+			UserInfo:   userInfoMapping[auditLog.UserID],
+			Action:     auditLog.Action,
+			ObjectType: auditLog.ObjectType,
+			ObjectID:   auditLog.ObjectID,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			IP:         auditLog.IP,
+			TraceID:    auditLog.TraceID,
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// ExportAuditLog export the audit logs matching the filter as csv
+func (as *AuditLogService) ExportAuditLog(ctx context.Context, req *schema.GetAuditLogPageReq) (
+	content []byte, err error) {
+	auditLogList, err := repo.AuditLogRepo.GetAuditLogList(ctx, req, auditLogExportLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	_ = writer.Write([]string{"id", "created_at", "user_id", "action", "object_type", "object_id",
+		"before", "after", "ip", "trace_id"})
+	for _, auditLog := range auditLogList {
+		_ = writer.Write(converter.EscapeCSVFormula([]string{
+			fmt.Sprintf("%d", auditLog.ID),
+			auditLog.CreatedAt.Format(time.RFC3339),
+			auditLog.UserID,
+			auditLog.Action,
+			auditLog.ObjectType,
+			auditLog.ObjectID,
+			auditLog.Before,
+			auditLog.After,
+			auditLog.IP,
+			auditLog.TraceID,
+		}))
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (as *AuditLogService) marshalSnapshot(snapshot interface{}) string {
+	if snapshot == nil {
+		return ""
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		glog.Slog.Error(err)
+		return ""
+	}
+	return string(data)
+}
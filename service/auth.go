@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"github.com/lawyer/commons/entity"
-	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/repo"
 )
 
@@ -15,6 +14,16 @@ type IAuthRepo interface {
 	SetUserCacheInfoByToken(ctx context.Context, accessToken string, userInfo *entity.UserCacheInfo) (err error)
 	RemoveUserCacheInfoByToken(ctx context.Context, accessToken string) (err error)
 	SetUserRegisterInfoByEmail(ctx context.Context, user *entity.User) (err error)
+	SetUserSession(ctx context.Context, session *entity.UserSession) (err error)
+	GetUserSession(ctx context.Context, sessionID string) (session *entity.UserSession, exist bool, err error)
+	GetUserSessionList(ctx context.Context, userID string) (sessionList []*entity.UserSession, err error)
+	TouchUserSession(ctx context.Context, sessionID, ip string, lastSeenAt int64) (err error)
+	RotateUserSessionRefreshToken(ctx context.Context, sessionID, oldHash, newHash string) (
+		session *entity.UserSession, ok bool, err error)
+	RemoveUserSession(ctx context.Context, userID, sessionID string) (err error)
+	RemoveUserSessions(ctx context.Context, userID, exceptSessionID string) (err error)
+	SetLoginExchange(ctx context.Context, code, content string) (err error)
+	UseLoginExchange(ctx context.Context, code string) (content string, err error)
 }
 
 // AuthServicer kit service
@@ -62,18 +71,19 @@ func (as *AuthService) GetUserCacheInfo(ctx context.Context, accessToken string)
 }
 
 // visit token 指向access token， acc token指向userCacheInfo
+// the refresh token of the created login session is returned with the access token
 func (as *AuthService) SetUserCacheInfo(ctx context.Context, userInfo *entity.UserCacheInfo) (
-	accessToken string, err error) {
+	accessToken, refreshToken string, err error) {
 	//accessToken = token.GenerateToken()
-	accessToken, err = utils.CreateToken(userInfo.UserName, userInfo.UserID, userInfo.RoleID)
+	accessToken, refreshToken, err = as.CreateSession(ctx, userInfo.UserID, userInfo.UserName, userInfo.RoleID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	err = as.r.SetUserCacheInfoByToken(ctx, accessToken, userInfo)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return accessToken, err
+	return accessToken, refreshToken, err
 }
 
 func (as *AuthService) SetUserRegisterInfoByEmail(ctx context.Context, userInfo *entity.User) error {
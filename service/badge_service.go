@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/day"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// BadgeRepo badge repository
+type BadgeRepo interface {
+	AddBadge(ctx context.Context, badge *entity.Badge) (err error)
+	UpdateBadge(ctx context.Context, badge *entity.Badge) (err error)
+	GetBadge(ctx context.Context, badgeID int) (badge *entity.Badge, exist bool, err error)
+	GetBadgeList(ctx context.Context, status int) (badgeList []*entity.Badge, err error)
+	GetEnabledBadgeListByRuleTypes(ctx context.Context, ruleTypes []string) (badgeList []*entity.Badge, err error)
+	AddBadgeAward(ctx context.Context, award *entity.BadgeAward) (added bool, err error)
+	ExistBadgeAward(ctx context.Context, userID string, badgeID int, tagID string) (exist bool, err error)
+	GetUserBadgeAwardList(ctx context.Context, userID string) (awardList []*entity.BadgeAward, err error)
+	CountBadgeAwardGroupByBadge(ctx context.Context) (counts map[int]int64, err error)
+	CountAcceptedAnswers(ctx context.Context, userID, tagID string) (count int64, err error)
+	CountHelpfulAnswers(ctx context.Context, userID, tagID string) (count int64, err error)
+	CountUpvotes(ctx context.Context, userID, tagID string, activityTypes []int) (count int64, err error)
+	GetAnswerCreatedAtList(ctx context.Context, userID string, after time.Time) (createdAtList []time.Time, err error)
+}
+
+// badgeRuleTypesMapping the activities which may make the user reach the badge rules
+var badgeRuleTypesMapping = map[constant.ActivityTypeKey][]string{
+	constant.ActAnswerAccepted:  {entity.BadgeRuleAcceptedAnswers, entity.BadgeRuleHelpfulAnswers},
+	constant.ActAnswerVotedUp:   {entity.BadgeRuleUpvotes, entity.BadgeRuleHelpfulAnswers},
+	constant.ActQuestionVotedUp: {entity.BadgeRuleUpvotes},
+	constant.ActAnswerAnswered:  {entity.BadgeRuleAnswerStreak},
+}
+
+// BadgeService badge service
+type BadgeService struct {
+}
+
+// NewBadgeService new badge service, the badges are awarded by handling the activities
+func NewBadgeService() *BadgeService {
+	bs := &BadgeService{}
+	ActivityQueueServicer.RegisterHandler(bs.HandleActivity)
+	return bs
+}
+
+// HandleActivity check the badge rules related to the activity and award the badges the user reached
+func (bs *BadgeService) HandleActivity(ctx context.Context, msg *schema.ActivityMsg) error {
+	ruleTypes, ok := badgeRuleTypesMapping[msg.ActivityTypeKey]
+	if !ok || len(msg.UserID) == 0 {
+		return nil
+	}
+	badgeList, err := repo.BadgeRepo.GetEnabledBadgeListByRuleTypes(ctx, ruleTypes)
+	if err != nil || len(badgeList) == 0 {
+		return err
+	}
+
+	var objectTagIDs []string
+	for _, badge := range badgeList {
+		tagIDs := []string{"0"}
+		if badge.PerTag {
+			if objectTagIDs == nil {
+				objectTagIDs, err = ObjServicer.GetTagIDs(ctx, msg.ObjectID)
+				if err != nil {
+					glog.Slog.Errorf("get tags of object %s failed: %v", msg.ObjectID, err)
+					objectTagIDs = make([]string, 0)
+				}
+			}
+			tagIDs = objectTagIDs
+		} else if len(badge.TagID) > 0 {
+			tagIDs = []string{badge.TagID}
+		}
+		for _, tagID := range tagIDs {
+			if err = bs.checkAndAward(ctx, badge, msg.UserID, tagID, msg.ObjectID); err != nil {
+				glog.Slog.Errorf("check badge %d for user %s failed: %v", badge.ID, msg.UserID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkAndAward award the badge to the user if the user reached the rule in the tag, tag id 0 means all tags
+func (bs *BadgeService) checkAndAward(ctx context.Context, badge *entity.Badge, userID, tagID, objectID string) (
+	err error) {
+	exist, err := repo.BadgeRepo.ExistBadgeAward(ctx, userID, badge.ID, bs.awardTagID(badge, tagID))
+	if err != nil || exist {
+		return err
+	}
+
+	var count int64
+	switch badge.RuleType {
+	case entity.BadgeRuleAcceptedAnswers:
+		count, err = repo.BadgeRepo.CountAcceptedAnswers(ctx, userID, tagID)
+	case entity.BadgeRuleHelpfulAnswers:
+		count, err = repo.BadgeRepo.CountHelpfulAnswers(ctx, userID, tagID)
+	case entity.BadgeRuleUpvotes:
+		activityTypes := make([]int, 0, 2)
+		for _, key := range []string{constant.QuestionVotedUp, constant.AnswerVotedUp} {
+			activityType, err := utils.GetIDByKey(ctx, key)
+			if err != nil {
+				return err
+			}
+			activityTypes = append(activityTypes, activityType)
+		}
+		count, err = repo.BadgeRepo.CountUpvotes(ctx, userID, tagID, activityTypes)
+	case entity.BadgeRuleAnswerStreak:
+		// only the answers in the last threshold days can make the streak
+		after := time.Now().AddDate(0, 0, -badge.Threshold)
+		createdAtList, err := repo.BadgeRepo.GetAnswerCreatedAtList(ctx, userID, after)
+		if err != nil {
+			return err
+		}
+		count = int64(day.Streak(createdAtList, time.Now(), time.Local))
+	}
+	if err != nil || count < int64(badge.Threshold) {
+		return err
+	}
+
+	added, err := repo.BadgeRepo.AddBadgeAward(ctx, &entity.BadgeAward{
+		UserID:   userID,
+		BadgeID:  badge.ID,
+		TagID:    bs.awardTagID(badge, tagID),
+		ObjectID: objectID,
+	})
+	if err != nil || !added {
+		return err
+	}
+	glog.Slog.Infof("user %s earned badge %d [%s]", userID, badge.ID, badge.Name)
+	NotificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       userID,
+		ReceiverUserID:      userID,
+		Type:                schema.NotificationTypeInbox,
+		Title:               badge.Name,
+		ObjectID:            strconv.Itoa(badge.ID),
+		ObjectType:          constant.BadgeObjectType,
+		NotificationAction:  constant.NotificationEarnedBadge,
+		NoNeedPushAllFollow: true,
+	})
+	return nil
+}
+
+// awardTagID the badge is awarded once for each tag only if the badge is per tag
+func (bs *BadgeService) awardTagID(badge *entity.Badge, tagID string) string {
+	if badge.PerTag {
+		return tagID
+	}
+	return "0"
+}
+
+// GetBadgeList get badge list with the award count, status 0 means all
+func (bs *BadgeService) GetBadgeList(ctx context.Context, status int) (resp []*schema.GetBadgeResp, err error) {
+	badgeList, err := repo.BadgeRepo.GetBadgeList(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := repo.BadgeRepo.CountBadgeAwardGroupByBadge(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetBadgeResp, 0, len(badgeList))
+	for _, badge := range badgeList {
+		item := &schema.GetBadgeResp{}
+		_ = copier.Copy(item, badge)
+		if item.TagID == "0" {
+			item.TagID = ""
+		}
+		item.AwardCount = counts[badge.ID]
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// AddBadge add badge
+func (bs *BadgeService) AddBadge(ctx context.Context, req *schema.AddBadgeReq) (err error) {
+	if err = bs.checkBadgeTag(ctx, &req.TagID); err != nil {
+		return err
+	}
+	badge := &entity.Badge{}
+	_ = copier.Copy(badge, req)
+	badge.Status = entity.BadgeStatusEnabled
+	return repo.BadgeRepo.AddBadge(ctx, badge)
+}
+
+// UpdateBadge update badge, the awarded badges are kept even if the rule is changed
+func (bs *BadgeService) UpdateBadge(ctx context.Context, req *schema.UpdateBadgeReq) (err error) {
+	_, exist, err := repo.BadgeRepo.GetBadge(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.BadgeNotFound)
+	}
+	if err = bs.checkBadgeTag(ctx, &req.TagID); err != nil {
+		return err
+	}
+	badge := &entity.Badge{}
+	_ = copier.Copy(badge, &req.AddBadgeReq)
+	badge.ID = req.ID
+	badge.Status = req.Status
+	return repo.BadgeRepo.UpdateBadge(ctx, badge)
+}
+
+// checkBadgeTag the tag must exist, empty tag id means all tags and is saved as 0
+func (bs *BadgeService) checkBadgeTag(ctx context.Context, tagID *string) (err error) {
+	if len(*tagID) == 0 || *tagID == "0" {
+		*tagID = "0"
+		return nil
+	}
+	tagList, err := repo.TagRepo.GetTagListByIDs(ctx, []string{*tagID})
+	if err != nil {
+		return err
+	}
+	if len(tagList) == 0 {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	return nil
+}
+
+// GetUserBadgeList get the badges of the user, the newest first
+func (bs *BadgeService) GetUserBadgeList(ctx context.Context, req *schema.GetUserBadgeReq) (
+	resp []*schema.GetUserBadgeResp, err error) {
+	userInfo, exist, err := UserCommonServicer.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	awardList, err := repo.BadgeRepo.GetUserBadgeAwardList(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	badgeList, err := repo.BadgeRepo.GetBadgeList(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	badgeMapping := make(map[int]*entity.Badge, len(badgeList))
+	for _, badge := range badgeList {
+		badgeMapping[badge.ID] = badge
+	}
+	tagIDs := make([]string, 0)
+	for _, award := range awardList {
+		if award.TagID != "0" {
+			tagIDs = append(tagIDs, award.TagID)
+		}
+	}
+	tagNameMapping := make(map[string]string)
+	if len(tagIDs) > 0 {
+		tagList, err := repo.TagRepo.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tagList {
+			tagNameMapping[tag.ID] = tag.DisplayName
+		}
+	}
+
+	resp = make([]*schema.GetUserBadgeResp, 0, len(awardList))
+	for _, award := range awardList {
+		badge, ok := badgeMapping[award.BadgeID]
+		if !ok {
+			continue
+		}
+		item := &schema.GetUserBadgeResp{
+			BadgeID:     badge.ID,
+			Name:        badge.Name,
+			Description: badge.Description,
+			Icon:        badge.Icon,
+			Level:       badge.Level,
+			ObjectID:    award.ObjectID,
+			CreatedAt:   award.CreatedAt.Unix(),
+		}
+		if award.TagID != "0" {
+			item.TagID = award.TagID
+			item.TagName = tagNameMapping[award.TagID]
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
@@ -209,6 +209,10 @@ func (cs *CaptchaService) ValidationStrategy(ctx context.Context, IP, actionType
 		return cs.CaptchaActionDelete(ctx, IP, info)
 	case entity.CaptchaActionVote:
 		return cs.CaptchaActionVote(ctx, IP, info)
+	case entity.CaptchaActionTwoFactor:
+		return cs.CaptchaActionTwoFactor(ctx, IP, info)
+	case entity.CaptchaActionMagicLink:
+		return cs.CaptchaActionMagicLink(ctx, IP, info)
 
 	}
 	//actionType not found
@@ -233,6 +237,30 @@ func (cs *CaptchaService) CaptchaActionPassword(ctx context.Context, unit string
 	return true
 }
 
+// CaptchaActionTwoFactor the captcha is required after 3 failed two-factor verifications in 30 minutes
+func (cs *CaptchaService) CaptchaActionTwoFactor(ctx context.Context, unit string, actioninfo *entity.ActionRecordInfo) bool {
+	setNum := 3
+	setTime := int64(60 * 30) //seconds
+	now := time.Now().Unix()
+	if now-actioninfo.LastTime > setTime {
+		repo.CaptchaRepo.SetActionType(ctx, unit, entity.CaptchaActionTwoFactor, "", 0)
+		return false
+	}
+	return actioninfo.Num >= setNum
+}
+
+// CaptchaActionMagicLink the captcha is required after 3 login emails are requested in 30 minutes
+func (cs *CaptchaService) CaptchaActionMagicLink(ctx context.Context, unit string, actioninfo *entity.ActionRecordInfo) bool {
+	setNum := 3
+	setTime := int64(60 * 30) //seconds
+	now := time.Now().Unix()
+	if now-actioninfo.LastTime > setTime {
+		repo.CaptchaRepo.SetActionType(ctx, unit, entity.CaptchaActionMagicLink, "", 0)
+		return false
+	}
+	return actioninfo.Num >= setNum
+}
+
 func (cs *CaptchaService) CaptchaActionEditUserinfo(ctx context.Context, unit string, actioninfo *entity.ActionRecordInfo) bool {
 	setNum := 3
 	setTime := int64(60 * 30) //seconds
@@ -28,6 +28,9 @@ type CommentRepo interface {
 	GetComment(ctx context.Context, commentID string) (comment *entity.Comment, exist bool, err error)
 	GetCommentPage(ctx context.Context, commentQuery *utils.CommentQuery) (
 		comments []*entity.Comment, total int64, err error)
+	UpdateCommentStatus(ctx context.Context, commentID string, status int) (err error)
+	GetPendingCommentPage(ctx context.Context, page, pageSize int) (
+		comments []*entity.Comment, total int64, err error)
 }
 
 // CommentServicer user service
@@ -73,19 +76,34 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		comment.SetReplyCommentID("")
 	}
 
+	spamResult := SpamServicer.Score(ctx, &schema.SpamCheckReq{
+		UserID:     req.UserID,
+		ObjectType: constant.CommentObjectType,
+		ObjectID:   req.ObjectID,
+		Content:    req.OriginalText,
+	})
+	if spamResult.Hold {
+		comment.Status = entity.CommentStatusPending
+	}
+
 	err = repo.CommentRepo.AddComment(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
+	SpamServicer.Record(ctx, comment.ID, constant.CommentObjectType, comment.UserID, spamResult)
+	ResponseTemplateServicer.RecordTemplateUse(ctx, req.TemplateID, req.UserID, comment.ID, constant.CommentObjectType)
 
 	resp = &schema.GetCommentResp{}
 	resp.SetFromComment(comment)
 	resp.MemberActions = permission.GetCommentPermission(ctx, req.UserID, resp.UserID,
 		time.Now(), req.CanEdit, req.CanDelete)
 
-	commentResp, err := cs.addCommentNotification(ctx, req, resp, comment, objInfo)
-	if err != nil {
-		return commentResp, err
+	// the held comment is published when it is approved
+	if comment.Status == entity.CommentStatusAvailable {
+		commentResp, err := cs.addCommentNotification(ctx, req, resp, comment, objInfo)
+		if err != nil {
+			return commentResp, err
+		}
 	}
 
 	// get user info
@@ -100,20 +118,51 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		resp.UserStatus = userInfo.Status
 	}
 
+	if comment.Status == entity.CommentStatusAvailable {
+		cs.sendCommentActivity(ctx, comment, req.ObjectID, objInfo.ObjectType)
+	}
+	return resp, nil
+}
+
+// PublishPendingComment publish the comment held for review, the reply user or the author of the commented post
+// is notified, the mentions are not notified again
+func (cs *CommentService) PublishPendingComment(ctx context.Context, comment *entity.Comment) (err error) {
+	objInfo, err := ObjServicer.GetInfo(ctx, comment.ObjectID)
+	if err != nil {
+		return err
+	}
+	objInfo.QuestionID = uid.DeShortID(objInfo.QuestionID)
+	objInfo.AnswerID = uid.DeShortID(objInfo.AnswerID)
+	if err = repo.CommentRepo.UpdateCommentStatus(ctx, comment.ID, entity.CommentStatusAvailable); err != nil {
+		return err
+	}
+	comment.Status = entity.CommentStatusAvailable
+
+	req := &schema.AddCommentReq{ObjectID: comment.ObjectID, UserID: comment.UserID}
+	resp := &schema.GetCommentResp{}
+	resp.SetFromComment(comment)
+	if _, err = cs.addCommentNotification(ctx, req, resp, comment, objInfo); err != nil {
+		return err
+	}
+	cs.sendCommentActivity(ctx, comment, comment.ObjectID, objInfo.ObjectType)
+	return nil
+}
+
+func (cs *CommentService) sendCommentActivity(ctx context.Context, comment *entity.Comment,
+	originalObjectID, objectType string) {
 	activityMsg := &schema.ActivityMsg{
 		UserID:           comment.UserID,
 		ObjectID:         comment.ID,
-		OriginalObjectID: req.ObjectID,
+		OriginalObjectID: originalObjectID,
 		ActivityTypeKey:  constant.ActQuestionCommented,
 	}
-	switch objInfo.ObjectType {
+	switch objectType {
 	case constant.QuestionObjectType:
 		activityMsg.ActivityTypeKey = constant.ActQuestionCommented
 	case constant.AnswerObjectType:
 		activityMsg.ActivityTypeKey = constant.ActAnswerCommented
 	}
 	ActivityQueueServicer.Send(ctx, activityMsg)
-	return resp, nil
 }
 
 func (cs *CommentService) addCommentNotification(
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/pkg/day"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// dashboardStatMaxDays the max days of the date range to query or roll up
+	dashboardStatMaxDays = 366
+	// dashboardStatTopLimit the number of the most active tags and top contributors
+	dashboardStatTopLimit = 20
+	// dashboardStatRecentDays the days rolled up again by the cron, the answers and the acceptances of the questions
+	// created on the earlier days change the answer rate, the acceptance rate and the time to the first answer
+	dashboardStatRecentDays = 30
+)
+
+// DashboardStatRepo dashboard statistics repository
+type DashboardStatRepo interface {
+	GetQuestionListByCreatedAt(ctx context.Context, start, end time.Time) (questionList []*entity.Question, err error)
+	GetAnswerListByCreatedAt(ctx context.Context, start, end time.Time) (answerList []*entity.Answer, err error)
+	GetAnswerListByQuestionIDs(ctx context.Context, questionIDs []string) (answerList []*entity.Answer, err error)
+	CountUserByCreatedAt(ctx context.Context, start, end time.Time) (count int64, err error)
+	SaveDailyStat(ctx context.Context, stat *entity.DashboardDailyStat,
+		tagStatList []*entity.DashboardDailyTagStat, userStatList []*entity.DashboardDailyUserStat) (err error)
+	GetDailyStatList(ctx context.Context, startDate, endDate string) (statList []*entity.DashboardDailyStat, err error)
+	GetTagStatSummary(ctx context.Context, startDate, endDate string, limit int) (
+		statList []*entity.DashboardDailyTagStat, err error)
+	GetUserStatSummary(ctx context.Context, startDate, endDate string, limit int) (
+		statList []*entity.DashboardDailyUserStat, err error)
+}
+
+// RollupRecentStat roll up the statistics of the recent days including today, it is called by the cron
+func (ds *dashboardService) RollupRecentStat(ctx context.Context) {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	for i := dashboardStatRecentDays - 1; i >= 0; i-- {
+		date := today.AddDate(0, 0, -i)
+		if err := ds.rollupDailyStat(ctx, date); err != nil {
+			glog.Slog.Errorf("roll up dashboard statistics of %s failed: %s", date.Format(day.DateLayout), err)
+		}
+	}
+}
+
+// RollupStat roll up the statistics between the dates again
+func (ds *dashboardService) RollupStat(ctx context.Context, req *schema.GetDashboardStatReq) (err error) {
+	dates, err := ds.checkDateRange(req)
+	if err != nil {
+		return err
+	}
+	for _, date := range dates {
+		if err = ds.rollupDailyStat(ctx, date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStat get the daily statistics, the most active tags and top contributors between the dates
+func (ds *dashboardService) GetStat(ctx context.Context, req *schema.GetDashboardStatReq) (
+	resp *schema.GetDashboardStatResp, err error) {
+	days, err := ds.getDailyStatList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetDashboardStatResp{Days: days}
+
+	resp.Tags, err = ds.getTagStatList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Contributors, err = ds.getContributorStatList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ExportStat export the daily statistics between the dates as csv
+func (ds *dashboardService) ExportStat(ctx context.Context, req *schema.GetDashboardStatReq) (
+	content []byte, err error) {
+	days, err := ds.getDailyStatList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	_ = writer.Write([]string{"date", "question_count", "answer_count", "user_count",
+		"answer_rate", "acceptance_rate", "median_first_answer_seconds"})
+	for _, d := range days {
+		_ = writer.Write([]string{
+			d.Date,
+			fmt.Sprintf("%d", d.QuestionCount),
+			fmt.Sprintf("%d", d.AnswerCount),
+			fmt.Sprintf("%d", d.UserCount),
+			fmt.Sprintf("%.4f", d.AnswerRate),
+			fmt.Sprintf("%.4f", d.AcceptanceRate),
+			fmt.Sprintf("%d", d.MedianFirstAnswerSeconds),
+		})
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (ds *dashboardService) checkDateRange(req *schema.GetDashboardStatReq) (dates []time.Time, err error) {
+	dates, err = day.Dates(req.StartDate, req.EndDate, time.Local)
+	if err != nil || len(dates) > dashboardStatMaxDays {
+		return nil, errors.BadRequest(reason.DashboardDateRangeInvalid)
+	}
+	return dates, nil
+}
+
+// getDailyStatList get the daily statistics, the days without statistics are filled with zero
+func (ds *dashboardService) getDailyStatList(ctx context.Context, req *schema.GetDashboardStatReq) (
+	days []*schema.DashboardDailyStat, err error) {
+	dates, err := ds.checkDateRange(req)
+	if err != nil {
+		return nil, err
+	}
+	statList, err := repo.DashboardStatRepo.GetDailyStatList(ctx, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	statMapping := make(map[string]*entity.DashboardDailyStat, len(statList))
+	for _, stat := range statList {
+		statMapping[stat.Date] = stat
+	}
+
+	days = make([]*schema.DashboardDailyStat, 0, len(dates))
+	for _, date := range dates {
+		item := &schema.DashboardDailyStat{Date: date.Format(day.DateLayout)}
+		if stat := statMapping[item.Date]; stat != nil {
+			item.QuestionCount = stat.QuestionCount
+			item.AnswerCount = stat.AnswerCount
+			item.UserCount = stat.UserCount
+			item.MedianFirstAnswerSeconds = stat.MedianFirstAnswerSeconds
+			if stat.QuestionCount > 0 {
+				item.AnswerRate = float64(stat.AnsweredQuestionCount) / float64(stat.QuestionCount)
+				item.AcceptanceRate = float64(stat.AcceptedQuestionCount) / float64(stat.QuestionCount)
+			}
+		}
+		days = append(days, item)
+	}
+	return days, nil
+}
+
+func (ds *dashboardService) getTagStatList(ctx context.Context, req *schema.GetDashboardStatReq) (
+	resp []*schema.DashboardTagStat, err error) {
+	resp = make([]*schema.DashboardTagStat, 0)
+	statList, err := repo.DashboardStatRepo.GetTagStatSummary(ctx, req.StartDate, req.EndDate, dashboardStatTopLimit)
+	if err != nil || len(statList) == 0 {
+		return resp, err
+	}
+	tagIDs := make([]string, 0, len(statList))
+	for _, stat := range statList {
+		tagIDs = append(tagIDs, stat.TagID)
+	}
+	tagList, err := repo.TagRepo.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return resp, err
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tagList))
+	for _, tag := range tagList {
+		tagMapping[tag.ID] = tag
+	}
+	for _, stat := range statList {
+		item := &schema.DashboardTagStat{
+			TagID:         stat.TagID,
+			QuestionCount: stat.QuestionCount,
+			AnswerCount:   stat.AnswerCount,
+		}
+		if tag := tagMapping[stat.TagID]; tag != nil {
+			item.SlugName = tag.SlugName
+			item.DisplayName = tag.DisplayName
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+func (ds *dashboardService) getContributorStatList(ctx context.Context, req *schema.GetDashboardStatReq) (
+	resp []*schema.DashboardContributorStat, err error) {
+	resp = make([]*schema.DashboardContributorStat, 0)
+	statList, err := repo.DashboardStatRepo.GetUserStatSummary(ctx, req.StartDate, req.EndDate, dashboardStatTopLimit)
+	if err != nil || len(statList) == 0 {
+		return resp, err
+	}
+	userIDs := make([]string, 0, len(statList))
+	for _, stat := range statList {
+		userIDs = append(userIDs, stat.UserID)
+	}
+	userInfoMapping, err := UserCommonServicer.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return resp, err
+	}
+	for _, stat := range statList {
+		userInfo := userInfoMapping[stat.UserID]
+		if userInfo == nil {
+			continue
+		}
+		resp = append(resp, &schema.DashboardContributorStat{
+			UserInfo:      userInfo,
+			QuestionCount: stat.QuestionCount,
+			AnswerCount:   stat.AnswerCount,
+			AcceptedCount: stat.AcceptedCount,
+		})
+	}
+	return resp, nil
+}
+
+// rollupDailyStat roll up the statistics of the day which begins at the date
+func (ds *dashboardService) rollupDailyStat(ctx context.Context, date time.Time) (err error) {
+	start, end := date, date.AddDate(0, 0, 1)
+	dateStr := start.Format(day.DateLayout)
+
+	questionList, err := repo.DashboardStatRepo.GetQuestionListByCreatedAt(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	answerList, err := repo.DashboardStatRepo.GetAnswerListByCreatedAt(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	userCount, err := repo.DashboardStatRepo.CountUserByCreatedAt(ctx, start, end)
+	if err != nil {
+		return err
+	}
+
+	stat := &entity.DashboardDailyStat{
+		Date:          dateStr,
+		QuestionCount: int64(len(questionList)),
+		AnswerCount:   int64(len(answerList)),
+		UserCount:     userCount,
+	}
+	questionIDs := make([]string, 0, len(questionList))
+	for _, question := range questionList {
+		questionIDs = append(questionIDs, question.ID)
+		if question.AnswerCount > 0 {
+			stat.AnsweredQuestionCount++
+		}
+		if len(question.AcceptedAnswerID) > 0 && question.AcceptedAnswerID != "0" {
+			stat.AcceptedQuestionCount++
+		}
+	}
+	stat.MedianFirstAnswerSeconds, err = ds.medianFirstAnswerSeconds(ctx, questionList)
+	if err != nil {
+		return err
+	}
+
+	tagStatList, err := ds.rollupDailyTagStat(ctx, dateStr, questionIDs, answerList)
+	if err != nil {
+		return err
+	}
+	userStatList := ds.rollupDailyUserStat(dateStr, questionList, answerList)
+	return repo.DashboardStatRepo.SaveDailyStat(ctx, stat, tagStatList, userStatList)
+}
+
+func (ds *dashboardService) medianFirstAnswerSeconds(ctx context.Context, questionList []*entity.Question) (
+	median int64, err error) {
+	questionIDs := make([]string, 0, len(questionList))
+	for _, question := range questionList {
+		if question.AnswerCount > 0 {
+			questionIDs = append(questionIDs, question.ID)
+		}
+	}
+	answerList, err := repo.DashboardStatRepo.GetAnswerListByQuestionIDs(ctx, questionIDs)
+	if err != nil {
+		return 0, err
+	}
+	firstAnswerAt := make(map[string]time.Time, len(questionIDs))
+	for _, answer := range answerList {
+		if at, ok := firstAnswerAt[answer.QuestionID]; !ok || answer.CreatedAt.Before(at) {
+			firstAnswerAt[answer.QuestionID] = answer.CreatedAt
+		}
+	}
+
+	durations := make([]int64, 0, len(firstAnswerAt))
+	for _, question := range questionList {
+		at, ok := firstAnswerAt[question.ID]
+		if !ok {
+			continue
+		}
+		seconds := int64(at.Sub(question.CreatedAt).Seconds())
+		if seconds < 0 {
+			seconds = 0
+		}
+		durations = append(durations, seconds)
+	}
+	if len(durations) == 0 {
+		return 0, nil
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2, nil
+	}
+	return durations[mid], nil
+}
+
+// rollupDailyTagStat count the questions asked and the answers created under each tag
+func (ds *dashboardService) rollupDailyTagStat(ctx context.Context, date string,
+	questionIDs []string, answerList []*entity.Answer) (tagStatList []*entity.DashboardDailyTagStat, err error) {
+	objectIDs := make([]string, 0, len(questionIDs)+len(answerList))
+	objectIDs = append(objectIDs, questionIDs...)
+	for _, answer := range answerList {
+		objectIDs = append(objectIDs, answer.QuestionID)
+	}
+	tagStatList = make([]*entity.DashboardDailyTagStat, 0)
+	if len(objectIDs) == 0 {
+		return tagStatList, nil
+	}
+	tagRelList, err := repo.TagRelRepo.BatchGetObjectTagRelList(ctx, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionTagIDs := make(map[string][]string, 0)
+	for _, rel := range tagRelList {
+		questionTagIDs[rel.ObjectID] = append(questionTagIDs[rel.ObjectID], rel.TagID)
+	}
+
+	tagStatMapping := make(map[string]*entity.DashboardDailyTagStat, 0)
+	getTagStat := func(tagID string) *entity.DashboardDailyTagStat {
+		tagStat, ok := tagStatMapping[tagID]
+		if !ok {
+			tagStat = &entity.DashboardDailyTagStat{Date: date, TagID: tagID}
+			tagStatMapping[tagID] = tagStat
+			tagStatList = append(tagStatList, tagStat)
+		}
+		return tagStat
+	}
+	for _, questionID := range questionIDs {
+		for _, tagID := range questionTagIDs[questionID] {
+			getTagStat(tagID).QuestionCount++
+		}
+	}
+	for _, answer := range answerList {
+		for _, tagID := range questionTagIDs[answer.QuestionID] {
+			getTagStat(tagID).AnswerCount++
+		}
+	}
+	return tagStatList, nil
+}
+
+// rollupDailyUserStat count the questions, answers and accepted answers of each user
+func (ds *dashboardService) rollupDailyUserStat(date string,
+	questionList []*entity.Question, answerList []*entity.Answer) (userStatList []*entity.DashboardDailyUserStat) {
+	userStatList = make([]*entity.DashboardDailyUserStat, 0)
+	userStatMapping := make(map[string]*entity.DashboardDailyUserStat, 0)
+	getUserStat := func(userID string) *entity.DashboardDailyUserStat {
+		userStat, ok := userStatMapping[userID]
+		if !ok {
+			userStat = &entity.DashboardDailyUserStat{Date: date, UserID: userID}
+			userStatMapping[userID] = userStat
+			userStatList = append(userStatList, userStat)
+		}
+		return userStat
+	}
+	for _, question := range questionList {
+		getUserStat(question.UserID).QuestionCount++
+	}
+	for _, answer := range answerList {
+		userStat := getUserStat(answer.UserID)
+		userStat.AnswerCount++
+		if answer.Accepted == schema.AnswerAcceptedEnable {
+			userStat.AcceptedCount++
+		}
+	}
+	return userStatList
+}
@@ -8,11 +8,9 @@ import (
 	c "github.com/lawyer/commons/config"
 	"github.com/lawyer/commons/constant"
 	glog "github.com/lawyer/commons/logger"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/pkg/display"
 	"github.com/lawyer/repo"
-	"github.com/lawyer/service/config"
 	"os"
 	"strings"
 	"time"
@@ -22,15 +20,19 @@ import (
 	"gopkg.in/gomail.v2"
 )
 
+// emailConfigKey the smtp settings and the email templates are stored as json in it
+const emailConfigKey = "email.config"
+
 // EmailServicer kit service
 type EmailService struct {
-	configService *config.ConfigService
 }
 
 // EmailRepo email repository
 type EmailRepo interface {
 	SetCode(ctx context.Context, code, content string, duration time.Duration) error
 	VerifyCode(ctx context.Context, code string) (content string, err error)
+	UseCode(ctx context.Context, code string) (content string, err error)
+	IncrCodeAttempts(ctx context.Context, code string, duration time.Duration) (attempts int64, err error)
 }
 
 // NewEmailService email service
@@ -109,7 +111,7 @@ func (es *EmailService) VerifyEmailByCode(ctx context.Context, code string) (con
 }
 
 func (es *EmailService) RegisterTemplate(ctx context.Context, registerUrl string) (title, body string, err error) {
-	siteInfo := site.Config.GetSiteGeneral()
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
 	if err != nil {
 		return
 	}
@@ -125,12 +127,12 @@ func (es *EmailService) RegisterTemplate(ctx context.Context, registerUrl string
 }
 
 func (es *EmailService) PassResetTemplate(ctx context.Context, passResetUrl string) (title, body string, err error) {
-	//site, err := SiteInfoCommonServicer.GetSiteGeneral(ctx)
-	//if err != nil {
-	//	return
-	//}
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
 
-	templateData := &schema.PassResetTemplateData{SiteName: "site.Name", PassResetUrl: passResetUrl}
+	templateData := &schema.PassResetTemplateData{SiteName: siteInfo.Name, PassResetUrl: passResetUrl}
 
 	lang := utils.GetLangByCtx(ctx)
 	title = translator.TrWithData(lang, constant.EmailTplKeyPassResetTitle, templateData)
@@ -139,12 +141,12 @@ func (es *EmailService) PassResetTemplate(ctx context.Context, passResetUrl stri
 }
 
 func (es *EmailService) ChangeEmailTemplate(ctx context.Context, changeEmailUrl string) (title, body string, err error) {
-	//site, err := SiteInfoCommonServicer.GetSiteGeneral(ctx)
-	//if err != nil {
-	//	return
-	//}
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
 	templateData := &schema.ChangeEmailTemplateData{
-		SiteName:       "site.Name",
+		SiteName:       siteInfo.Name,
 		ChangeEmailUrl: changeEmailUrl,
 	}
 
@@ -154,9 +156,28 @@ func (es *EmailService) ChangeEmailTemplate(ctx context.Context, changeEmailUrl
 	return title, body, nil
 }
 
+// MagicLinkTemplate the email of the passwordless login, it contains both the link and the one-time code
+func (es *EmailService) MagicLinkTemplate(ctx context.Context, magicLinkUrl, code string) (title, body string, err error) {
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.MagicLinkTemplateData{
+		SiteName:      siteInfo.Name,
+		MagicLinkUrl:  magicLinkUrl,
+		Code:          code,
+		ExpireMinutes: int(constant.UserMagicLinkCacheTime.Minutes()),
+	}
+
+	lang := utils.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyMagicLinkTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyMagicLinkBody, templateData)
+	return title, body, nil
+}
+
 // TestTemplate send test email template parse
 func (es *EmailService) TestTemplate(ctx context.Context) (title, body string, err error) {
-	siteInfo := site.Config.GetSiteGeneral()
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
 	if err != nil {
 		return
 	}
@@ -171,8 +192,11 @@ func (es *EmailService) TestTemplate(ctx context.Context) (title, body string, e
 // NewAnswerTemplate new answer template
 func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAnswerTemplateRawData) (
 	title, body string, err error) {
-	siteInfo := site.Config.GetSiteGeneral()
-	seoInfo := site.Config.GetSiteSeo()
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := SiteInfoServicer.GetSiteSeo(ctx)
 	if err != nil {
 		return
 	}
@@ -194,11 +218,11 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 // NewInviteAnswerTemplate new invite answer template
 func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema.NewInviteAnswerTemplateRawData) (
 	title, body string, err error) {
-	siteInfo := site.Config.GetSiteGeneral()
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
 	if err != nil {
 		return
 	}
-	seo := site.Config.GetSiteSeo()
+	seo, err := SiteInfoServicer.GetSiteSeo(ctx)
 	if err != nil {
 		return
 	}
@@ -219,8 +243,14 @@ func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema
 // NewCommentTemplate new comment template
 func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewCommentTemplateRawData) (
 	title, body string, err error) {
-	siteInfo := site.Config.GetSiteGeneral()
-	seo := site.Config.GetSiteSeo()
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seo, err := SiteInfoServicer.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
 	templateData := &schema.NewCommentTemplateData{
 		SiteName:       siteInfo.Name,
 		DisplayName:    raw.CommentUserDisplayName,
@@ -240,9 +270,14 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 // NewQuestionTemplate new question template
 func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.NewQuestionTemplateRawData) (
 	title, body string, err error) {
-	siteInfo := site.Config.GetSiteGeneral()
-
-	seo := site.Config.GetSiteSeo()
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seo, err := SiteInfoServicer.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
 
 	templateData := &schema.NewQuestionTemplateData{
 		SiteName:       siteInfo.Name,
@@ -261,21 +296,26 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 
 func (es *EmailService) GetEmailConfig(ctx context.Context) (ec *c.EmailConfig, err error) {
 	ec = &c.EmailConfig{}
-	//todo 先写死在这里
-	//Encryption         string `json:"encryption"` // "" SSL
-	//SMTPAuthentication bool   `json:"smtp_authentication"`
-	ec.FromEmail = "jackzhi4716@gmail.com"
-	ec.FromName = "jackzhi4716@gmail.com"
-	ec.SMTPHost = "smtp.gmail.com"
-	ec.SMTPPort = 465
-	ec.SMTPPassword = "myxz hxsn anin awon"
-	ec.SMTPUsername = "jackzhi4716@gmail.com"
-
+	cfg, err := utils.GetConfigByKey(ctx, emailConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), ec); err != nil {
+		return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
 	return ec, nil
 }
 
-// SetEmailConfig set email config
+// SetEmailConfig set email config, the other fields of the config are kept
 func (es *EmailService) SetEmailConfig(ctx context.Context, ec *c.EmailConfig) (err error) {
+	cfg, err := utils.GetConfigByKey(ctx, emailConfigKey)
+	if err != nil {
+		return err
+	}
+	content := make(map[string]any)
+	_ = json.Unmarshal([]byte(cfg.Value), &content)
 	data, _ := json.Marshal(ec)
-	return es.configService.UpdateConfig(ctx, "email.config", string(data))
+	_ = json.Unmarshal(data, &content)
+	data, _ = json.Marshal(content)
+	return utils.UpdateConfig(ctx, emailConfigKey, string(data))
 }
@@ -32,7 +32,11 @@ var (
 	ExternalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	CommentServicer                  *CommentService
 	RolePowerRelServicer             *RolePowerRelService
+	PowerServicer                    *PowerService
+	AuditLogServicer                 *AuditLogService
 	RankServicer                     *RankService
+	ReputationServicer               *ReputationService
+	ReputationPolicyServicer         *ReputationPolicyService
 	ReportServicer                   *ReportService
 	VoteServicer                     *VoteService
 	TagServicer                      *TagService
@@ -62,6 +66,17 @@ var (
 	UploaderServicer             UploaderService
 	DashboardServicer            DashboardService
 	ActivityServicer             *ActivityService
+	BadgeServicer                *BadgeService
+	QuestionLifecycleServicer    *QuestionLifecycleService
+	ResponseTemplateServicer     *ResponseTemplateService
+	AnswerDisclaimerServicer     *AnswerDisclaimerService
+	RevisionReviewServicer       *RevisionReviewService
+	PreModerationServicer        *PreModerationService
+	SpamServicer                 *SpamService
+	SiteInfoServicer             *SiteInfoService
+	QuestionViewServicer         *QuestionViewService
+	UserTwoFactorServicer        *UserTwoFactorService
+	UserDataServicer             *UserDataService
 )
 
 var (
@@ -95,7 +110,11 @@ func InitServices() {
 	CommentServicer = NewCommentService()
 
 	RolePowerRelServicer = NewRolePowerRelService()
+	PowerServicer = NewPowerService()
+	AuditLogServicer = NewAuditLogService()
 	RankServicer = NewRankService()
+	ReputationServicer = NewReputationService()
+	ReputationPolicyServicer = NewReputationPolicyService()
 
 	ReportServicer = NewReportService()
 	VoteServicer = NewVoteService()
@@ -118,7 +137,7 @@ func InitServices() {
 	ReportAdminServicer = NewReportAdminService()
 	UserAdminServicer = NewUserAdminService()
 	ReasonService = reason.NewReasonService()
-	//SiteInfoServicer = NewSiteInfoService()
+	SiteInfoServicer = NewSiteInfoService()
 	NotificationCommonServicer = NewNotificationCommon()
 	NotificationServicer = NewNotificationService()
 	ActivityCommonServicer = NewActivityCommon()
@@ -127,4 +146,14 @@ func InitServices() {
 	UploaderServicer = NewUploaderService()
 	DashboardServicer = NewDashboardService()
 	ActivityServicer = NewActivityService()
+	BadgeServicer = NewBadgeService()
+	QuestionLifecycleServicer = NewQuestionLifecycleService()
+	ResponseTemplateServicer = NewResponseTemplateService()
+	AnswerDisclaimerServicer = NewAnswerDisclaimerService()
+	RevisionReviewServicer = NewRevisionReviewService()
+	PreModerationServicer = NewPreModerationService()
+	SpamServicer = NewSpamService()
+	QuestionViewServicer = NewQuestionViewService()
+	UserTwoFactorServicer = NewUserTwoFactorService()
+	UserDataServicer = NewUserDataService()
 }
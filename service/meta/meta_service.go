@@ -12,6 +12,7 @@ import (
 // MetaRepo meta repository
 type MetaRepo interface {
 	AddMeta(ctx context.Context, meta *entity.Meta) (err error)
+	AddMetaIfAbsent(ctx context.Context, meta *entity.Meta) (added bool, err error)
 	RemoveMeta(ctx context.Context, id int) (err error)
 	UpdateMeta(ctx context.Context, meta *entity.Meta) (err error)
 	GetMetaByObjectIdAndKey(ctx context.Context, objectId, key string) (meta *entity.Meta, exist bool, err error)
@@ -36,6 +37,16 @@ func (ms *MetaService) AddMeta(ctx context.Context, objID, key, value string) (e
 	return repo.MetaRepo.AddMeta(ctx, meta)
 }
 
+// AddMetaIfAbsent add meta if there is no meta with the same object id and key
+func (ms *MetaService) AddMetaIfAbsent(ctx context.Context, objID, key, value string) (added bool, err error) {
+	meta := &entity.Meta{
+		ObjectID: objID,
+		Key:      key,
+		Value:    value,
+	}
+	return repo.MetaRepo.AddMetaIfAbsent(ctx, meta)
+}
+
 // RemoveMeta delete meta
 func (ms *MetaService) RemoveMeta(ctx context.Context, id int) (err error) {
 	return repo.MetaRepo.RemoveMeta(ctx, id)
@@ -63,8 +63,12 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		Type:               msg.Type,
 	}
 	var questionID string // just for notify all followers
-	objInfo, err := ObjServicer.GetInfo(ctx, req.ObjectInfo.ObjectID)
-	if err != nil {
+	var objInfo *schema.SimpleObjectInfo
+	var err error
+	if msg.ObjectType == constant.BadgeObjectType {
+		// badge is not a post, the title is the badge name
+		req.ObjectInfo.ObjectID = msg.ObjectID
+	} else if objInfo, err = ObjServicer.GetInfo(ctx, req.ObjectInfo.ObjectID); err != nil {
 		glog.Slog.Error(err)
 	} else {
 		req.ObjectInfo.Title = objInfo.Title
@@ -209,3 +209,25 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 	}
 	return objInfo, err
 }
+
+// GetTagIDs get the tags of the question which the object belongs to, or the tag itself
+func (os *ObjService) GetTagIDs(ctx context.Context, objectID string) (tagIDs []string, err error) {
+	objectInfo, err := os.GetInfo(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(objectInfo.TagID) > 0 {
+		return []string{objectInfo.TagID}, nil
+	}
+	if len(objectInfo.QuestionID) == 0 {
+		return nil, nil
+	}
+	tagRelList, err := repo.TagRelRepo.GetObjectTagRelList(ctx, objectInfo.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range tagRelList {
+		tagIDs = append(tagIDs, rel.TagID)
+	}
+	return tagIDs, nil
+}
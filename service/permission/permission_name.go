@@ -44,6 +44,9 @@ const (
 	AnswerUnDelete    = "answer.undeleted"
 	QuestionUnDelete  = "question.undeleted"
 	TagUnDelete       = "tag.undeleted"
+	ReportHandle      = "report.handle"
+	// ResponseTemplateManage manage the site-wide response templates
+	ResponseTemplateManage = "response_template.manage"
 )
 
 const (
@@ -2,10 +2,33 @@ package service
 
 import (
 	"context"
+	"github.com/jinzhu/copier"
 	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/repo"
 )
 
 // PowerRepo power repository
 type PowerRepo interface {
 	GetPowerList(ctx context.Context, power *entity.Power) (powers []*entity.Power, err error)
 }
+
+// PowerService power service
+type PowerService struct {
+}
+
+// NewPowerService new power service
+func NewPowerService() *PowerService {
+	return &PowerService{}
+}
+
+// GetPowerList get all powers which can be assigned to roles
+func (ps *PowerService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp, err error) {
+	powers, err := repo.PowerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	resp = []*schema.GetPowerResp{}
+	_ = copier.Copy(&resp, powers)
+	return resp, nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/commons/utils/pager"
+	"github.com/lawyer/pkg/obj"
+	"github.com/lawyer/pkg/uid"
+	"github.com/lawyer/repo"
+	"github.com/lawyer/service/permission"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PreModerationService the first questions and answers of the new users wait for the approval of the moderators
+type PreModerationService struct {
+}
+
+// NewPreModerationService new pre-moderation service
+func NewPreModerationService() *PreModerationService {
+	return &PreModerationService{}
+}
+
+// GetPreModerationPolicy get the pre-moderation rules
+func (ps *PreModerationService) GetPreModerationPolicy(ctx context.Context) (
+	policy *schema.PreModerationPolicy, err error) {
+	policy = &schema.PreModerationPolicy{}
+	cfg, err := utils.GetConfigByKey(ctx, entity.PreModerationConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Value) == 0 {
+		return policy, nil
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+		return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
+	return policy, nil
+}
+
+// UpdatePreModerationPolicy update the pre-moderation rules
+func (ps *PreModerationService) UpdatePreModerationPolicy(ctx context.Context,
+	req *schema.UpdatePreModerationPolicyReq) (err error) {
+	oldPolicy, err := ps.GetPreModerationPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &req.PreModerationPolicy
+	content, _ := json.Marshal(policy)
+	if err = utils.UpdateConfig(ctx, entity.PreModerationConfigKey, string(content)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionPreModeration,
+		ObjectType: "pre_moderation",
+		ObjectID:   entity.PreModerationConfigKey,
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// NeedPreModeration whether the new question or answer of the user waits for the approval, the moderators and the
+// users with enough rank or published posts are trusted. The question id is the one of the new answer.
+func (ps *PreModerationService) NeedPreModeration(ctx context.Context, userID, questionID string) bool {
+	policy, err := ps.GetPreModerationPolicy(ctx)
+	if err != nil {
+		glog.Slog.Errorf("get pre-moderation policy failed: %v", err)
+		return false
+	}
+	if !policy.Enabled || policy.FirstPosts == 0 {
+		return false
+	}
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, userID)
+	if err != nil || !exist {
+		glog.Slog.Errorf("get user %s failed: %v", userID, err)
+		return false
+	}
+	if policy.MaxRank > 0 && userInfo.Rank >= policy.MaxRank {
+		return false
+	}
+	if userInfo.QuestionCount+userInfo.AnswerCount >= policy.FirstPosts {
+		return false
+	}
+	return !ps.CanViewPendingPost(ctx, userID, questionID)
+}
+
+// CanViewPendingPost the users with the audit power can view the pending posts, by the role or by the tag scoped
+// roles on the object. The object is the pending post, or the question or answer which the new post belongs to,
+// it is empty for the new question and only the audit power of the role counts.
+func (ps *PreModerationService) CanViewPendingPost(ctx context.Context, userID, objectID string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	if len(objectID) == 0 {
+		return RankServicer.CheckUserPower(ctx, userID, permission.QuestionAudit)
+	}
+	power := permission.QuestionAudit
+	if objectType, _ := obj.GetObjectTypeStrByObjectID(objectID); objectType == constant.AnswerObjectType {
+		power = permission.AnswerAudit
+	}
+	return RankServicer.CheckObjectPower(ctx, userID, power, objectID)
+}
+
+// GetPendingPostPage get the pending questions, answers or comments, the oldest first
+func (ps *PreModerationService) GetPendingPostPage(ctx context.Context, req *schema.GetPendingPostPageReq) (
+	resp *pager.PageModel, err error) {
+	list := make([]*schema.PendingPostInfo, 0)
+	userIDs := make([]string, 0)
+	var total int64
+	switch req.ObjectType {
+	case constant.QuestionObjectType:
+		questionList, count, err := repo.QuestionRepo.GetPendingQuestionPage(ctx, req.Page, req.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questionList {
+			list = append(list, &schema.PendingPostInfo{
+				ObjectID:   question.ID,
+				ObjectType: constant.QuestionObjectType,
+				QuestionID: question.ID,
+				Title:      question.Title,
+				Content:    question.OriginalText,
+				HTML:       question.ParsedText,
+				CreatedAt:  question.CreatedAt.Unix(),
+			})
+			userIDs = append(userIDs, question.UserID)
+		}
+		total = count
+	case constant.AnswerObjectType:
+		answerList, count, err := repo.AnswerRepo.GetPendingAnswerPage(ctx, req.Page, req.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, answer := range answerList {
+			item := &schema.PendingPostInfo{
+				ObjectID:   answer.ID,
+				ObjectType: constant.AnswerObjectType,
+				QuestionID: answer.QuestionID,
+				Content:    answer.OriginalText,
+				HTML:       answer.ParsedText,
+				CreatedAt:  answer.CreatedAt.Unix(),
+			}
+			objInfo, err := ObjServicer.GetInfo(ctx, answer.QuestionID)
+			if err != nil {
+				glog.Slog.Error(err)
+			} else {
+				item.Title = objInfo.Title
+			}
+			list = append(list, item)
+			userIDs = append(userIDs, answer.UserID)
+		}
+		total = count
+	case constant.CommentObjectType:
+		commentList, count, err := repo.CommentRepo.GetPendingCommentPage(ctx, req.Page, req.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range commentList {
+			item := &schema.PendingPostInfo{
+				ObjectID:   comment.ID,
+				ObjectType: constant.CommentObjectType,
+				QuestionID: comment.QuestionID,
+				Content:    comment.OriginalText,
+				HTML:       comment.ParsedText,
+				CreatedAt:  comment.CreatedAt.Unix(),
+			}
+			objInfo, err := ObjServicer.GetInfo(ctx, comment.ObjectID)
+			if err != nil {
+				glog.Slog.Error(err)
+			} else {
+				item.Title = objInfo.Title
+			}
+			list = append(list, item)
+			userIDs = append(userIDs, comment.UserID)
+		}
+		total = count
+	}
+
+	userInfoMapping, err := UserCommonServicer.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range list {
+		item.UserInfo = userInfoMapping[userIDs[i]]
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// ReviewPendingPost approve or reject the pending question, answer or comment, the approved post is published and
+// the rejected post is deleted, the author is notified either way. the decision of the post held by the spam
+// score is fed back to the spam hold score
+func (ps *PreModerationService) ReviewPendingPost(ctx context.Context, req *schema.ReviewPendingPostReq) (err error) {
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(req.ObjectID)
+	if err != nil {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	var authorID string
+	switch objectType {
+	case constant.QuestionObjectType:
+		question, exist, err := repo.QuestionRepo.GetQuestion(ctx, req.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.QuestionNotFound)
+		}
+		if question.Status != entity.QuestionStatusPending {
+			return errors.BadRequest(reason.PostNotPending)
+		}
+		if req.Operation == schema.RevisionAuditApprove {
+			err = QuestionServicer.PublishPendingQuestion(ctx, question)
+		} else {
+			err = QuestionCommonServicer.RemoveQuestion(ctx, &schema.RemoveQuestionReq{ID: question.ID})
+		}
+		if err != nil {
+			return err
+		}
+		authorID = question.UserID
+	case constant.AnswerObjectType:
+		answer, exist, err := repo.AnswerRepo.GetByID(ctx, req.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.AnswerNotFound)
+		}
+		if answer.Status != entity.AnswerStatusPending {
+			return errors.BadRequest(reason.PostNotPending)
+		}
+		if req.Operation == schema.RevisionAuditApprove {
+			err = AnswerServicer.PublishPendingAnswer(ctx, answer)
+		} else {
+			err = repo.AnswerRepo.UpdateAnswerStatus(ctx, answer.ID, entity.AnswerStatusDeleted)
+		}
+		if err != nil {
+			return err
+		}
+		authorID = answer.UserID
+	case constant.CommentObjectType:
+		comment, exist, err := repo.CommentRepo.GetComment(ctx, req.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.CommentNotFound)
+		}
+		if comment.Status != entity.CommentStatusPending {
+			return errors.BadRequest(reason.PostNotPending)
+		}
+		if req.Operation == schema.RevisionAuditApprove {
+			err = CommentServicer.PublishPendingComment(ctx, comment)
+		} else {
+			err = repo.CommentRepo.RemoveComment(ctx, comment.ID)
+		}
+		if err != nil {
+			return err
+		}
+		authorID = comment.UserID
+	default:
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionPendingPostReview,
+		ObjectType: objectType,
+		ObjectID:   req.ObjectID,
+		After:      req.Operation,
+	})
+	SpamServicer.Feedback(ctx, req.ObjectID, req.Operation)
+	ps.notificationReviewed(ctx, req.UserID, authorID, req.ObjectID, objectType, req.Operation)
+	return nil
+}
+
+func (ps *PreModerationService) notificationReviewed(ctx context.Context, userID, authorID, objectID,
+	objectType, operation string) {
+	action := constant.NotificationYourPostWasApproved
+	if operation == schema.RevisionAuditReject {
+		action = constant.NotificationYourPostWasRejected
+	}
+	NotificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       userID,
+		ReceiverUserID:      authorID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            objectID,
+		ObjectType:          objectType,
+		NotificationAction:  action,
+		NoNeedPushAllFollow: true,
+	})
+}
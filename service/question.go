@@ -36,7 +36,7 @@ type QuestionRepo interface {
 	RecoverQuestion(ctx context.Context, questionID string) (err error)
 	UpdateQuestionOperation(ctx context.Context, question *entity.Question) (err error)
 	GetQuestionsByTitle(ctx context.Context, title string, pageSize int) (questionList []*entity.Question, err error)
-	UpdatePvCount(ctx context.Context, questionID string) (err error)
+	IncrViewCount(ctx context.Context, questionID string, views, uniqueViews int64) (err error)
 	UpdateAnswerCount(ctx context.Context, questionID string, num int) (err error)
 	UpdateCollectionCount(ctx context.Context, questionID string) (count int64, err error)
 	UpdateAccepted(ctx context.Context, question *entity.Question) (err error)
@@ -46,6 +46,8 @@ type QuestionRepo interface {
 	GetQuestionCount(ctx context.Context) (count int64, err error)
 	GetUserQuestionCount(ctx context.Context, userID string) (count int64, err error)
 	SitemapQuestions(ctx context.Context, page, pageSize int) (questionIDList []*schema.SiteMapQuestionInfo, err error)
+	GetOpenQuestionList(ctx context.Context, before time.Time, lastID string, limit int) (
+		questionList []*entity.Question, err error)
 	RemoveAllUserQuestion(ctx context.Context, userID string) (err error)
 }
 
@@ -61,10 +63,6 @@ func (qs *QuestionCommon) GetUserQuestionCount(ctx context.Context, userID strin
 	return repo.QuestionRepo.GetUserQuestionCount(ctx, userID)
 }
 
-func (qs *QuestionCommon) UpdatePv(ctx context.Context, questionID string) error {
-	return repo.QuestionRepo.UpdatePvCount(ctx, questionID)
-}
-
 func (qs *QuestionCommon) UpdateAnswerCount(ctx context.Context, questionID string) error {
 	count, err := repo.AnswerRepo.GetCountByQuestionID(ctx, questionID)
 	if err != nil {
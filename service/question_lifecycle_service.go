@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// questionLifecycleBatchSize the number of questions checked in one batch
+	questionLifecycleBatchSize = 100
+)
+
+// QuestionLifecycleService remind, bump and close the open questions with the lifecycle rules
+type QuestionLifecycleService struct {
+}
+
+// NewQuestionLifecycleService new question lifecycle service
+func NewQuestionLifecycleService() *QuestionLifecycleService {
+	return &QuestionLifecycleService{}
+}
+
+// GetQuestionLifecyclePolicy get the question lifecycle rules
+func (ls *QuestionLifecycleService) GetQuestionLifecyclePolicy(ctx context.Context) (
+	policy *schema.QuestionLifecyclePolicy, err error) {
+	policy = &schema.QuestionLifecyclePolicy{
+		Default: &schema.QuestionLifecycleRule{},
+		Tags:    make(map[string]*schema.QuestionLifecycleRule),
+	}
+	cfg, err := utils.GetConfigByKey(ctx, entity.QuestionLifecycleConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Value) == 0 {
+		return policy, nil
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+		return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
+	return policy, nil
+}
+
+// UpdateQuestionLifecyclePolicy update the question lifecycle rules
+func (ls *QuestionLifecycleService) UpdateQuestionLifecyclePolicy(ctx context.Context,
+	req *schema.UpdateQuestionLifecyclePolicyReq) (err error) {
+	closeReasons, err := ReasonService.GetReasons(ctx, schema.ReasonReq{
+		ObjectType: constant.QuestionObjectType,
+		Action:     "close",
+	})
+	if err != nil {
+		return err
+	}
+	reasonTypes := make(map[int]bool, len(closeReasons))
+	for _, item := range closeReasons {
+		reasonTypes[item.ReasonType] = true
+	}
+	rules := []*schema.QuestionLifecycleRule{req.Default}
+	tagIDs := make([]string, 0, len(req.Tags))
+	for tagID, rule := range req.Tags {
+		tagIDs = append(tagIDs, tagID)
+		rules = append(rules, rule)
+	}
+	for _, rule := range rules {
+		if rule.AutoCloseDays > 0 && !reasonTypes[rule.CloseReasonType] {
+			return errors.BadRequest(reason.QuestionLifecycleCloseReasonInvalid)
+		}
+	}
+	if len(tagIDs) > 0 {
+		tagList, err := repo.TagRepo.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return err
+		}
+		if len(tagList) != len(tagIDs) {
+			return errors.BadRequest(reason.TagNotFound)
+		}
+	}
+
+	oldPolicy, err := ls.GetQuestionLifecyclePolicy(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &schema.QuestionLifecyclePolicy{Default: req.Default, Tags: req.Tags}
+	content, _ := json.Marshal(policy)
+	if err = utils.UpdateConfig(ctx, entity.QuestionLifecycleConfigKey, string(content)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionQuestionLifecycle,
+		ObjectType: "question_lifecycle",
+		ObjectID:   entity.QuestionLifecycleConfigKey,
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// LifecycleCron check all open questions with the lifecycle rules
+func (ls *QuestionLifecycleService) LifecycleCron(ctx context.Context) {
+	policy, err := ls.GetQuestionLifecyclePolicy(ctx)
+	if err != nil {
+		glog.Slog.Errorf("get question lifecycle policy failed: %v", err)
+		return
+	}
+	minDays := policy.MinDays()
+	if minDays == 0 {
+		return
+	}
+
+	now := time.Now()
+	before := now.AddDate(0, 0, -minDays)
+	lastID := ""
+	for {
+		questionList, err := repo.QuestionRepo.GetOpenQuestionList(ctx, before, lastID, questionLifecycleBatchSize)
+		if err != nil {
+			glog.Slog.Errorf("get open question list failed: %v", err)
+			return
+		}
+		for _, question := range questionList {
+			if err = ls.checkQuestion(ctx, policy, question, now); err != nil {
+				glog.Slog.Errorf("check question %s lifecycle failed: %v", question.ID, err)
+			}
+			lastID = question.ID
+		}
+		if len(questionList) < questionLifecycleBatchSize {
+			break
+		}
+	}
+}
+
+// checkQuestion do the first step the question reached, every step is done only once for a question
+func (ls *QuestionLifecycleService) checkQuestion(ctx context.Context, policy *schema.QuestionLifecyclePolicy,
+	question *entity.Question, now time.Time) (err error) {
+	tagRelList, err := repo.TagRelRepo.GetObjectTagRelList(ctx, question.ID)
+	if err != nil {
+		return err
+	}
+	tagIDs := make([]string, 0, len(tagRelList))
+	for _, rel := range tagRelList {
+		tagIDs = append(tagIDs, rel.TagID)
+	}
+	rule := policy.GetRule(tagIDs)
+	reached := func(t time.Time, days int) bool {
+		return days > 0 && !t.After(now.AddDate(0, 0, -days))
+	}
+
+	if question.AnswerCount > 0 {
+		if !reached(question.CreatedAt, rule.AcceptReminderDays) {
+			return nil
+		}
+		return ls.remindAcceptAnswer(ctx, question)
+	}
+
+	lastActiveAt := question.PostUpdateTime
+	if lastActiveAt.IsZero() || lastActiveAt.Before(question.CreatedAt) {
+		lastActiveAt = question.CreatedAt
+	}
+	if reached(lastActiveAt, rule.AutoCloseDays) {
+		return ls.closeQuestion(ctx, question, rule)
+	}
+	if reached(question.CreatedAt, rule.UnansweredBumpDays) {
+		return ls.bumpQuestion(ctx, question, tagIDs)
+	}
+	return nil
+}
+
+func (ls *QuestionLifecycleService) remindAcceptAnswer(ctx context.Context, question *entity.Question) (err error) {
+	done, err := ls.markDone(ctx, question.ID, entity.QuestionAcceptRemindedKey)
+	if err != nil || done {
+		return err
+	}
+	NotificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       question.UserID,
+		ReceiverUserID:      question.UserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            question.ID,
+		ObjectType:          constant.QuestionObjectType,
+		NotificationAction:  constant.NotificationRemindAcceptAnswer,
+		NoNeedPushAllFollow: true,
+	})
+	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
+		UserID:           question.UserID,
+		ObjectID:         question.ID,
+		OriginalObjectID: question.ID,
+		ActivityTypeKey:  constant.ActQuestionAcceptReminded,
+	})
+	return nil
+}
+
+// bumpQuestion move the question to the top of the active list and notify the followers of its tags
+func (ls *QuestionLifecycleService) bumpQuestion(ctx context.Context, question *entity.Question, tagIDs []string) (
+	err error) {
+	done, err := ls.markDone(ctx, question.ID, entity.QuestionBumpedKey)
+	if err != nil || done {
+		return err
+	}
+	if err = QuestionCommonServicer.UpdatePostTime(ctx, question.ID); err != nil {
+		return err
+	}
+	notified := map[string]bool{question.UserID: true}
+	for _, tagID := range tagIDs {
+		userIDs, err := repo.FollowRepo.GetFollowUserIDs(ctx, tagID)
+		if err != nil {
+			glog.Slog.Error(err)
+			continue
+		}
+		for _, userID := range userIDs {
+			if notified[userID] {
+				continue
+			}
+			notified[userID] = true
+			NotificationQueueService.Send(ctx, &schema.NotificationMsg{
+				TriggerUserID:       question.UserID,
+				ReceiverUserID:      userID,
+				Type:                schema.NotificationTypeInbox,
+				ObjectID:            question.ID,
+				ObjectType:          constant.QuestionObjectType,
+				NotificationAction:  constant.NotificationUnansweredQuestion,
+				NoNeedPushAllFollow: true,
+			})
+		}
+	}
+	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
+		UserID:           question.UserID,
+		ObjectID:         question.ID,
+		OriginalObjectID: question.ID,
+		ActivityTypeKey:  constant.ActQuestionBumped,
+	})
+	return nil
+}
+
+func (ls *QuestionLifecycleService) closeQuestion(ctx context.Context, question *entity.Question,
+	rule *schema.QuestionLifecycleRule) (err error) {
+	err = QuestionCommonServicer.CloseQuestion(ctx, &schema.CloseQuestionReq{
+		ID:        question.ID,
+		CloseType: rule.CloseReasonType,
+		CloseMsg:  rule.CloseMsg,
+	})
+	if err != nil {
+		return err
+	}
+	glog.Slog.Infof("question %s is closed by the lifecycle rules", question.ID)
+	NotificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       question.UserID,
+		ReceiverUserID:      question.UserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            question.ID,
+		ObjectType:          constant.QuestionObjectType,
+		NotificationAction:  constant.NotificationYourQuestionIsClosed,
+		NoNeedPushAllFollow: true,
+	})
+	return nil
+}
+
+// markDone record the step of the question is done, done is true if it has been done before
+// or is being done by another worker at the same time
+func (ls *QuestionLifecycleService) markDone(ctx context.Context, questionID, key string) (done bool, err error) {
+	added, err := MetaService.AddMetaIfAbsent(ctx, questionID, key, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return false, err
+	}
+	return !added, nil
+}
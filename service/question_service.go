@@ -9,7 +9,6 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	entity "github.com/lawyer/commons/entity"
 	glog "github.com/lawyer/commons/logger"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/pkg/token"
 	"github.com/lawyer/repo"
@@ -47,11 +46,20 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 		return nil
 	}
 
+	before := &schema.ObjectStatusSnapshot{Status: questionInfo.Status}
 	questionInfo.Status = entity.QuestionStatusClosed
 	err = repo.QuestionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionQuestionClose,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     before,
+		After:      &schema.ObjectStatusSnapshot{Status: questionInfo.Status},
+	})
 
 	closeMeta, _ := json.Marshal(schema.CloseQuestionMeta{
 		CloseType: req.CloseType,
@@ -81,11 +89,20 @@ func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.Reope
 		return nil
 	}
 
+	before := &schema.ObjectStatusSnapshot{Status: questionInfo.Status}
 	questionInfo.Status = entity.QuestionStatusAvailable
 	err = repo.QuestionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionQuestionReopen,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     before,
+		After:      &schema.ObjectStatusSnapshot{Status: questionInfo.Status},
+	})
 	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
 		ObjectID:         questionInfo.ID,
@@ -222,6 +239,15 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	question.LastEditUserID = "0"
 	//question.PostUpdateTime = nil
 	question.Status = entity.QuestionStatusAvailable
+	spamResult := SpamServicer.Score(ctx, &schema.SpamCheckReq{
+		UserID:     req.UserID,
+		ObjectType: constant.QuestionObjectType,
+		Title:      req.Title,
+		Content:    req.Content,
+	})
+	if spamResult.Hold || PreModerationServicer.NeedPreModeration(ctx, req.UserID, "") {
+		question.Status = entity.QuestionStatusPending
+	}
 	question.RevisionID = "0"
 	question.CreatedAt = now
 	question.PostUpdateTime = now
@@ -232,6 +258,7 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	if err != nil {
 		return
 	}
+	SpamServicer.Record(ctx, question.ID, constant.QuestionObjectType, question.UserID, spamResult)
 	objectTagData := schema.TagChange{}
 	objectTagData.ObjectID = question.ID
 	objectTagData.Tags = req.Tags
@@ -269,6 +296,18 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		}
 	}
 
+	// the pending question is published when it is approved
+	if question.Status == entity.QuestionStatusAvailable {
+		qs.publishQuestion(ctx, question, tags, revisionID)
+	}
+
+	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
+	return
+}
+
+// publishQuestion send the activity and the notification of the new question
+func (qs *QuestionService) publishQuestion(ctx context.Context, question *entity.Question, tags []*entity.Tag,
+	revisionID string) {
 	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
 		UserID:           question.UserID,
 		ObjectID:         question.ID,
@@ -279,9 +318,29 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 
 	ExternalNotificationQueueService.Send(ctx,
 		schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, tags))
+}
 
-	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
-	return
+// PublishPendingQuestion publish the question approved by the moderator
+func (qs *QuestionService) PublishPendingQuestion(ctx context.Context, question *entity.Question) (err error) {
+	err = repo.QuestionRepo.UpdateQuestionStatus(ctx, question.ID, entity.QuestionStatusAvailable)
+	if err != nil {
+		return err
+	}
+	userQuestionCount, err := QuestionCommonServicer.GetUserQuestionCount(ctx, question.UserID)
+	if err != nil {
+		glog.Slog.Errorf("get user question count error %v", err)
+	} else {
+		err = UserCommonServicer.UpdateQuestionCount(ctx, question.UserID, userQuestionCount)
+		if err != nil {
+			glog.Slog.Errorf("update user question count error %v", err)
+		}
+	}
+	tags, err := TagServicer.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		return err
+	}
+	qs.publishQuestion(ctx, question, tags, question.RevisionID)
+	return nil
 }
 
 // OperationQuestion
@@ -301,6 +360,7 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if questionInfo.Pin == entity.QuestionPin && req.Operation == schema.QuestionOperationHide {
 		return nil
 	}
+	before := &schema.QuestionOperationSnapshot{Pin: questionInfo.Pin, Show: questionInfo.Show}
 
 	switch req.Operation {
 	case schema.QuestionOperationHide:
@@ -333,6 +393,14 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     questionOperationAuditActions[req.Operation],
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     before,
+		After:      &schema.QuestionOperationSnapshot{Pin: questionInfo.Pin, Show: questionInfo.Show},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.QuestionOperationPin] = constant.ActQuestionPin
@@ -352,6 +420,14 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	return nil
 }
 
+// questionOperationAuditActions the audit log actions of the question operations
+var questionOperationAuditActions = map[string]string{
+	schema.QuestionOperationPin:   entity.AuditActionQuestionPin,
+	schema.QuestionOperationUnPin: entity.AuditActionQuestionUnPin,
+	schema.QuestionOperationHide:  entity.AuditActionQuestionHide,
+	schema.QuestionOperationShow:  entity.AuditActionQuestionShow,
+}
+
 // RemoveQuestion delete question
 func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.RemoveQuestionReq) (err error) {
 	questionInfo, has, err := repo.QuestionRepo.GetQuestion(ctx, req.ID)
@@ -393,11 +469,23 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 		}
 	}
 
+	before := &schema.ObjectStatusSnapshot{Status: questionInfo.Status}
 	questionInfo.Status = entity.QuestionStatusDeleted
 	err = repo.QuestionRepo.UpdateQuestionStatusWithOutUpdateTime(ctx, questionInfo)
 	if err != nil {
 		return err
 	}
+	// the authors deleting their own questions are not moderation
+	if questionInfo.UserID != req.UserID {
+		AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+			UserID:     req.UserID,
+			Action:     entity.AuditActionQuestionDelete,
+			ObjectType: constant.QuestionObjectType,
+			ObjectID:   questionInfo.ID,
+			Before:     before,
+			After:      &schema.ObjectStatusSnapshot{Status: questionInfo.Status},
+		})
+	}
 
 	userQuestionCount, err := QuestionCommonServicer.GetUserQuestionCount(ctx, questionInfo.UserID)
 	if err != nil {
@@ -525,6 +613,14 @@ func (qs *QuestionService) RecoverQuestion(ctx context.Context, req *schema.Ques
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionQuestionRecover,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     &schema.ObjectStatusSnapshot{Status: questionInfo.Status},
+		After:      &schema.ObjectStatusSnapshot{Status: entity.QuestionStatusAvailable},
+	})
 
 	// update user's question count
 	userQuestionCount, err := QuestionCommonServicer.GetUserQuestionCount(ctx, questionInfo.UserID)
@@ -837,6 +933,22 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 	return
 }
 
+// isQuestionVisible the author can always view the question. If the question is deleted, only the users who
+// can reopen it can view it. If the question is pending, only the users who can view the pending posts can view it.
+func (qs *QuestionService) isQuestionVisible(ctx context.Context, questionID, authorID string, status int,
+	userID string, canReopen bool) bool {
+	if len(userID) > 0 && authorID == userID {
+		return true
+	}
+	switch status {
+	case entity.QuestionStatusDeleted:
+		return canReopen
+	case entity.QuestionStatusPending:
+		return PreModerationServicer.CanViewPendingPost(ctx, userID, questionID)
+	}
+	return true
+}
+
 // GetQuestion get question one
 func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID string,
 	per schema.QuestionPermission) (resp *schema.QuestionInfo, err error) {
@@ -846,8 +958,7 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 	if err != nil {
 		return
 	}
-	// If the question is deleted, only the administrator and the author can view it
-	if question.Status == entity.QuestionStatusDeleted && !per.CanReopen && question.UserID != userID {
+	if !qs.isQuestionVisible(ctx, question.ID, question.UserID, question.Status, userID, per.CanReopen) {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
 	//问题没关闭
@@ -882,6 +993,12 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		operation.Level = schema.OperationLevelDanger
 		question.Operation = operation
 	}
+	if question.Status == entity.QuestionStatusPending {
+		question.Operation = &schema.Operation{
+			Msg:   translator.Tr(utils.GetLangByCtx(ctx), reason.PostPendingReview),
+			Level: schema.OperationLevelInfo,
+		}
+	}
 
 	question.Description = htmltext.FetchExcerpt(question.HTML, "...", 240)
 	question.MemberActions = permission.GetQuestionPermission(ctx, userID, question.UserID, question.Status,
@@ -892,15 +1009,23 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 	return question, nil
 }
 
-// GetQuestionAndAddPV get question one
+// GetQuestionAndAddPV get question one, the view is counted when the question is found
 func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID string,
-	per schema.QuestionPermission) (resp *schema.QuestionInfo, err error) {
-
-	err = QuestionCommonServicer.UpdatePv(ctx, questionID)
+	per schema.QuestionPermission, view *schema.AddQuestionViewReq) (resp *schema.QuestionInfo, err error) {
+	resp, err = qs.GetQuestion(ctx, questionID, loginUserID, per)
 	if err != nil {
+		return nil, err
+	}
+	if err := QuestionViewServicer.AddView(ctx, view); err != nil {
 		log.Error(err)
 	}
-	return qs.GetQuestion(ctx, questionID, loginUserID, per)
+	return resp, nil
+}
+
+// GetQuestionViewTrend get the daily views of the question
+func (qs *QuestionService) GetQuestionViewTrend(ctx context.Context, req *schema.GetQuestionViewTrendReq) (
+	resp []*schema.QuestionDailyView, err error) {
+	return QuestionViewServicer.GetViewTrend(ctx, req)
 }
 
 func (qs *QuestionService) InviteUserInfo(ctx context.Context, questionID string) (inviteList []*schema.UserBasicInfo, err error) {
@@ -1223,6 +1348,14 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionQuestionUpdateStatus,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     &schema.ObjectStatusSnapshot{Status: questionInfo.Status},
+		After:      &schema.ObjectStatusSnapshot{Status: setStatus},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuestionStatusDeleted {
@@ -1366,7 +1499,11 @@ func (qs *QuestionService) changeQuestionToRevision(ctx context.Context, questio
 }
 
 func (qs *QuestionService) SitemapCron(ctx context.Context) {
-	siteSeo := site.Config.GetSiteSeo()
+	siteSeo, err := SiteInfoServicer.GetSiteSeo(ctx)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
 	ctx = context.WithValue(ctx, constant.ShortIDFlag, siteSeo.IsShortLink())
 	QuestionCommonServicer.SitemapCron(ctx)
 }
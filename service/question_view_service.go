@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/pkg/day"
+	"github.com/lawyer/pkg/uid"
+	"github.com/lawyer/pkg/useragent"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// questionViewTrendMaxDays the max days of the view trend to query
+const questionViewTrendMaxDays = 366
+
+// QuestionViewRepo question view repository
+type QuestionViewRepo interface {
+	AddView(ctx context.Context, questionID, visitor, date string) (added bool, err error)
+	TakePendingViews(ctx context.Context) (views, uniqueViews map[string]int64, err error)
+	RestorePendingViews(ctx context.Context, questionID string, views, uniqueViews int64) (err error)
+	GetDailyViews(ctx context.Context, date string) (statList []*entity.QuestionDailyViewStat, err error)
+	SaveDailyViewStat(ctx context.Context, statList []*entity.QuestionDailyViewStat) (err error)
+	GetDailyViewStatList(ctx context.Context, questionID, startDate, endDate string) (
+		statList []*entity.QuestionDailyViewStat, err error)
+}
+
+// QuestionViewService count the views of the questions. The views of the crawlers are dropped, the repeated views
+// of the same visitor within the dedup window are counted once, and the counters are flushed to the database by the cron.
+type QuestionViewService struct {
+}
+
+// NewQuestionViewService new question view service
+func NewQuestionViewService() *QuestionViewService {
+	return &QuestionViewService{}
+}
+
+// AddView count the view of the question
+func (vs *QuestionViewService) AddView(ctx context.Context, req *schema.AddQuestionViewReq) (err error) {
+	if useragent.IsCrawler(req.UserAgent) {
+		return nil
+	}
+	visitor := "ip:" + req.IP
+	if len(req.UserID) > 0 {
+		visitor = "user:" + req.UserID
+	} else if len(req.IP) == 0 {
+		return nil
+	}
+	date := time.Now().Format(day.DateLayout)
+	_, err = repo.QuestionViewRepo.AddView(ctx, uid.DeShortID(req.QuestionID), visitor, date)
+	return err
+}
+
+// FlushCron flush the counted views to the questions and the daily view statistics, it is called by the cron
+func (vs *QuestionViewService) FlushCron(ctx context.Context) {
+	views, uniqueViews, err := repo.QuestionViewRepo.TakePendingViews(ctx)
+	if err != nil {
+		glog.Slog.Errorf("take pending question views failed: %s", err)
+		return
+	}
+	for questionID, count := range views {
+		uniqueCount := uniqueViews[questionID]
+		if err = repo.QuestionRepo.IncrViewCount(ctx, questionID, count, uniqueCount); err == nil {
+			continue
+		}
+		glog.Slog.Errorf("flush views of question %s failed: %s", questionID, err)
+		if err = repo.QuestionViewRepo.RestorePendingViews(ctx, questionID, count, uniqueCount); err != nil {
+			glog.Slog.Errorf("restore views of question %s failed: %s", questionID, err)
+		}
+	}
+
+	// the views of the last minutes of yesterday are flushed after midnight
+	today := time.Now()
+	for _, date := range []time.Time{today.AddDate(0, 0, -1), today} {
+		dateStr := date.Format(day.DateLayout)
+		statList, err := repo.QuestionViewRepo.GetDailyViews(ctx, dateStr)
+		if err != nil {
+			glog.Slog.Errorf("get question views of %s failed: %s", dateStr, err)
+			continue
+		}
+		if len(statList) == 0 {
+			continue
+		}
+		if err = repo.QuestionViewRepo.SaveDailyViewStat(ctx, statList); err != nil {
+			glog.Slog.Errorf("save question views of %s failed: %s", dateStr, err)
+		}
+	}
+}
+
+// GetViewTrend get the daily views of the question, the days without views are filled with zero
+func (vs *QuestionViewService) GetViewTrend(ctx context.Context, req *schema.GetQuestionViewTrendReq) (
+	resp []*schema.QuestionDailyView, err error) {
+	dates, err := day.Dates(req.StartDate, req.EndDate, time.Local)
+	if err != nil || len(dates) > questionViewTrendMaxDays {
+		return nil, errors.BadRequest(reason.QuestionViewDateRangeInvalid)
+	}
+	questionID := uid.DeShortID(req.QuestionID)
+	_, exist, err := repo.QuestionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+
+	statList, err := repo.QuestionViewRepo.GetDailyViewStatList(ctx, questionID, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	statMapping := make(map[string]*entity.QuestionDailyViewStat, len(statList))
+	for _, stat := range statList {
+		statMapping[stat.Date] = stat
+	}
+	resp = make([]*schema.QuestionDailyView, 0, len(dates))
+	for _, date := range dates {
+		item := &schema.QuestionDailyView{Date: date.Format(day.DateLayout)}
+		if stat := statMapping[item.Date]; stat != nil {
+			item.ViewCount = stat.ViewCount
+			item.UniqueViewCount = stat.UniqueViewCount
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
@@ -30,6 +30,9 @@ type UserRankRepo interface {
 		userID string, userCurrentScore, deltaRank int) (err error)
 	TriggerUserRank(ctx context.Context, session *xorm.Session, userId string, rank int, activityType int) (isReachStandard bool, err error)
 	UserRankPage(ctx context.Context, userId string, page, pageSize int) (rankPage []*entity.Activity, total int64, err error)
+	GetUserRankActivityList(ctx context.Context, userID string) (activityList []*entity.Activity, err error)
+	GetUserRankList(ctx context.Context, page, pageSize int) (userList []*entity.User, err error)
+	SetUserRank(ctx context.Context, userID string, rank int) (err error)
 }
 
 // RankServicer rank service
@@ -71,6 +74,10 @@ func (rs *RankService) CheckOperationPermission(ctx context.Context, userID stri
 			objectInfo.ObjectCreatorUserID == userID {
 			return true, nil
 		}
+		// if the user is the moderator of the tags of this object, the user can operate this object.
+		if UserRoleRelServicer.GetUserScopedPowerMapping(ctx, userID, objectID)[action] {
+			return true, nil
+		}
 	}
 
 	can, _ = rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
@@ -116,6 +123,31 @@ func (rs *RankService) CheckOperationPermissions(ctx context.Context, userID str
 	return can, err
 }
 
+// CheckObjectPermission check whether the user can do the action on the object,
+// unlike CheckOperationPermission the object creator has no special treatment.
+func (rs *RankService) CheckObjectPermission(ctx context.Context, userID, action, objectID string) (
+	can bool, err error) {
+	can, err = rs.CheckOperationPermission(ctx, userID, action, "")
+	if err != nil || can {
+		return can, err
+	}
+	return UserRoleRelServicer.GetUserScopedPowerMapping(ctx, userID, objectID)[action], nil
+}
+
+// CheckObjectPermissions check whether the user can do the actions on the object
+func (rs *RankService) CheckObjectPermissions(ctx context.Context, userID, objectID string, actions []string) (
+	can []bool, err error) {
+	can, err = rs.CheckOperationPermissions(ctx, userID, actions)
+	if err != nil {
+		return can, err
+	}
+	scopedPowerMapping := UserRoleRelServicer.GetUserScopedPowerMapping(ctx, userID, objectID)
+	for i, action := range actions {
+		can[i] = can[i] || scopedPowerMapping[action]
+	}
+	return can, nil
+}
+
 // CheckOperationObjectOwner check operation object owner
 func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, objectID string) bool {
 	objectID = uid.DeShortID(objectID)
@@ -179,6 +211,22 @@ func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID
 	return can, needRank, nil
 }
 
+// CheckUserPower check whether the role of user has the power, the rank of user is not considered
+func (rs *RankService) CheckUserPower(ctx context.Context, userID string, power string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	return rs.getUserPowerMapping(ctx, userID)[power]
+}
+
+// CheckObjectPower check whether the user has the power on the object, by the role or by the tag scoped roles
+func (rs *RankService) CheckObjectPower(ctx context.Context, userID, power, objectID string) bool {
+	if rs.CheckUserPower(ctx, userID, power) {
+		return true
+	}
+	return UserRoleRelServicer.GetUserScopedPowerMapping(ctx, userID, objectID)[power]
+}
+
 // getUserPowerMapping get user power mapping
 func (rs *RankService) getUserPowerMapping(ctx context.Context, userID string) (powerMapping map[string]bool) {
 	powerMapping = make(map[string]bool, 0)
@@ -12,6 +12,7 @@ import (
 	"github.com/lawyer/commons/utils/pager"
 	"github.com/lawyer/pkg/htmltext"
 	"github.com/lawyer/repo"
+	"github.com/lawyer/service/permission"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -38,6 +39,17 @@ func (rs *ReportAdminService) ListReportPage(ctx context.Context, dto schema.Get
 		users map[string]*schema.UserBasicInfo
 	)
 
+	// the moderators of tags can only see the reports under their tags
+	if !RankServicer.CheckUserPower(ctx, dto.UserID, permission.ReportHandle) {
+		dto.ScopeTagIDs, err = UserRoleRelServicer.GetUserScopedTagIDs(ctx, dto.UserID, permission.ReportHandle)
+		if err != nil {
+			return nil, err
+		}
+		if len(dto.ScopeTagIDs) == 0 {
+			return nil, errors.Forbidden(reason.ReportNoPermission)
+		}
+	}
+
 	flags, total, err = repo.ReportRepo.GetReportListPage(ctx, dto)
 	if err != nil {
 		return
@@ -91,6 +103,11 @@ func (rs *ReportAdminService) HandleReported(ctx context.Context, req schema.Rep
 		return
 	}
 
+	if !RankServicer.CheckObjectPower(ctx, req.UserID, permission.ReportHandle, reported.ObjectID) {
+		err = errors.Forbidden(reason.ReportNoPermission)
+		return
+	}
+
 	// check if handle or not
 	if reported.Status != entity.ReportStatusPending {
 		return
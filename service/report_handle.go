@@ -61,7 +61,23 @@ func (rh *ReportHandle) HandleObject(ctx context.Context, reported *entity.Repor
 			rh.sendNotification(ctx, reportedUserID, objectID, constant.NotificationYourCommentWasDeleted)
 		}
 	}
-	return
+	if err != nil {
+		return err
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionReportHandle,
+		ObjectType: constant.ReportObjectType,
+		ObjectID:   reported.ID,
+		Before:     &schema.ReportHandleSnapshot{Status: reported.Status, ObjectID: objectID},
+		After: &schema.ReportHandleSnapshot{
+			Status:         entity.ReportStatusCompleted,
+			ObjectID:       objectID,
+			FlaggedType:    req.FlaggedType,
+			FlaggedContent: req.FlaggedContent,
+		},
+	})
+	return nil
 }
 
 // sendNotification send rank triggered notification
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/repo"
+	"github.com/lawyer/repoCommon"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ReputationPolicyRepo reputation policy repository
+type ReputationPolicyRepo interface {
+	GetReputationPolicy(ctx context.Context) (policy *schema.ReputationPolicy, err error)
+	SaveReputationPolicy(ctx context.Context, policy *schema.ReputationPolicy, userID string) (err error)
+	GetReputationPolicyVersionList(ctx context.Context) (policyList []*entity.ReputationPolicy, err error)
+}
+
+// ReputationPolicyService reputation policy service
+type ReputationPolicyService struct {
+}
+
+// NewReputationPolicyService new reputation policy service
+func NewReputationPolicyService() *ReputationPolicyService {
+	return &ReputationPolicyService{}
+}
+
+// GetReputationPolicy get the current reputation policy
+func (ps *ReputationPolicyService) GetReputationPolicy(ctx context.Context) (policy *schema.ReputationPolicy, err error) {
+	policy, err = repoCommon.NewReputationPolicyRepo().GetReputationPolicy(ctx)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return policy, nil
+}
+
+// UpdateReputationPolicy save the policy as a new version
+func (ps *ReputationPolicyService) UpdateReputationPolicy(ctx context.Context, req *schema.UpdateReputationPolicyReq) (
+	err error) {
+	for activityType := range req.Points {
+		if _, err = utils.GetConfigByKey(ctx, activityType); err != nil {
+			return errors.BadRequest(reason.ReputationActivityTypeInvalid)
+		}
+	}
+	for _, activityType := range req.DailyCapExclude {
+		if _, err = utils.GetConfigByKey(ctx, activityType); err != nil {
+			return errors.BadRequest(reason.ReputationActivityTypeInvalid)
+		}
+	}
+	if len(req.TagMultipliers) > 0 {
+		tagIDs := make([]string, 0, len(req.TagMultipliers))
+		for tagID := range req.TagMultipliers {
+			tagIDs = append(tagIDs, tagID)
+		}
+		tagList, err := repo.TagRepo.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return err
+		}
+		if len(tagList) != len(tagIDs) {
+			return errors.BadRequest(reason.TagNotFound)
+		}
+	}
+
+	oldPolicy, err := ps.GetReputationPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	// the version is given by the repository when the policy is saved
+	policy := &schema.ReputationPolicy{
+		Points:          req.Points,
+		DailyCap:        req.DailyCap,
+		DailyCapExclude: req.DailyCapExclude,
+		MinRank:         req.MinRank,
+		TagMultipliers:  req.TagMultipliers,
+	}
+	if err = repoCommon.NewReputationPolicyRepo().SaveReputationPolicy(ctx, policy, req.UserID); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionReputationPolicy,
+		ObjectType: "reputation_policy",
+		ObjectID:   strconv.Itoa(policy.Version),
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// GetReputationPolicyVersionList get all saved versions of the policy
+func (ps *ReputationPolicyService) GetReputationPolicyVersionList(ctx context.Context) (
+	resp []*schema.GetReputationPolicyVersionResp, err error) {
+	policyList, err := repoCommon.NewReputationPolicyRepo().GetReputationPolicyVersionList(ctx)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	userIDs := make([]string, 0, len(policyList))
+	for _, item := range policyList {
+		userIDs = append(userIDs, item.UserID)
+	}
+	userInfoMapping, err := UserCommonServicer.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.GetReputationPolicyVersionResp, 0, len(policyList))
+	for _, item := range policyList {
+		policy := &schema.ReputationPolicy{}
+		if err = json.Unmarshal([]byte(item.Content), policy); err != nil {
+			glog.Slog.Errorf("reputation policy version %d is not json format: %v", item.Version, err)
+			continue
+		}
+		resp = append(resp, &schema.GetReputationPolicyVersionResp{
+			Version:   item.Version,
+			CreatedAt: item.CreatedAt.Unix(),
+			UserInfo:  userInfoMapping[item.UserID],
+			Policy:    policy,
+		})
+	}
+	return resp, nil
+}
+
+// GetActivityRank get the rank of the activity on the object with the policy,
+// the tags of the object are only queried when the policy has tag multipliers
+func (ps *ReputationPolicyService) GetActivityRank(ctx context.Context, policy *schema.ReputationPolicy,
+	activityType string, defaultPoints int, objectID string) int {
+	var tagIDs []string
+	if len(policy.TagMultipliers) > 0 && len(objectID) > 0 && objectID != "0" {
+		var err error
+		tagIDs, err = ObjServicer.GetTagIDs(ctx, objectID)
+		if err != nil {
+			glog.Slog.Errorf("get tags of object %s failed: %v", objectID, err)
+		}
+	}
+	return policy.GetRank(activityType, defaultPoints, tagIDs)
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/commons/utils/pager"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/pkg/day"
+	"github.com/lawyer/pkg/obj"
+	"github.com/lawyer/plugin"
+	"github.com/lawyer/repo"
+	"github.com/lawyer/repo/activity"
+	"github.com/lawyer/repoCommon"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// reputationRecalculateBatchSize the number of users recalculated in one batch
+	reputationRecalculateBatchSize = 100
+	// userInitialRank the rank of the new user. The users added by the admin with the verified email get it when
+	// they are created, the registered users get it by the activation activity.
+	userInitialRank = 1
+)
+
+// ReputationService replay the activities to recalculate the rank of the users
+type ReputationService struct {
+}
+
+// NewReputationService new reputation service
+func NewReputationService() *ReputationService {
+	return &ReputationService{}
+}
+
+// reputationRules the current reputation policy with the cached points and tags used in replay
+type reputationRules struct {
+	policy *schema.ReputationPolicy
+	// activity type to the config key and the default points of the config
+	keyMapping    map[int]string
+	pointsMapping map[int]int
+	// object id to the tags of the object, only used when the policy has tag multipliers
+	tagMapping map[string][]string
+}
+
+// GetReputationLedger get the reputation ledger of the user, explain how each activity changed the rank
+func (rs *ReputationService) GetReputationLedger(ctx context.Context, req *schema.GetReputationLedgerReq) (
+	resp *schema.GetReputationLedgerResp, err error) {
+	if plugin.RankAgentEnabled() {
+		return nil, errors.BadRequest(reason.RankManagedByPlugin)
+	}
+	user, exist, err := repo.UserRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	userInfo := UserCommonServicer.FormatUserBasicInfo(ctx, user)
+	userInfo.Avatar = schema.FormatAvatar(user.Avatar, user.EMail, user.Status).GetURL()
+	rules, err := rs.getReputationRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, initialRank, calculatedRank, err := rs.replay(ctx, rules, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// the newest first
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	page, pageSize := pager.ValPageAndPageSize(req.Page, req.PageSize)
+	start, end := (page-1)*pageSize, page*pageSize
+	if start > len(entries) {
+		start = len(entries)
+	}
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return &schema.GetReputationLedgerResp{
+		UserInfo:       userInfo,
+		StoredRank:     userInfo.Rank,
+		InitialRank:    initialRank,
+		CalculatedRank: calculatedRank,
+		Count:          int64(len(entries)),
+		List:           entries[start:end],
+	}, nil
+}
+
+// RecalculateReputation recalculate the rank of one or all users and report the discrepancies,
+// the stored rank will be replaced by the recalculated rank if req.Fix is true
+func (rs *ReputationService) RecalculateReputation(ctx context.Context, req *schema.RecalculateReputationReq) (
+	resp *schema.RecalculateReputationResp, err error) {
+	if plugin.RankAgentEnabled() {
+		return nil, errors.BadRequest(reason.RankManagedByPlugin)
+	}
+	rules, err := rs.getReputationRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.RecalculateReputationResp{Discrepancies: make([]*schema.ReputationDiscrepancy, 0)}
+
+	if len(req.UserID) > 0 {
+		user, exist, err := repo.UserRepo.GetByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		if err = rs.recalculateUserRank(ctx, rules, user, req, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	for page := 1; ; page++ {
+		userList, err := repoCommon.NewUserRankRepo().GetUserRankList(ctx, page, reputationRecalculateBatchSize)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, user := range userList {
+			if err = rs.recalculateUserRank(ctx, rules, user, req, resp); err != nil {
+				return nil, err
+			}
+		}
+		if len(userList) < reputationRecalculateBatchSize {
+			break
+		}
+	}
+	glog.Slog.Infof("reputation recalculated, checked %d users, found %d discrepancies, fixed %d",
+		resp.CheckedCount, len(resp.Discrepancies), resp.FixedCount)
+	return resp, nil
+}
+
+func (rs *ReputationService) recalculateUserRank(ctx context.Context, rules *reputationRules,
+	user *entity.User, req *schema.RecalculateReputationReq, resp *schema.RecalculateReputationResp) (err error) {
+	_, _, calculatedRank, err := rs.replay(ctx, rules, user)
+	if err != nil {
+		return err
+	}
+	resp.CheckedCount++
+	if calculatedRank == user.Rank {
+		return nil
+	}
+	resp.Discrepancies = append(resp.Discrepancies, &schema.ReputationDiscrepancy{
+		UserID:         user.ID,
+		Username:       user.Username,
+		StoredRank:     user.Rank,
+		CalculatedRank: calculatedRank,
+		Difference:     calculatedRank - user.Rank,
+	})
+	if !req.Fix {
+		return nil
+	}
+	if err = repoCommon.NewUserRankRepo().SetUserRank(ctx, user.ID, calculatedRank); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	resp.FixedCount++
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.OperatorID,
+		Action:     entity.AuditActionUserUpdateRank,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   user.ID,
+		Before:     &schema.UserRankSnapshot{Rank: user.Rank},
+		After:      &schema.UserRankSnapshot{Rank: calculatedRank},
+	})
+	return nil
+}
+
+// replay the available activities of the user in order with the current rank rules, starting from the initial rank.
+// Same as the online rank change, the positive rank is ignored if the user reached the daily limit,
+// and the rank can not be reduced to lower than the min rank.
+func (rs *ReputationService) replay(ctx context.Context, rules *reputationRules, user *entity.User) (
+	entries []*schema.ReputationLedgerEntry, initialRank, rank int, err error) {
+	activityList, err := repoCommon.NewUserRankRepo().GetUserRankActivityList(ctx, user.ID)
+	if err != nil {
+		return nil, 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	initialRank, err = rs.getInitialRank(ctx, user, activityList)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	rank = initialRank
+
+	entries = make([]*schema.ReputationLedgerEntry, 0, len(activityList))
+	dailyEarned := make(map[string]int)
+	for _, act := range activityList {
+		delta, err := rs.getActivityRank(ctx, rules, act)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		note := ""
+		date := act.CreatedAt.Format(day.DateLayout)
+		if delta > 0 && !rules.policy.IsDailyCapExcluded(rules.keyMapping[act.ActivityType]) &&
+			dailyEarned[date] >= rules.policy.DailyCap {
+			delta, note = 0, schema.ReputationLedgerNoteDailyLimit
+		} else if delta < 0 && rank+delta < rules.policy.MinRank {
+			delta, note = rules.policy.MinRank-rank, schema.ReputationLedgerNoteMinRank
+			// the rank which is already lower than the min rank is not raised
+			if delta > 0 {
+				delta = 0
+			}
+		}
+		dailyEarned[date] += delta
+		rank += delta
+
+		objectType, _ := obj.GetObjectTypeStrByObjectID(act.ObjectID)
+		entries = append(entries, &schema.ReputationLedgerEntry{
+			ActivityID:         act.ID,
+			CreatedAt:          act.CreatedAt.Unix(),
+			ActivityType:       rules.keyMapping[act.ActivityType],
+			ObjectID:           act.ObjectID,
+			ObjectType:         objectType,
+			TriggerUserID:      converter.IntToString(act.TriggerUserID),
+			RecordedReputation: act.Rank,
+			RuleVersion:        act.RuleVersion,
+			Reputation:         delta,
+			Balance:            rank,
+			Note:               note,
+		})
+	}
+	return entries, initialRank, rank, nil
+}
+
+// getInitialRank the users which are not activated yet and the users activated by themselves start from zero
+func (rs *ReputationService) getInitialRank(ctx context.Context, user *entity.User, activityList []*entity.Activity) (
+	initialRank int, err error) {
+	if user.MailStatus == entity.EmailStatusToBeVerified {
+		return 0, nil
+	}
+	cfg, err := utils.GetConfigByKey(ctx, activity.UserActivated)
+	if err != nil {
+		return 0, err
+	}
+	for _, act := range activityList {
+		if act.ActivityType == cfg.ID {
+			return 0, nil
+		}
+	}
+	return userInitialRank, nil
+}
+
+func (rs *ReputationService) getReputationRules(ctx context.Context) (rules *reputationRules, err error) {
+	policy, err := ReputationPolicyServicer.GetReputationPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &reputationRules{
+		policy:        policy,
+		keyMapping:    make(map[int]string),
+		pointsMapping: make(map[int]int),
+		tagMapping:    make(map[string][]string),
+	}, nil
+}
+
+// getActivityRank get the rank of the activity with the current policy
+func (rs *ReputationService) getActivityRank(ctx context.Context, rules *reputationRules, act *entity.Activity) (
+	rank int, err error) {
+	if _, ok := rules.keyMapping[act.ActivityType]; !ok {
+		cfg, err := utils.GetConfigByID(ctx, act.ActivityType)
+		if err != nil {
+			return 0, err
+		}
+		rules.keyMapping[act.ActivityType] = cfg.Key
+		rules.pointsMapping[act.ActivityType] = cfg.GetIntValue()
+	}
+
+	var tagIDs []string
+	if len(rules.policy.TagMultipliers) > 0 && len(act.ObjectID) > 0 && act.ObjectID != "0" {
+		var ok bool
+		if tagIDs, ok = rules.tagMapping[act.ObjectID]; !ok {
+			tagIDs, err = ObjServicer.GetTagIDs(ctx, act.ObjectID)
+			if err != nil {
+				glog.Slog.Errorf("get tags of object %s failed: %v", act.ObjectID, err)
+			}
+			rules.tagMapping[act.ObjectID] = tagIDs
+		}
+	}
+	return rules.policy.GetRank(rules.keyMapping[act.ActivityType], rules.pointsMapping[act.ActivityType], tagIDs), nil
+}
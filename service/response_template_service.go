@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/pkg/display"
+	"github.com/lawyer/pkg/uid"
+	"github.com/lawyer/repo"
+	"github.com/lawyer/service/permission"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ResponseTemplateRepo response template repository
+type ResponseTemplateRepo interface {
+	AddTemplate(ctx context.Context, template *entity.ResponseTemplate) (err error)
+	UpdateTemplate(ctx context.Context, template *entity.ResponseTemplate) (err error)
+	RemoveTemplate(ctx context.Context, templateID int64) (err error)
+	GetTemplate(ctx context.Context, templateID int64) (template *entity.ResponseTemplate, exist bool, err error)
+	GetTemplateList(ctx context.Context, userID, scope string) (templateList []*entity.ResponseTemplate, err error)
+	AddTemplateUse(ctx context.Context, use *entity.ResponseTemplateUse) (err error)
+}
+
+// ResponseTemplateService the canned responses of the users and the site
+type ResponseTemplateService struct {
+}
+
+// NewResponseTemplateService new response template service
+func NewResponseTemplateService() *ResponseTemplateService {
+	return &ResponseTemplateService{}
+}
+
+// GetTemplateList get the site-wide templates and the templates of the user
+func (ts *ResponseTemplateService) GetTemplateList(ctx context.Context, req *schema.GetResponseTemplateListReq) (
+	resp []*schema.GetResponseTemplateResp, err error) {
+	templateList, err := repo.ResponseTemplateRepo.GetTemplateList(ctx, req.UserID, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	canEditSiteWide := ts.canManageSiteWide(ctx, req.UserID)
+	resp = make([]*schema.GetResponseTemplateResp, 0, len(templateList))
+	for _, template := range templateList {
+		siteWide := template.UserID == "0"
+		resp = append(resp, &schema.GetResponseTemplateResp{
+			ID:       template.ID,
+			Title:    template.Title,
+			Content:  template.Content,
+			Scope:    template.Scope,
+			SiteWide: siteWide,
+			UseCount: template.UseCount,
+			CanEdit:  (siteWide && canEditSiteWide) || template.UserID == req.UserID,
+		})
+	}
+	return resp, nil
+}
+
+// AddTemplate add the template of the user, or the site-wide template by the user with the power
+func (ts *ResponseTemplateService) AddTemplate(ctx context.Context, req *schema.AddResponseTemplateReq) (err error) {
+	template := &entity.ResponseTemplate{
+		UserID:  req.UserID,
+		Title:   req.Title,
+		Content: req.Content,
+		Scope:   req.Scope,
+		Status:  entity.ResponseTemplateStatusAvailable,
+	}
+	if req.SiteWide {
+		if !ts.canManageSiteWide(ctx, req.UserID) {
+			return errors.Forbidden(reason.ResponseTemplateNoPermission)
+		}
+		template.UserID = "0"
+	}
+	return repo.ResponseTemplateRepo.AddTemplate(ctx, template)
+}
+
+// UpdateTemplate update template
+func (ts *ResponseTemplateService) UpdateTemplate(ctx context.Context, req *schema.UpdateResponseTemplateReq) (
+	err error) {
+	if _, err = ts.getEditableTemplate(ctx, req.ID, req.UserID); err != nil {
+		return err
+	}
+	return repo.ResponseTemplateRepo.UpdateTemplate(ctx, &entity.ResponseTemplate{
+		ID:      req.ID,
+		Title:   req.Title,
+		Content: req.Content,
+		Scope:   req.Scope,
+	})
+}
+
+// RemoveTemplate remove template
+func (ts *ResponseTemplateService) RemoveTemplate(ctx context.Context, req *schema.RemoveResponseTemplateReq) (
+	err error) {
+	if _, err = ts.getEditableTemplate(ctx, req.ID, req.UserID); err != nil {
+		return err
+	}
+	return repo.ResponseTemplateRepo.RemoveTemplate(ctx, req.ID)
+}
+
+// RenderTemplate replace the variables in the template with the question
+func (ts *ResponseTemplateService) RenderTemplate(ctx context.Context, req *schema.RenderResponseTemplateReq) (
+	resp *schema.RenderResponseTemplateResp, err error) {
+	template, err := ts.getVisibleTemplate(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	questionInfo, exist, err := repo.QuestionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	// the same visibility as the question detail, the title of the hidden question is not leaked
+	canReopen := false
+	if questionInfo.Status == entity.QuestionStatusDeleted {
+		canReopen, err = RankServicer.CheckObjectPermission(ctx, req.UserID, permission.QuestionReopen, questionInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !QuestionServicer.isQuestionVisible(ctx, questionInfo.ID, questionInfo.UserID, questionInfo.Status,
+		req.UserID, canReopen) {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+
+	values := map[string]string{schema.ResponseTemplateVarQuestionTitle: questionInfo.Title}
+	askerInfo, exist, err := UserCommonServicer.GetUserBasicInfoByID(ctx, questionInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		values[schema.ResponseTemplateVarAskerName] = askerInfo.DisplayName
+	}
+	tagList, err := TagServicer.GetObjectEntityTag(ctx, questionInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	tagNames := make([]string, 0, len(tagList))
+	for _, tag := range tagList {
+		tagNames = append(tagNames, tag.DisplayName)
+	}
+	values[schema.ResponseTemplateVarQuestionTags] = strings.Join(tagNames, ", ")
+	siteInfo, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seo, err := SiteInfoServicer.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values[schema.ResponseTemplateVarQuestionLink] = display.QuestionURL(
+		seo.Permalink, siteInfo.SiteUrl, uid.DeShortID(questionInfo.ID), questionInfo.Title)
+	return &schema.RenderResponseTemplateResp{Content: converter.ReplaceVariables(template.Content, values)}, nil
+}
+
+// RecordTemplateUse record the template is used by the answer or the comment,
+// the template the user can not see is ignored
+func (ts *ResponseTemplateService) RecordTemplateUse(ctx context.Context, templateID int64,
+	userID, objectID, objectType string) {
+	if templateID == 0 {
+		return
+	}
+	if _, err := ts.getVisibleTemplate(ctx, templateID, userID); err != nil {
+		glog.Slog.Warnf("user %s used invalid response template %d: %v", userID, templateID, err)
+		return
+	}
+	err := repo.ResponseTemplateRepo.AddTemplateUse(ctx, &entity.ResponseTemplateUse{
+		TemplateID: templateID,
+		UserID:     userID,
+		ObjectID:   uid.DeShortID(objectID),
+		ObjectType: objectType,
+	})
+	if err != nil {
+		glog.Slog.Error(err)
+	}
+}
+
+// getVisibleTemplate get the site-wide template or the template of the user
+func (ts *ResponseTemplateService) getVisibleTemplate(ctx context.Context, templateID int64, userID string) (
+	template *entity.ResponseTemplate, err error) {
+	template, exist, err := repo.ResponseTemplateRepo.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || (template.UserID != "0" && template.UserID != userID) {
+		return nil, errors.BadRequest(reason.ResponseTemplateNotFound)
+	}
+	return template, nil
+}
+
+// getEditableTemplate get the template of the user, or the site-wide template if the user has the power
+func (ts *ResponseTemplateService) getEditableTemplate(ctx context.Context, templateID int64, userID string) (
+	template *entity.ResponseTemplate, err error) {
+	template, err = ts.getVisibleTemplate(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if template.UserID == "0" && !ts.canManageSiteWide(ctx, userID) {
+		return nil, errors.Forbidden(reason.ResponseTemplateNoPermission)
+	}
+	return template, nil
+}
+
+func (ts *ResponseTemplateService) canManageSiteWide(ctx context.Context, userID string) bool {
+	can, err := RankServicer.CheckOperationPermission(ctx, userID, permission.ResponseTemplateManage, "")
+	if err != nil {
+		glog.Slog.Error(err)
+		return false
+	}
+	return can
+}
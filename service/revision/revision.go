@@ -3,6 +3,7 @@ package revision
 import (
 	"context"
 	"github.com/lawyer/commons/entity"
+	"time"
 
 	"xorm.io/xorm"
 )
@@ -13,8 +14,12 @@ type RevisionRepo interface {
 	GetRevisionByID(ctx context.Context, revisionID string) (revision *entity.Revision, exist bool, err error)
 	GetLastRevisionByObjectID(ctx context.Context, objectID string) (revision *entity.Revision, exist bool, err error)
 	GetRevisionList(ctx context.Context, revision *entity.Revision) (revisionList []entity.Revision, err error)
+	GetPreviousApprovedRevision(ctx context.Context, objectID, revisionID string) (revision *entity.Revision, exist bool, err error)
 	UpdateObjectRevisionId(ctx context.Context, revision *entity.Revision, session *xorm.Session) (err error)
 	ExistUnreviewedByObjectID(ctx context.Context, objectID string) (revision *entity.Revision, exist bool, err error)
-	GetUnreviewedRevisionPage(ctx context.Context, page, pageSize int, objectTypes []int) ([]*entity.Revision, int64, error)
-	UpdateStatus(ctx context.Context, id string, status int, reviewUserID string) (err error)
+	GetUnreviewedRevisionPage(ctx context.Context, page, pageSize int, objectTypes []int, reviewerID string) ([]*entity.Revision, int64, error)
+	GetExpiredUnreviewedRevisionList(ctx context.Context, before time.Time, limit int) (revisionList []*entity.Revision, err error)
+	UpdateStatus(ctx context.Context, id string, fromStatus, status int, reviewUserID string) (updated bool, err error)
+	AddReview(ctx context.Context, review *entity.RevisionReview) (exist bool, err error)
+	GetReviewList(ctx context.Context, revisionID string) (reviewList []*entity.RevisionReview, err error)
 }
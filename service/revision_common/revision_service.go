@@ -25,7 +25,7 @@ func (rs *RevisionService) GetUnreviewedRevisionCount(ctx context.Context, req *
 	if len(req.GetCanReviewObjectTypes()) == 0 {
 		return 0, nil
 	}
-	_, count, err = repo.RevisionRepo.GetUnreviewedRevisionPage(ctx, req.Page, 1, req.GetCanReviewObjectTypes())
+	_, count, err = repo.RevisionRepo.GetUnreviewedRevisionPage(ctx, req.Page, 1, req.GetCanReviewObjectTypes(), req.UserID)
 	return count, err
 }
 
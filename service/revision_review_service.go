@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/repo"
+	"github.com/lawyer/service/permission"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// revisionExpireBatchSize the number of revisions expired in one batch
+	revisionExpireBatchSize = 100
+)
+
+// ReviewActivityRepo the reputation of reviewing the suggested edits
+type ReviewActivityRepo interface {
+	AddReviewActivity(ctx context.Context, activity *entity.Activity, policy *schema.ReputationPolicy,
+		dailyCapExcluded bool) (err error)
+}
+
+// RevisionReviewService the suggested edits are decided by the votes of many reviewers
+type RevisionReviewService struct {
+}
+
+// NewRevisionReviewService new revision review service
+func NewRevisionReviewService() *RevisionReviewService {
+	return &RevisionReviewService{}
+}
+
+// GetRevisionReviewPolicy get the suggested edits review rules
+func (rs *RevisionReviewService) GetRevisionReviewPolicy(ctx context.Context) (
+	policy *schema.RevisionReviewPolicy, err error) {
+	policy = &schema.RevisionReviewPolicy{RequiredApprovals: 1, RequiredRejections: 1}
+	cfg, err := utils.GetConfigByKey(ctx, entity.RevisionReviewConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Value) == 0 {
+		return policy, nil
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+		return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
+	return policy, nil
+}
+
+// UpdateRevisionReviewPolicy update the suggested edits review rules
+func (rs *RevisionReviewService) UpdateRevisionReviewPolicy(ctx context.Context,
+	req *schema.UpdateRevisionReviewPolicyReq) (err error) {
+	oldPolicy, err := rs.GetRevisionReviewPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &req.RevisionReviewPolicy
+	content, _ := json.Marshal(policy)
+	if err = utils.UpdateConfig(ctx, entity.RevisionReviewConfigKey, string(content)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionRevisionReview,
+		ObjectType: "revision_review",
+		ObjectID:   entity.RevisionReviewConfigKey,
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// Vote record the vote of the reviewer and award the reviewer, the decided operation is returned when the revision
+// gets enough approvals or rejections, the vote of the user with the audit power decides the revision at once.
+// empty operation means the revision is still waiting for more votes.
+func (rs *RevisionReviewService) Vote(ctx context.Context, revision *entity.Revision, req *schema.RevisionAuditReq) (
+	operation string, err error) {
+	if revision.UserID == req.UserID {
+		return "", errors.BadRequest(reason.RevisionCannotReviewOwn)
+	}
+	exist, err := repo.RevisionRepo.AddReview(ctx, &entity.RevisionReview{
+		RevisionID: revision.ID,
+		UserID:     req.UserID,
+		Operation:  req.Operation,
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		return "", err
+	}
+	if exist {
+		return "", errors.BadRequest(reason.RevisionAlreadyReviewed)
+	}
+	rs.awardReviewer(ctx, revision, req.UserID)
+
+	if rs.isBindingReviewer(ctx, revision, req.UserID) {
+		return req.Operation, nil
+	}
+	policy, err := rs.GetRevisionReviewPolicy(ctx)
+	if err != nil {
+		return "", err
+	}
+	reviewList, err := repo.RevisionRepo.GetReviewList(ctx, revision.ID)
+	if err != nil {
+		return "", err
+	}
+	approvals, rejections := 0, 0
+	for _, review := range reviewList {
+		switch review.Operation {
+		case schema.RevisionAuditApprove:
+			approvals++
+		case schema.RevisionAuditReject:
+			rejections++
+		}
+	}
+	if rejections >= policy.RequiredRejections {
+		return schema.RevisionAuditReject, nil
+	}
+	if approvals >= policy.RequiredApprovals {
+		return schema.RevisionAuditApprove, nil
+	}
+	return "", nil
+}
+
+// GetReviewInfoList get the votes of the revision with the reviewers
+func (rs *RevisionReviewService) GetReviewInfoList(ctx context.Context, revisionID string) (
+	infoList []*schema.RevisionReviewInfo, err error) {
+	reviewList, err := repo.RevisionRepo.GetReviewList(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(reviewList))
+	for _, review := range reviewList {
+		userIDs = append(userIDs, review.UserID)
+	}
+	userInfoMapping, err := UserCommonServicer.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	infoList = make([]*schema.RevisionReviewInfo, 0, len(reviewList))
+	for _, review := range reviewList {
+		infoList = append(infoList, &schema.RevisionReviewInfo{
+			UserInfo:  userInfoMapping[review.UserID],
+			Operation: review.Operation,
+			Comment:   review.Comment,
+			CreatedAt: review.CreatedAt.Unix(),
+		})
+	}
+	return infoList, nil
+}
+
+// ExpireCron expire the suggested edits which are not decided in time
+func (rs *RevisionReviewService) ExpireCron(ctx context.Context) {
+	policy, err := rs.GetRevisionReviewPolicy(ctx)
+	if err != nil {
+		glog.Slog.Errorf("get revision review policy failed: %v", err)
+		return
+	}
+	if policy.ExpireDays <= 0 {
+		return
+	}
+
+	before := time.Now().AddDate(0, 0, -policy.ExpireDays)
+	for {
+		revisionList, err := repo.RevisionRepo.GetExpiredUnreviewedRevisionList(ctx, before, revisionExpireBatchSize)
+		if err != nil {
+			glog.Slog.Errorf("get expired revision list failed: %v", err)
+			return
+		}
+		for _, revision := range revisionList {
+			_, err = repo.RevisionRepo.UpdateStatus(ctx, revision.ID, entity.RevisionUnreviewedStatus,
+				entity.RevisionReviewExpiredStatus, "0")
+			if err != nil {
+				glog.Slog.Errorf("expire revision %s failed: %v", revision.ID, err)
+				return
+			}
+		}
+		if len(revisionList) < revisionExpireBatchSize {
+			break
+		}
+	}
+}
+
+// isBindingReviewer the vote of the user with the audit power on the object is binding, such as the admin,
+// the moderator and the moderator of its tags. The users who can review by the rank only vote.
+func (rs *RevisionReviewService) isBindingReviewer(ctx context.Context, revision *entity.Revision, userID string) bool {
+	power, ok := map[string]string{
+		constant.QuestionObjectType: permission.QuestionAudit,
+		constant.AnswerObjectType:   permission.AnswerAudit,
+		constant.TagObjectType:      permission.TagAudit,
+	}[constant.ObjectTypeNumberMapping[revision.ObjectType]]
+	if !ok {
+		return false
+	}
+	return RankServicer.CheckObjectPower(ctx, userID, power, revision.ObjectID)
+}
+
+// awardReviewer the reviewer gets the reputation once per revision
+func (rs *RevisionReviewService) awardReviewer(ctx context.Context, revision *entity.Revision, userID string) {
+	cfg, err := utils.GetConfigByKey(ctx, constant.RevisionReviewed)
+	if err != nil {
+		glog.Slog.Errorf("get config by key error: %v", err)
+		return
+	}
+	policy, err := ReputationPolicyServicer.GetReputationPolicy(ctx)
+	if err != nil {
+		glog.Slog.Warnf("get reputation policy error: %v", err)
+		policy = &schema.ReputationPolicy{}
+	}
+	activity := &entity.Activity{
+		UserID:           userID,
+		ObjectID:         revision.ObjectID,
+		OriginalObjectID: revision.ObjectID,
+		ActivityType:     cfg.ID,
+		Rank:             ReputationPolicyServicer.GetActivityRank(ctx, policy, cfg.Key, cfg.GetIntValue(), revision.ObjectID),
+		RevisionID:       converter.StringToInt64(revision.ID),
+		RuleVersion:      policy.Version,
+	}
+	err = repo.ReviewActivityRepo.AddReviewActivity(ctx, activity, policy, policy.IsDailyCapExcluded(cfg.Key))
+	if err != nil {
+		glog.Slog.Error(err)
+	}
+}
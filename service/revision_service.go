@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	constant "github.com/lawyer/commons/constant"
 	"github.com/lawyer/commons/constant/reason"
 	entity "github.com/lawyer/commons/entity"
@@ -14,7 +15,9 @@ import (
 	"github.com/lawyer/commons/schema"
 	"github.com/lawyer/commons/utils/pager"
 	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/pkg/diff"
 	"github.com/lawyer/pkg/obj"
+	"github.com/lawyer/service/permission"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -37,14 +40,29 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 	if revisioninfo.Status != entity.RevisionUnreviewedStatus {
 		return
 	}
-	if req.Operation == schema.RevisionAuditReject {
-		err = repo.RevisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewRejectStatus, req.UserID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(revisioninfo.ObjectID)
+	if err != nil {
+		return err
+	}
+	if !rs.canAuditRevision(ctx, req, objectType, revisioninfo.ObjectID) {
+		return errors.BadRequest(reason.RevisionNoPermission)
+	}
+	// the revision is decided when it gets enough votes
+	operation, err := RevisionReviewServicer.Vote(ctx, revisioninfo, req)
+	if err != nil {
+		return err
+	}
+	if operation == schema.RevisionAuditReject {
+		_, err = repo.RevisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionUnreviewedStatus,
+			entity.RevisionReviewRejectStatus, req.UserID)
 		return
 	}
-	if req.Operation == schema.RevisionAuditApprove {
-		objectType, objectTypeerr := obj.GetObjectTypeStrByObjectID(revisioninfo.ObjectID)
-		if objectTypeerr != nil {
-			return objectTypeerr
+	if operation == schema.RevisionAuditApprove {
+		// claim the decision first, the revision decided by another vote or expired is not applied again
+		claimed, err := repo.RevisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionUnreviewedStatus,
+			entity.RevisionReviewPassStatus, req.UserID)
+		if err != nil || !claimed {
+			return err
 		}
 		revisionitem := &schema.GetRevisionResp{}
 		_ = copier.Copy(revisionitem, revisioninfo)
@@ -52,34 +70,44 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 		var saveErr error
 		switch objectType {
 		case constant.QuestionObjectType:
-			if !req.CanReviewQuestion {
-				saveErr = errors.BadRequest(reason.RevisionNoPermission)
-			} else {
-				saveErr = rs.revisionAuditQuestion(ctx, revisionitem)
-			}
+			saveErr = rs.revisionAuditQuestion(ctx, revisionitem)
 		case constant.AnswerObjectType:
-			if !req.CanReviewAnswer {
-				saveErr = errors.BadRequest(reason.RevisionNoPermission)
-			} else {
-				saveErr = rs.revisionAuditAnswer(ctx, revisionitem)
-			}
+			saveErr = rs.revisionAuditAnswer(ctx, revisionitem)
 		case constant.TagObjectType:
-			if !req.CanReviewTag {
-				saveErr = errors.BadRequest(reason.RevisionNoPermission)
-			} else {
-				saveErr = rs.revisionAuditTag(ctx, revisionitem)
-			}
+			saveErr = rs.revisionAuditTag(ctx, revisionitem)
 		}
 		if saveErr != nil {
+			// give the revision back to the reviewers when it is not applied
+			if _, err = repo.RevisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewPassStatus,
+				entity.RevisionUnreviewedStatus, "0"); err != nil {
+				glog.Slog.Errorf("reset the status of revision %s failed: %v", req.ID, err)
+			}
 			return saveErr
 		}
-		err = repo.RevisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewPassStatus, req.UserID)
-		return
+		return nil
 	}
 
 	return nil
 }
 
+// canAuditRevision the global audit power comes from the request, the moderators of the tags can audit the
+// revisions of the questions, answers and tags under their tags.
+func (rs *RevisionService) canAuditRevision(ctx context.Context, req *schema.RevisionAuditReq,
+	objectType, objectID string) bool {
+	switch objectType {
+	case constant.QuestionObjectType:
+		return req.CanReviewQuestion ||
+			RankServicer.CheckObjectPower(ctx, req.UserID, permission.QuestionAudit, objectID)
+	case constant.AnswerObjectType:
+		return req.CanReviewAnswer ||
+			RankServicer.CheckObjectPower(ctx, req.UserID, permission.AnswerAudit, objectID)
+	case constant.TagObjectType:
+		return req.CanReviewTag ||
+			RankServicer.CheckObjectPower(ctx, req.UserID, permission.TagAudit, objectID)
+	}
+	return false
+}
+
 func (rs *RevisionService) revisionAuditQuestion(ctx context.Context, revisionitem *schema.GetRevisionResp) (err error) {
 	questioninfo, ok := revisionitem.ContentParsed.(*schema.QuestionInfo)
 	if ok {
@@ -239,8 +267,12 @@ func (rs *RevisionService) GetUnreviewedRevisionPage(ctx context.Context, req *s
 	if len(req.GetCanReviewObjectTypes()) == 0 {
 		return pager.NewPageModel(0, revisionResp), nil
 	}
+	policy, err := RevisionReviewServicer.GetRevisionReviewPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
 	revisionPage, total, err := repo.RevisionRepo.GetUnreviewedRevisionPage(
-		ctx, req.Page, 1, req.GetCanReviewObjectTypes())
+		ctx, req.Page, 1, req.GetCanReviewObjectTypes(), req.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +292,15 @@ func (rs *RevisionService) GetUnreviewedRevisionPage(ctx context.Context, req *s
 		_ = copier.Copy(revisionitem, rev)
 		rs.parseItem(ctx, revisionitem)
 		item.UnreviewedInfo = revisionitem
+		item.Diff, err = rs.diffWithPreviousRevision(ctx, rev)
+		if err != nil {
+			glog.Slog.Errorf("diff revision %s failed: %v", rev.ID, err)
+		}
+		item.Reviews, err = RevisionReviewServicer.GetReviewInfoList(ctx, rev.ID)
+		if err != nil {
+			return nil, err
+		}
+		item.RequiredApprovals, item.RequiredRejections = policy.RequiredApprovals, policy.RequiredRejections
 
 		// get user info
 		userInfo, exists, e := UserCommonServicer.GetUserBasicInfoByID(ctx, revisionitem.UserID)
@@ -338,7 +379,8 @@ func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisi
 		if err != nil {
 			break
 		}
-		answerInfo = AnswerServicer.ShowFormat(ctx, &answer)
+		answerInfo = AnswerServicer.ShowFormat(ctx, &answer,
+			AnswerDisclaimerServicer.GetQuestionDisclaimer(ctx, answer.QuestionID))
 		item.ContentParsed = answerInfo
 	case constant.ObjectTypeStrMapping["tag"]:
 		err = json.Unmarshal([]byte(item.Content), &tag)
@@ -368,6 +410,283 @@ func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisi
 	item.CreatedAtParsed = item.CreatedAt.Unix()
 }
 
+// GetRevisionDiff diff two revisions of the same object,
+// the unreviewed and rejected revisions can only be seen by the author and the reviewers
+func (rs *RevisionService) GetRevisionDiff(ctx context.Context, req *schema.GetRevisionDiffReq) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	newRevision, err := rs.getVisibleRevision(ctx, req, req.NewID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.OldID) == 0 {
+		return rs.diffWithPreviousRevision(ctx, newRevision)
+	}
+	oldRevision, err := rs.getVisibleRevision(ctx, req, req.OldID)
+	if err != nil {
+		return nil, err
+	}
+	if oldRevision.ObjectID != newRevision.ObjectID {
+		return nil, errors.BadRequest(reason.RevisionNotSameObject)
+	}
+	return rs.diffRevision(oldRevision, newRevision)
+}
+
+func (rs *RevisionService) getVisibleRevision(ctx context.Context, req *schema.GetRevisionDiffReq, revisionID string) (
+	revision *entity.Revision, err error) {
+	revision, exist, err := repo.RevisionRepo.GetRevision(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+	if revision.Status == entity.RevisioNnormalStatus || revision.Status == entity.RevisionReviewPassStatus ||
+		revision.UserID == req.UserID {
+		return revision, nil
+	}
+	switch constant.ObjectTypeNumberMapping[revision.ObjectType] {
+	case constant.QuestionObjectType:
+		if req.CanReviewQuestion {
+			return revision, nil
+		}
+	case constant.AnswerObjectType:
+		if req.CanReviewAnswer {
+			return revision, nil
+		}
+	case constant.TagObjectType:
+		if req.CanReviewTag {
+			return revision, nil
+		}
+	}
+	return nil, errors.Forbidden(reason.RevisionNoPermission)
+}
+
+// diffWithPreviousRevision diff the revision with the previous approved revision, with empty if it is the first one
+func (rs *RevisionService) diffWithPreviousRevision(ctx context.Context, revision *entity.Revision) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	oldRevision, exist, err := repo.RevisionRepo.GetPreviousApprovedRevision(ctx, revision.ObjectID, revision.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		oldRevision = nil
+	}
+	return rs.diffRevision(oldRevision, revision)
+}
+
+// diffRevision diff the title and the body word by word and the tags as set, old revision is nil means empty
+func (rs *RevisionService) diffRevision(oldRevision, newRevision *entity.Revision) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	newTitle, newBody, newTags, err := rs.parseDiffContent(newRevision)
+	if err != nil {
+		return nil, err
+	}
+	var oldTitle, oldBody string
+	oldTags := make([]string, 0)
+	resp = &schema.GetRevisionDiffResp{
+		ObjectID:   newRevision.ObjectID,
+		ObjectType: constant.ObjectTypeNumberMapping[newRevision.ObjectType],
+		NewID:      newRevision.ID,
+	}
+	if oldRevision != nil {
+		if oldTitle, oldBody, oldTags, err = rs.parseDiffContent(oldRevision); err != nil {
+			return nil, err
+		}
+		resp.OldID = oldRevision.ID
+	}
+	resp.Title = rs.diffText(oldTitle, newTitle)
+	resp.Body = rs.diffText(oldBody, newBody)
+	if resp.ObjectType == constant.QuestionObjectType {
+		tagDiff := &schema.RevisionTagDiff{}
+		tagDiff.Added, tagDiff.Removed, tagDiff.Kept = diff.Set(oldTags, newTags)
+		tagDiff.Changed = len(tagDiff.Added) > 0 || len(tagDiff.Removed) > 0
+		resp.Tags = tagDiff
+	}
+	return resp, nil
+}
+
+func (rs *RevisionService) diffText(oldText, newText string) *schema.RevisionTextDiff {
+	hunks := diff.Words(oldText, newText)
+	return &schema.RevisionTextDiff{
+		Changed: diff.Changed(hunks),
+		Hunks:   hunks,
+		HTML:    diff.SideBySideHTML(hunks),
+	}
+}
+
+// parseDiffContent get the title, the markdown body and the tag slug names from the revision snapshot
+func (rs *RevisionService) parseDiffContent(revision *entity.Revision) (title, body string, tags []string, err error) {
+	tags = make([]string, 0)
+	switch constant.ObjectTypeNumberMapping[revision.ObjectType] {
+	case constant.QuestionObjectType:
+		question := &entity.QuestionWithTagsRevision{}
+		if err = json.Unmarshal([]byte(revision.Content), question); err != nil {
+			return "", "", nil, err
+		}
+		for _, tag := range question.Tags {
+			tags = append(tags, tag.SlugName)
+		}
+		return question.Title, question.OriginalText, tags, nil
+	case constant.AnswerObjectType:
+		answer := &entity.Answer{}
+		if err = json.Unmarshal([]byte(revision.Content), answer); err != nil {
+			return "", "", nil, err
+		}
+		return "", answer.OriginalText, tags, nil
+	case constant.TagObjectType:
+		tag := &entity.Tag{}
+		if err = json.Unmarshal([]byte(revision.Content), tag); err != nil {
+			return "", "", nil, err
+		}
+		return tag.DisplayName, tag.OriginalText, tags, nil
+	}
+	return revision.Title, revision.Content, tags, nil
+}
+
+// RollbackRevision restore the published revision as a new revision of the object with the normal edit flow,
+// so the new revision is reviewed if the user can not edit without review
+func (rs *RevisionService) RollbackRevision(ctx context.Context, req *schema.RollbackRevisionReq) (
+	resp *schema.RollbackRevisionResp, err error) {
+	revision, exist, err := repo.RevisionRepo.GetRevision(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+	if revision.Status != entity.RevisioNnormalStatus && revision.Status != entity.RevisionReviewPassStatus {
+		return nil, errors.BadRequest(reason.RevisionCannotRollback)
+	}
+	objectType := constant.ObjectTypeNumberMapping[revision.ObjectType]
+	actions, ok := map[string][]string{
+		constant.QuestionObjectType: {permission.QuestionEdit, permission.QuestionEditWithoutReview},
+		constant.AnswerObjectType:   {permission.AnswerEdit, permission.AnswerEditWithoutReview},
+		constant.TagObjectType:      {permission.TagEdit, permission.TagEditWithoutReview},
+	}[objectType]
+	if !ok {
+		return nil, errors.BadRequest(reason.RevisionCannotRollback)
+	}
+	canList, err := RankServicer.CheckOperationPermissions(ctx, req.UserID,
+		append(actions, permission.TagUseReservedTag))
+	if err != nil {
+		return nil, err
+	}
+	// the edit permission on the object includes the owner and the moderator of its tags
+	canEdit, err := RankServicer.CheckOperationPermission(ctx, req.UserID, actions[0], revision.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	objectOwner := RankServicer.CheckOperationObjectOwner(ctx, req.UserID, revision.ObjectID)
+	noNeedReview := canList[1] || objectOwner
+	if len(req.EditSummary) == 0 {
+		req.EditSummary = fmt.Sprintf("Rollback to revision %s", revision.ID)
+	}
+
+	switch objectType {
+	case constant.QuestionObjectType:
+		err = rs.rollbackQuestion(ctx, req, revision, noNeedReview, canList[2])
+	case constant.AnswerObjectType:
+		err = rs.rollbackAnswer(ctx, req, revision, noNeedReview)
+	case constant.TagObjectType:
+		err = rs.rollbackTag(ctx, req, revision, noNeedReview)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if noNeedReview {
+		rs.notificationRollback(ctx, req.UserID, revision.ObjectID, objectType)
+	}
+	return &schema.RollbackRevisionResp{WaitForReview: !noNeedReview}, nil
+}
+
+// rollbackQuestion the tags of the revision are synced by the question update
+func (rs *RevisionService) rollbackQuestion(ctx context.Context, req *schema.RollbackRevisionReq,
+	revision *entity.Revision, noNeedReview, canUseReservedTag bool) (err error) {
+	question := &entity.QuestionWithTagsRevision{}
+	if err = json.Unmarshal([]byte(revision.Content), question); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	tags := make([]*schema.TagItem, 0, len(question.Tags))
+	for _, tag := range question.Tags {
+		tags = append(tags, &schema.TagItem{SlugName: tag.SlugName, DisplayName: tag.DisplayName})
+	}
+	updateReq := &schema.QuestionUpdate{
+		ID:           revision.ObjectID,
+		Title:        question.Title,
+		Content:      question.OriginalText,
+		HTML:         converter.Markdown2HTML(question.OriginalText),
+		Tags:         tags,
+		EditSummary:  req.EditSummary,
+		UserID:       req.UserID,
+		NoNeedReview: noNeedReview,
+	}
+	updateReq.CanEdit = true
+	updateReq.CanUseReservedTag = canUseReservedTag
+	_, err = QuestionServicer.UpdateQuestion(ctx, updateReq)
+	return err
+}
+
+func (rs *RevisionService) rollbackAnswer(ctx context.Context, req *schema.RollbackRevisionReq,
+	revision *entity.Revision, noNeedReview bool) (err error) {
+	answer := &entity.Answer{}
+	if err = json.Unmarshal([]byte(revision.Content), answer); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	_, err = AnswerServicer.Update(ctx, &schema.AnswerUpdateReq{
+		ID:           revision.ObjectID,
+		QuestionID:   answer.QuestionID,
+		Content:      answer.OriginalText,
+		HTML:         converter.Markdown2HTML(answer.OriginalText),
+		EditSummary:  req.EditSummary,
+		UserID:       req.UserID,
+		NoNeedReview: noNeedReview,
+		CanEdit:      true,
+	})
+	return err
+}
+
+func (rs *RevisionService) rollbackTag(ctx context.Context, req *schema.RollbackRevisionReq,
+	revision *entity.Revision, noNeedReview bool) (err error) {
+	tag := &entity.Tag{}
+	if err = json.Unmarshal([]byte(revision.Content), tag); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return TagServicer.UpdateTag(ctx, &schema.UpdateTagReq{
+		TagID:        revision.ObjectID,
+		SlugName:     tag.SlugName,
+		DisplayName:  tag.DisplayName,
+		OriginalText: tag.OriginalText,
+		ParsedText:   converter.Markdown2HTML(tag.OriginalText),
+		EditSummary:  req.EditSummary,
+		UserID:       req.UserID,
+		NoNeedReview: noNeedReview,
+	})
+}
+
+// notificationRollback notify the owner of the question or the answer, the tags have no owner
+func (rs *RevisionService) notificationRollback(ctx context.Context, userID, objectID, objectType string) {
+	objInfo, err := ObjServicer.GetInfo(ctx, objectID)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
+	if objInfo == nil || len(objInfo.ObjectCreatorUserID) == 0 || objInfo.ObjectCreatorUserID == userID {
+		return
+	}
+	NotificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       userID,
+		ReceiverUserID:      objInfo.ObjectCreatorUserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            objectID,
+		ObjectType:          objectType,
+		NotificationAction:  constant.NotificationYourPostWasRolledBack,
+		NoNeedPushAllFollow: true,
+	})
+}
+
 // CheckCanUpdateRevision can check revision
 func (rs *RevisionService) CheckCanUpdateRevision(ctx context.Context, req *schema.CheckCanQuestionUpdate) (
 	resp *schema.ErrTypeData, err error) {
@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
 	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
 )
 
 // RolePowerRelRepo rolePowerRel repository
 type RolePowerRelRepo interface {
 	GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error)
+	SaveRolePowerTypeList(ctx context.Context, roleID int, powerTypes []string) (err error)
+	GetRoleIDListByPowerType(ctx context.Context, powerTypes []string) (roleIDs []int, err error)
 }
 
 // RolePowerRelServicer user service
@@ -32,3 +38,67 @@ func (rs *RolePowerRelService) GetUserPowerList(ctx context.Context, userID stri
 	}
 	return repo.RolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
 }
+
+// GetRolePowerDetail get the powers of the role for admin
+func (rs *RolePowerRelService) GetRolePowerDetail(ctx context.Context, req *schema.GetRolePowerReq) (
+	resp *schema.GetRolePowerResp, err error) {
+	if err = RoleServicer.CheckRoleExist(ctx, req.RoleID); err != nil {
+		return nil, err
+	}
+	powers, err := repo.RolePowerRelRepo.GetRolePowerTypeList(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.GetRolePowerResp{RoleID: req.RoleID, PowerTypes: powers}, nil
+}
+
+// UpdateRolePowerList replace the powers of the role.
+// The powers of admin role can not be changed, otherwise the site may lose its administrator.
+func (rs *RolePowerRelService) UpdateRolePowerList(ctx context.Context, req *schema.UpdateRolePowerReq) (err error) {
+	if req.RoleID == RoleAdminID {
+		return errors.BadRequest(reason.RoleCannotUpdateBuiltIn)
+	}
+	if err = RoleServicer.CheckRoleExist(ctx, req.RoleID); err != nil {
+		return err
+	}
+	powerTypes, err := rs.checkPowerTypes(ctx, req.PowerTypes)
+	if err != nil {
+		return err
+	}
+	return repo.RolePowerRelRepo.SaveRolePowerTypeList(ctx, req.RoleID, powerTypes)
+}
+
+// GetRoleIDListByPowerType get the roles which have any of the powers
+func (rs *RolePowerRelService) GetRoleIDListByPowerType(ctx context.Context, powerTypes ...string) (
+	roleIDs []int, err error) {
+	return repo.RolePowerRelRepo.GetRoleIDListByPowerType(ctx, powerTypes)
+}
+
+// checkPowerTypes all power types must be defined in power table, the duplicate ones are removed
+func (rs *RolePowerRelService) checkPowerTypes(ctx context.Context, powerTypes []string) (
+	checked []string, err error) {
+	checked = make([]string, 0, len(powerTypes))
+	if len(powerTypes) == 0 {
+		return checked, nil
+	}
+	powers, err := repo.PowerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	defined := make(map[string]bool, len(powers))
+	for _, power := range powers {
+		defined[power.PowerType] = true
+	}
+	added := make(map[string]bool, len(powerTypes))
+	for _, powerType := range powerTypes {
+		if !defined[powerType] {
+			return nil, errors.BadRequest(reason.PowerNotFound)
+		}
+		if added[powerType] {
+			continue
+		}
+		added[powerType] = true
+		checked = append(checked, powerType)
+	}
+	return checked, nil
+}
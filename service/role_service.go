@@ -3,18 +3,20 @@ package service
 import (
 	"context"
 	"github.com/lawyer/commons/base/translator"
+	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/repo"
+	"strings"
 
 	"github.com/jinzhu/copier"
 	"github.com/lawyer/commons/schema"
+	"github.com/segmentfault/pacman/errors"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in roles are translated directly, the roles created by admin
+	// are shown as they are named.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -37,6 +39,11 @@ const (
 type RoleRepo interface {
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
+	GetRoleByName(ctx context.Context, name string) (role *entity.Role, exist bool, err error)
+	AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
 }
 
 // RoleServicer user service
@@ -47,6 +54,11 @@ func NewRoleService() *RoleService {
 	return &RoleService{}
 }
 
+// IsBuiltInRole the built-in roles can not be renamed or removed
+func IsBuiltInRole(roleID int) bool {
+	return roleID == RoleUserID || roleID == RoleAdminID || roleID == RoleModeratorID
+}
+
 // GetRoleList get role list all
 func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleResp, err error) {
 	roles, err := repo.RoleRepo.GetRoleAllList(ctx)
@@ -67,7 +79,109 @@ func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]
 	return repo.RoleRepo.GetRoleAllMapping(ctx)
 }
 
+// AddRole add a custom role with its powers
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (resp *schema.AddRoleResp, err error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err = rs.checkRoleNameAvailable(ctx, 0, req.Name); err != nil {
+		return nil, err
+	}
+	powerTypes, err := RolePowerRelServicer.checkPowerTypes(ctx, req.PowerTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err = repo.RoleRepo.AddRole(ctx, role, powerTypes); err != nil {
+		return nil, err
+	}
+	return &schema.AddRoleResp{ID: role.ID}, nil
+}
+
+// UpdateRole update the name and description of custom role
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleCannotUpdateBuiltIn)
+	}
+	role, exist, err := repo.RoleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if err = rs.checkRoleNameAvailable(ctx, role.ID, req.Name); err != nil {
+		return err
+	}
+	role.Name = req.Name
+	role.Description = req.Description
+	return repo.RoleRepo.UpdateRole(ctx, role)
+}
+
+// RemoveRole remove custom role, the role can not be removed when it is still assigned to users
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleCannotUpdateBuiltIn)
+	}
+	_, exist, err := repo.RoleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	count, err := repo.UserRoleRelRepo.CountUserRoleRelByRoleID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.BadRequest(reason.RoleIsUsedCannotDelete)
+	}
+	count, err = repo.UserRoleScopeRelRepo.CountUserRoleScopeRelByRoleID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.BadRequest(reason.RoleIsUsedCannotDelete)
+	}
+	return repo.RoleRepo.RemoveRole(ctx, req.ID)
+}
+
+// CheckRoleExist check role exist
+func (rs *RoleService) CheckRoleExist(ctx context.Context, roleID int) (err error) {
+	_, exist, err := repo.RoleRepo.GetRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	return nil
+}
+
+// checkRoleNameAvailable the role name should be unique and not the same as built-in role names
+func (rs *RoleService) checkRoleNameAvailable(ctx context.Context, roleID int, name string) (err error) {
+	switch strings.ToLower(name) {
+	case strings.ToLower(roleUserName), strings.ToLower(roleAdminName), strings.ToLower(roleModeratorName):
+		return errors.BadRequest(reason.RoleNameDuplicate)
+	}
+	role, exist, err := repo.RoleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exist && role.ID != roleID {
+		return errors.BadRequest(reason.RoleNameDuplicate)
+	}
+	return nil
+}
+
 func (rs *RoleService) translateRole(ctx context.Context, role *entity.Role) {
+	if !IsBuiltInRole(role.ID) {
+		return
+	}
 	switch role.Name {
 	case roleUserName:
 		role.Name = translator.Tr(utils.GetLangByCtx(ctx), trRoleNameUser)
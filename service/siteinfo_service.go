@@ -0,0 +1,505 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lawyer/commons/base/translator"
+	c "github.com/lawyer/commons/config"
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/handler"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/commons/utils/pager"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SiteInfoHistoryRepo site info history repository
+type SiteInfoHistoryRepo interface {
+	AddHistory(ctx context.Context, history *entity.SiteInfoHistory) (err error)
+	GetHistory(ctx context.Context, siteType string, version int) (
+		history *entity.SiteInfoHistory, exist bool, err error)
+	CountHistory(ctx context.Context, siteType string) (count int64, err error)
+	GetHistoryPage(ctx context.Context, siteType string, page, pageSize int) (
+		historyList []*entity.SiteInfoHistory, total int64, err error)
+}
+
+// siteInfoCache the site settings cached in the instance
+type siteInfoCache struct {
+	content   string
+	expiredAt time.Time
+}
+
+// SiteInfoService the site settings of every type are stored as json in the config table, every change is saved
+// as a new version which can be restored. the settings are cached in every instance and the change is published
+// to all instances to drop their cache.
+type SiteInfoService struct {
+	cache sync.Map
+}
+
+// NewSiteInfoService new site info service
+func NewSiteInfoService() *SiteInfoService {
+	return &SiteInfoService{}
+}
+
+// GetSiteGeneral get site general information
+func (ss *SiteInfoService) GetSiteGeneral(ctx context.Context) (resp *schema.SiteGeneralResp, err error) {
+	resp = &schema.SiteGeneralResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeGeneral, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteInterface get site interface
+func (ss *SiteInfoService) GetSiteInterface(ctx context.Context) (resp *schema.SiteInterfaceResp, err error) {
+	resp = &schema.SiteInterfaceResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeInterface, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteBranding get site branding
+func (ss *SiteInfoService) GetSiteBranding(ctx context.Context) (resp *schema.SiteBrandingResp, err error) {
+	resp = &schema.SiteBrandingResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeBranding, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteWrite get site write
+func (ss *SiteInfoService) GetSiteWrite(ctx context.Context) (resp *schema.SiteWriteResp, err error) {
+	resp = &schema.SiteWriteResp{RecommendTags: make([]string, 0), ReservedTags: make([]string, 0)}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeWrite, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteLegal get site legal
+func (ss *SiteInfoService) GetSiteLegal(ctx context.Context) (resp *schema.SiteLegalResp, err error) {
+	resp = &schema.SiteLegalResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeLegal, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteSeo get site seo
+func (ss *SiteInfoService) GetSiteSeo(ctx context.Context) (resp *schema.SiteSeoResp, err error) {
+	resp = &schema.SiteSeoResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeSeo, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteLogin get site login
+func (ss *SiteInfoService) GetSiteLogin(ctx context.Context) (resp *schema.SiteLoginResp, err error) {
+	resp = &schema.SiteLoginResp{AllowEmailDomains: make([]string, 0)}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeLogin, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteCustomCssHTML get site custom css html
+func (ss *SiteInfoService) GetSiteCustomCssHTML(ctx context.Context) (resp *schema.SiteCustomCssHTMLResp, err error) {
+	resp = &schema.SiteCustomCssHTMLResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeCustomCssHTML, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteTheme get site theme with the theme options
+func (ss *SiteInfoService) GetSiteTheme(ctx context.Context) (resp *schema.SiteThemeResp, err error) {
+	theme := &schema.SiteThemeReq{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeTheme, theme); err != nil {
+		return nil, err
+	}
+	resp = &schema.SiteThemeResp{
+		ThemeOptions: schema.GetThemeOptions,
+		Theme:        theme.Theme,
+		ThemeConfig:  theme.ThemeConfig,
+	}
+	return resp, nil
+}
+
+// GetSiteUsers get site users
+func (ss *SiteInfoService) GetSiteUsers(ctx context.Context) (resp *schema.SiteUsersResp, err error) {
+	resp = &schema.SiteUsersResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeUsers, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSMTPConfig get smtp config
+func (ss *SiteInfoService) GetSMTPConfig(ctx context.Context) (resp *schema.GetSMTPConfigResp, err error) {
+	resp = &schema.GetSMTPConfigResp{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypeSMTP, resp); err != nil {
+		return nil, err
+	}
+	return resp.Mask(), nil
+}
+
+// GetPrivilegesConfig get the privileges options and the selected level
+func (ss *SiteInfoService) GetPrivilegesConfig(ctx context.Context) (resp *schema.GetPrivilegesConfigResp, err error) {
+	privilege := &schema.UpdatePrivilegesConfigReq{}
+	if err = ss.getSiteInfo(ctx, constant.SiteTypePrivileges, privilege); err != nil {
+		return nil, err
+	}
+	resp = &schema.GetPrivilegesConfigResp{
+		Options:       schema.DefaultPrivilegeOptions,
+		SelectedLevel: privilege.Level,
+	}
+	return resp, nil
+}
+
+// SaveSiteGeneral save site general information
+func (ss *SiteInfoService) SaveSiteGeneral(ctx context.Context, req *schema.SiteGeneralReq, userID string) (err error) {
+	req.FormatSiteUrl()
+	return ss.saveSiteInfo(ctx, constant.SiteTypeGeneral, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSiteInterface save site interface, the language must be one of the language options
+func (ss *SiteInfoService) SaveSiteInterface(ctx context.Context, req *schema.SiteInterfaceReq, userID string) (
+	err error) {
+	if _, err = time.LoadLocation(req.TimeZone); err != nil {
+		return errors.BadRequest(reason.SiteInfoTimeZoneInvalid)
+	}
+	if !ss.isLanguageOption(req.Language) {
+		return errors.BadRequest(reason.LangNotFound)
+	}
+	return ss.saveSiteInfo(ctx, constant.SiteTypeInterface, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSiteBranding save site branding
+func (ss *SiteInfoService) SaveSiteBranding(ctx context.Context, req *schema.SiteBrandingReq, userID string) (
+	err error) {
+	return ss.saveSiteInfo(ctx, constant.SiteTypeBranding, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSiteWrite save site write, the recommend tags and the reserved tags must exist
+func (ss *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWriteReq) (
+	resp *schema.SiteWriteResp, err error) {
+	for _, tags := range [][]string{req.RecommendTags, req.ReservedTags} {
+		if len(tags) == 0 {
+			continue
+		}
+		tagList, err := TagServicer.GetTagListByNames(ctx, tags)
+		if err != nil {
+			return nil, err
+		}
+		if len(tagList) != len(tags) {
+			return nil, errors.BadRequest(reason.RecommendTagNotExist)
+		}
+	}
+	if err = ss.saveSiteInfo(ctx, constant.SiteTypeWrite, req, req.UserID, entity.AuditActionSiteInfoUpdate); err != nil {
+		return nil, err
+	}
+	return ss.GetSiteWrite(ctx)
+}
+
+// SaveSiteLegal save site legal, the parsed text is rendered from the original markdown
+func (ss *SiteInfoService) SaveSiteLegal(ctx context.Context, req *schema.SiteLegalReq, userID string) (err error) {
+	req.TermsOfServiceParsedText = converter.Markdown2HTML(req.TermsOfServiceOriginalText)
+	req.PrivacyPolicyParsedText = converter.Markdown2HTML(req.PrivacyPolicyOriginalText)
+	return ss.saveSiteInfo(ctx, constant.SiteTypeLegal, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSeo save site seo
+func (ss *SiteInfoService) SaveSeo(ctx context.Context, req *schema.SiteSeoReq, userID string) (err error) {
+	return ss.saveSiteInfo(ctx, constant.SiteTypeSeo, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSiteLogin save site login
+func (ss *SiteInfoService) SaveSiteLogin(ctx context.Context, req *schema.SiteLoginReq, userID string) (err error) {
+	return ss.saveSiteInfo(ctx, constant.SiteTypeLogin, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSiteCustomCssHTML save site custom css html
+func (ss *SiteInfoService) SaveSiteCustomCssHTML(ctx context.Context, req *schema.SiteCustomCssHTMLReq,
+	userID string) (err error) {
+	return ss.saveSiteInfo(ctx, constant.SiteTypeCustomCssHTML, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// SaveSiteTheme save site theme, the theme must be one of the theme options
+func (ss *SiteInfoService) SaveSiteTheme(ctx context.Context, req *schema.SiteThemeReq, userID string) (err error) {
+	for _, option := range schema.GetThemeOptions {
+		if option.Value == req.Theme {
+			return ss.saveSiteInfo(ctx, constant.SiteTypeTheme, req, userID, entity.AuditActionSiteInfoUpdate)
+		}
+	}
+	return errors.BadRequest(reason.ThemeNotFound)
+}
+
+// SaveSiteUsers save site users
+func (ss *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUsersReq, userID string) (err error) {
+	return ss.saveSiteInfo(ctx, constant.SiteTypeUsers, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// UpdateSMTPConfig update smtp config, the test email is sent with the new config if the recipient is set
+func (ss *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.UpdateSMTPConfigReq, userID string) (
+	err error) {
+	smtp := &schema.GetSMTPConfigResp{
+		FromEmail:          req.FromEmail,
+		FromName:           req.FromName,
+		SMTPHost:           req.SMTPHost,
+		SMTPPort:           req.SMTPPort,
+		Encryption:         req.Encryption,
+		SMTPUsername:       req.SMTPUsername,
+		SMTPPassword:       req.SMTPPassword,
+		SMTPAuthentication: req.SMTPAuthentication,
+	}
+	if err = ss.saveSiteInfo(ctx, constant.SiteTypeSMTP, smtp, userID, entity.AuditActionSiteInfoUpdate); err != nil {
+		return err
+	}
+	if len(req.TestEmailRecipient) > 0 {
+		title, body, err := EmailServicer.TestTemplate(ctx)
+		if err != nil {
+			return err
+		}
+		go EmailServicer.Send(context.Background(), req.TestEmailRecipient, title, body)
+	}
+	return nil
+}
+
+// UpdatePrivilegesConfig update the privileges level, the rank of every privilege is set by the level
+func (ss *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schema.UpdatePrivilegesConfigReq,
+	userID string) (err error) {
+	return ss.saveSiteInfo(ctx, constant.SiteTypePrivileges, req, userID, entity.AuditActionSiteInfoUpdate)
+}
+
+// GetSiteInfoHistoryPage get the saved versions of the site settings type, the latest first
+func (ss *SiteInfoService) GetSiteInfoHistoryPage(ctx context.Context, req *schema.GetSiteInfoHistoryPageReq) (
+	resp *pager.PageModel, err error) {
+	historyList, total, err := repo.SiteInfoHistoryRepo.GetHistoryPage(ctx, req.SiteType, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(historyList))
+	for _, history := range historyList {
+		userIDs = append(userIDs, history.UserID)
+	}
+	userInfoMapping, err := UserCommonServicer.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.SiteInfoHistoryInfo, 0, len(historyList))
+	for _, history := range historyList {
+		list = append(list, &schema.SiteInfoHistoryInfo{
+			SiteType:  history.SiteType,
+			Version:   history.Version,
+			Content:   json.RawMessage(history.Content),
+			UserInfo:  userInfoMapping[history.UserID],
+			CreatedAt: history.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// RollbackSiteInfo restore the site settings type to the saved version, the restored settings are saved as
+// a new version so the rollback can be undone
+func (ss *SiteInfoService) RollbackSiteInfo(ctx context.Context, req *schema.RollbackSiteInfoReq) (err error) {
+	history, exist, err := repo.SiteInfoHistoryRepo.GetHistory(ctx, req.SiteType, req.Version)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SiteInfoVersionNotFound)
+	}
+	content := ss.newSiteInfo(req.SiteType)
+	if err = json.Unmarshal([]byte(history.Content), content); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return ss.saveSiteInfo(ctx, req.SiteType, content, req.UserID, entity.AuditActionSiteInfoRollback)
+}
+
+// SubscribeInvalidation drop the cached site settings when they are changed by any instance
+func (ss *SiteInfoService) SubscribeInvalidation(ctx context.Context) {
+	pubSub := handler.RedisClient.Subscribe(ctx, constant.SiteInfoInvalidateChannel)
+	go func() {
+		defer pubSub.Close()
+		for msg := range pubSub.Channel() {
+			ss.cache.Delete(msg.Payload)
+		}
+	}()
+}
+
+// getSiteInfo get the site settings of the type into resp
+func (ss *SiteInfoService) getSiteInfo(ctx context.Context, siteType string, resp any) (err error) {
+	if cached, ok := ss.cache.Load(siteType); ok {
+		item := cached.(*siteInfoCache)
+		if time.Now().Before(item.expiredAt) {
+			return json.Unmarshal([]byte(item.content), resp)
+		}
+	}
+	cfg, err := utils.GetConfigByKey(ctx, ss.configKey(siteType))
+	if err != nil {
+		glog.Slog.Errorf("get site info %s failed: %v", siteType, err)
+		return errors.BadRequest(reason.SiteInfoConfigNotFound)
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), resp); err != nil {
+		return fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
+	ss.cache.Store(siteType, &siteInfoCache{content: cfg.Value, expiredAt: time.Now().Add(constant.SiteInfoCacheTime)})
+	return nil
+}
+
+// saveSiteInfo save the site settings of the type and add it as the next version, the settings before the first
+// change are saved as the first version
+func (ss *SiteInfoService) saveSiteInfo(ctx context.Context, siteType string, content any, userID, action string) (
+	err error) {
+	oldContent := ss.newSiteInfo(siteType)
+	if err = ss.getSiteInfo(ctx, siteType, oldContent); err != nil {
+		return err
+	}
+	count, err := repo.SiteInfoHistoryRepo.CountHistory(ctx, siteType)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if err = ss.addHistory(ctx, siteType, ss.mask(oldContent), "0"); err != nil {
+			return err
+		}
+	}
+
+	if err = ss.applySiteInfo(ctx, siteType, content); err != nil {
+		return err
+	}
+	ss.invalidate(ctx, siteType)
+	if err = ss.addHistory(ctx, siteType, ss.mask(content), userID); err != nil {
+		return err
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     userID,
+		Action:     action,
+		ObjectType: "siteinfo",
+		ObjectID:   siteType,
+		Before:     ss.mask(oldContent),
+		After:      ss.mask(content),
+	})
+	return nil
+}
+
+// mask the secrets of the site settings, such as the smtp password, before they are saved in the history
+// and the audit log
+func (ss *SiteInfoService) mask(content any) any {
+	if smtp, ok := content.(*schema.GetSMTPConfigResp); ok {
+		return smtp.Mask()
+	}
+	return content
+}
+
+// applySiteInfo write the site settings to the config, the smtp settings are merged into the email config and
+// the privileges level changes the rank of every privilege
+func (ss *SiteInfoService) applySiteInfo(ctx context.Context, siteType string, content any) (err error) {
+	data, _ := json.Marshal(content)
+	switch siteType {
+	case constant.SiteTypeSMTP:
+		ec := &c.EmailConfig{}
+		_ = json.Unmarshal(data, ec)
+		// the masked password is submitted back or restored from the history, keep the saved one
+		if ec.SMTPPassword == schema.SMTPPasswordMask {
+			oldConfig, err := EmailServicer.GetEmailConfig(ctx)
+			if err != nil {
+				return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+			ec.SMTPPassword = oldConfig.SMTPPassword
+		}
+		err = EmailServicer.SetEmailConfig(ctx, ec)
+	case constant.SiteTypePrivileges:
+		option := schema.DefaultPrivilegeOptions.Choose(content.(*schema.UpdatePrivilegesConfigReq).Level)
+		if option == nil {
+			return errors.BadRequest(reason.RequestFormatError)
+		}
+		for _, privilege := range option.Privileges {
+			if err = utils.UpdateConfig(ctx, privilege.Key, fmt.Sprintf("%d", privilege.Value)); err != nil {
+				return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+		}
+		err = utils.UpdateConfig(ctx, ss.configKey(siteType), string(data))
+	default:
+		err = utils.UpdateConfig(ctx, ss.configKey(siteType), string(data))
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (ss *SiteInfoService) addHistory(ctx context.Context, siteType string, content any, userID string) (err error) {
+	data, _ := json.Marshal(content)
+	return repo.SiteInfoHistoryRepo.AddHistory(ctx, &entity.SiteInfoHistory{
+		SiteType: siteType,
+		Content:  string(data),
+		UserID:   userID,
+	})
+}
+
+// invalidate drop the cached site settings of this instance and tell the other instances to drop theirs
+func (ss *SiteInfoService) invalidate(ctx context.Context, siteType string) {
+	ss.cache.Delete(siteType)
+	if err := handler.RedisClient.Publish(ctx, constant.SiteInfoInvalidateChannel, siteType).Err(); err != nil {
+		glog.Slog.Errorf("publish site info invalidation failed: %v", err)
+	}
+}
+
+func (ss *SiteInfoService) configKey(siteType string) string {
+	if siteType == constant.SiteTypeSMTP {
+		return emailConfigKey
+	}
+	return constant.SiteInfoConfigKeyPrefix + siteType
+}
+
+// newSiteInfo new the typed site settings of the type
+func (ss *SiteInfoService) newSiteInfo(siteType string) any {
+	switch siteType {
+	case constant.SiteTypeGeneral:
+		return &schema.SiteGeneralReq{}
+	case constant.SiteTypeInterface:
+		return &schema.SiteInterfaceReq{}
+	case constant.SiteTypeBranding:
+		return &schema.SiteBrandingReq{}
+	case constant.SiteTypeWrite:
+		return &schema.SiteWriteReq{}
+	case constant.SiteTypeLegal:
+		return &schema.SiteLegalReq{}
+	case constant.SiteTypeSeo:
+		return &schema.SiteSeoReq{}
+	case constant.SiteTypeLogin:
+		return &schema.SiteLoginReq{}
+	case constant.SiteTypeCustomCssHTML:
+		return &schema.SiteCustomCssHTMLReq{}
+	case constant.SiteTypeTheme:
+		return &schema.SiteThemeReq{}
+	case constant.SiteTypePrivileges:
+		return &schema.UpdatePrivilegesConfigReq{}
+	case constant.SiteTypeUsers:
+		return &schema.SiteUsersReq{}
+	case constant.SiteTypeSMTP:
+		return &schema.GetSMTPConfigResp{}
+	}
+	return &map[string]any{}
+}
+
+func (ss *SiteInfoService) isLanguageOption(language string) bool {
+	for _, option := range translator.LanguageOptions {
+		if option.Value == language {
+			return true
+		}
+	}
+	return false
+}
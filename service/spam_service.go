@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/encryption"
+	"github.com/lawyer/pkg/htmltext"
+	"github.com/lawyer/plugin"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SpamScoreRepo spam score repository
+type SpamScoreRepo interface {
+	AddScore(ctx context.Context, score *entity.SpamScore) (err error)
+	GetScoreByObjectID(ctx context.Context, objectID string) (score *entity.SpamScore, exist bool, err error)
+	CountContentHashSince(ctx context.Context, contentHash string, since time.Time) (count int64, err error)
+	UpdateDecision(ctx context.Context, id int64, decision string) (err error)
+	UpdateHoldScore(ctx context.Context, oldScore, newScore int) (updated bool, err error)
+}
+
+// SpamService score the new questions, answers and comments with the spam signals, the posts with high score
+// are held for review and the review decisions adjust the hold score
+type SpamService struct {
+}
+
+// NewSpamService new spam service
+func NewSpamService() *SpamService {
+	return &SpamService{}
+}
+
+// GetSpamPolicy get the spam scoring rules
+func (ss *SpamService) GetSpamPolicy(ctx context.Context) (policy *schema.SpamPolicy, err error) {
+	policy = &schema.SpamPolicy{BlockedDomains: make([]string, 0)}
+	cfg, err := utils.GetConfigByKey(ctx, entity.SpamPolicyConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Value) > 0 {
+		if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+			return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+		}
+	}
+	// the hold score adjusted by the review decisions is saved apart from the policy
+	if holdScore, err := utils.GetIntValue(ctx, entity.SpamHoldScoreConfigKey); err == nil && holdScore > 0 {
+		policy.HoldScore = holdScore
+	}
+	return policy, nil
+}
+
+// UpdateSpamPolicy update the spam scoring rules
+func (ss *SpamService) UpdateSpamPolicy(ctx context.Context, req *schema.UpdateSpamPolicyReq) (err error) {
+	if req.MinHoldScore > req.MaxHoldScore || req.HoldScore < req.MinHoldScore || req.HoldScore > req.MaxHoldScore {
+		return errors.BadRequest(reason.SpamHoldScoreInvalid)
+	}
+	oldPolicy, err := ss.GetSpamPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &req.SpamPolicy
+	if err = ss.savePolicy(ctx, policy); err != nil {
+		return err
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionSpamPolicy,
+		ObjectType: "spam_policy",
+		ObjectID:   entity.SpamPolicyConfigKey,
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// Score score the post with the spam signals, the posts of the moderators are trusted
+func (ss *SpamService) Score(ctx context.Context, req *schema.SpamCheckReq) (result *schema.SpamScoreResult) {
+	result = &schema.SpamScoreResult{Signals: make(map[string]int)}
+	policy, err := ss.GetSpamPolicy(ctx)
+	if err != nil {
+		glog.Slog.Errorf("get spam policy failed: %v", err)
+		return result
+	}
+	if !policy.Enabled || PreModerationServicer.CanViewPendingPost(ctx, req.UserID, req.ObjectID) {
+		return result
+	}
+	text := req.Title + "\n" + req.Content
+	result.ContentHash = encryption.MD5(strings.ToLower(strings.Join(strings.Fields(text), " ")))
+
+	hosts := htmltext.FetchLinkHosts(text)
+	if len(hosts) > policy.FreeLinks {
+		result.Signals[schema.SpamSignalLinks] = (len(hosts) - policy.FreeLinks) * policy.LinkWeight
+	}
+	for _, host := range hosts {
+		if policy.IsBlockedDomain(host) {
+			result.Signals[schema.SpamSignalBlockedDomain] = policy.BlockedDomainWeight
+			break
+		}
+	}
+	if policy.RepeatWindowHours > 0 {
+		since := time.Now().Add(-time.Duration(policy.RepeatWindowHours) * time.Hour)
+		count, err := repo.SpamScoreRepo.CountContentHashSince(ctx, result.ContentHash, since)
+		if err != nil {
+			glog.Slog.Error(err)
+		} else if count > 0 {
+			result.Signals[schema.SpamSignalRepeatedContent] = policy.RepeatedContentWeight
+		}
+	}
+	if policy.NewAccountDays > 0 {
+		userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, req.UserID)
+		if err != nil {
+			glog.Slog.Error(err)
+		} else if exist && userInfo.CreatedAt.After(time.Now().AddDate(0, 0, -policy.NewAccountDays)) {
+			result.Signals[schema.SpamSignalNewAccount] = policy.NewAccountWeight
+		}
+	}
+	if policy.ActionBurstCount > 0 {
+		actionInfo, err := repo.CaptchaRepo.GetActionType(ctx, req.UserID, req.ObjectType)
+		if err != nil {
+			glog.Slog.Error(err)
+		} else if actionInfo != nil && actionInfo.Num >= policy.ActionBurstCount {
+			result.Signals[schema.SpamSignalActionBurst] = policy.ActionBurstWeight
+		}
+	}
+	_ = plugin.CallFilter(func(filter plugin.Filter) error {
+		if err := filter.FilterText(text); err != nil {
+			result.Signals[schema.SpamSignalFilter] = policy.FilterWeight
+			return err
+		}
+		return nil
+	})
+
+	for _, score := range result.Signals {
+		result.Score += score
+	}
+	result.Hold = result.Score >= policy.HoldScore
+	return result
+}
+
+// Record save the spam score of the post, it is used to find the repeated content and to adjust the hold score
+func (ss *SpamService) Record(ctx context.Context, objectID, objectType, userID string,
+	result *schema.SpamScoreResult) {
+	if len(result.ContentHash) == 0 {
+		return
+	}
+	signals, _ := json.Marshal(result.Signals)
+	err := repo.SpamScoreRepo.AddScore(ctx, &entity.SpamScore{
+		ObjectID:    objectID,
+		ObjectType:  objectType,
+		UserID:      userID,
+		ContentHash: result.ContentHash,
+		Score:       result.Score,
+		Signals:     string(signals),
+		Held:        result.Hold,
+	})
+	if err != nil {
+		glog.Slog.Error(err)
+	}
+}
+
+// Feedback record the review decision of the held post, the approved post means the hold score is too low and
+// the rejected post means it can be lower, so the hold score moves one step for each decision
+func (ss *SpamService) Feedback(ctx context.Context, objectID, decision string) {
+	score, exist, err := repo.SpamScoreRepo.GetScoreByObjectID(ctx, objectID)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
+	if !exist || !score.Held || len(score.Decision) > 0 {
+		return
+	}
+	if err = repo.SpamScoreRepo.UpdateDecision(ctx, score.ID, decision); err != nil {
+		glog.Slog.Error(err)
+		return
+	}
+
+	policy, err := ss.GetSpamPolicy(ctx)
+	if err != nil {
+		glog.Slog.Errorf("get spam policy failed: %v", err)
+		return
+	}
+	if policy.AdjustStep == 0 {
+		return
+	}
+	holdScore := policy.HoldScore
+	if decision == schema.RevisionAuditApprove {
+		holdScore += policy.AdjustStep
+		if holdScore > policy.MaxHoldScore {
+			holdScore = policy.MaxHoldScore
+		}
+	} else {
+		holdScore -= policy.AdjustStep
+		if holdScore < policy.MinHoldScore {
+			holdScore = policy.MinHoldScore
+		}
+	}
+	if holdScore == policy.HoldScore {
+		return
+	}
+	// only the hold score is changed, the policy saved by the admin meanwhile is kept
+	updated, err := repo.SpamScoreRepo.UpdateHoldScore(ctx, policy.HoldScore, holdScore)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
+	if !updated {
+		glog.Slog.Infof("spam hold score is changed by others, the adjustment by %s is skipped", objectID)
+		return
+	}
+	glog.Slog.Infof("spam hold score is adjusted from %d to %d by the %s decision of %s",
+		policy.HoldScore, holdScore, decision, objectID)
+}
+
+// GetSpamScore get the spam score of the post
+func (ss *SpamService) GetSpamScore(ctx context.Context, req *schema.GetSpamScoreReq) (
+	resp *schema.GetSpamScoreResp, err error) {
+	score, exist, err := repo.SpamScoreRepo.GetScoreByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.SpamScoreNotFound)
+	}
+	resp = &schema.GetSpamScoreResp{
+		ObjectID:   req.ObjectID,
+		ObjectType: score.ObjectType,
+		Score:      score.Score,
+		Signals:    make(map[string]int),
+		Held:       score.Held,
+		Decision:   score.Decision,
+		CreatedAt:  score.CreatedAt.Unix(),
+	}
+	_ = json.Unmarshal([]byte(score.Signals), &resp.Signals)
+	return resp, nil
+}
+
+func (ss *SpamService) savePolicy(ctx context.Context, policy *schema.SpamPolicy) (err error) {
+	content, _ := json.Marshal(policy)
+	if err = utils.UpdateConfig(ctx, entity.SpamPolicyConfigKey, string(content)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = utils.UpdateConfig(ctx, entity.SpamHoldScoreConfigKey, strconv.Itoa(policy.HoldScore)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
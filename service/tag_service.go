@@ -10,7 +10,6 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	glog "github.com/lawyer/commons/logger"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils/pager"
 	"github.com/lawyer/pkg/htmltext"
 	"github.com/lawyer/repo"
@@ -370,6 +369,11 @@ func (ts *TagService) UpdateTagSynonym(ctx context.Context, req *schema.UpdateTa
 		}
 	}
 
+	oldSynonyms := make([]string, 0, len(oldSynonymList))
+	for _, oldSynonym := range oldSynonymList {
+		oldSynonyms = append(oldSynonyms, oldSynonym.SlugName)
+	}
+
 	// remove old synonyms
 	if len(removeSynonymTagList) > 0 {
 		err = repo.TagRepo.UpdateTagSynonym(ctx, removeSynonymTagList, 0, "")
@@ -385,6 +389,14 @@ func (ts *TagService) UpdateTagSynonym(ctx context.Context, req *schema.UpdateTa
 			return err
 		}
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionTagUpdateSynonym,
+		ObjectType: constant.TagObjectType,
+		ObjectID:   mainTagInfo.ID,
+		Before:     &schema.TagSynonymSnapshot{Synonyms: oldSynonyms},
+		After:      &schema.TagSynonymSnapshot{Synonyms: addSynonymTagList},
+	})
 	return nil
 }
 
@@ -585,7 +597,10 @@ func (ts *TagService) GetTagListByNames(ctx context.Context, tagNames []string)
 }
 
 func (ts *TagService) ExistRecommend(ctx context.Context, tags []*schema.TagItem) (bool, error) {
-	taginfo := site.Config.GetSiteWrite()
+	taginfo, err := SiteInfoServicer.GetSiteWrite(ctx)
+	if err != nil {
+		return false, err
+	}
 	if !taginfo.RequiredTag {
 		return true, nil
 	}
@@ -745,7 +760,11 @@ func (ts *TagService) TagsFormatRecommendAndReserved(ctx context.Context, tagLis
 	if len(tagList) == 0 {
 		return
 	}
-	tagConfig := site.Config.GetSiteWrite()
+	tagConfig, err := SiteInfoServicer.GetSiteWrite(ctx)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
 	if !tagConfig.RequiredTag {
 		for _, tag := range tagList {
 			tag.Recommend = false
@@ -757,7 +776,11 @@ func (ts *TagService) tagFormatRecommendAndReserved(ctx context.Context, tag *en
 	if tag == nil {
 		return
 	}
-	tagConfig := site.Config.GetSiteWrite()
+	tagConfig, err := SiteInfoServicer.GetSiteWrite(ctx)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
 	if !tagConfig.RequiredTag {
 		tag.Recommend = false
 	}
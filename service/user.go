@@ -116,6 +116,7 @@ func (us *UserCommon) FormatUserBasicInfo(ctx context.Context, userInfo *entity.
 	userBasicInfo.ID = userInfo.ID
 	userBasicInfo.Username = userInfo.Username
 	userBasicInfo.Rank = userInfo.Rank
+	userBasicInfo.BadgeCount = userInfo.BadgeCount
 	userBasicInfo.DisplayName = userInfo.DisplayName
 	userBasicInfo.Website = userInfo.Website
 	userBasicInfo.Location = userInfo.Location
@@ -166,7 +167,7 @@ func (us *UserCommon) MakeUsername(ctx context.Context, displayName string) (use
 }
 
 func (us *UserCommon) CacheLoginUserInfo(ctx context.Context, userID string, userStatus, emailStatus int, externalID string) (
-	accessToken string, userCacheInfo *entity.UserCacheInfo, err error) {
+	accessToken, refreshToken string, userCacheInfo *entity.UserCacheInfo, err error) {
 
 	roleID, err := UserRoleRelServicer.GetUserRole(ctx, userID)
 	if err != nil {
@@ -181,9 +182,9 @@ func (us *UserCommon) CacheLoginUserInfo(ctx context.Context, userID string, use
 		ExternalID:  externalID,
 	}
 
-	accessToken, err = AuthServicer.SetUserCacheInfo(ctx, userCacheInfo)
+	accessToken, refreshToken, err = AuthServicer.SetUserCacheInfo(ctx, userCacheInfo)
 	if err != nil {
-		return "", nil, err
+		return "", "", nil, err
 	}
-	return accessToken, userCacheInfo, nil
+	return accessToken, refreshToken, userCacheInfo, nil
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	glog "github.com/lawyer/commons/logger"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/repo"
 	"net/mail"
@@ -34,7 +33,10 @@ type UserAdminRepo interface {
 		usernameOrDisplayName string, isStaff bool) (users []*entity.User, total int64, err error)
 	AddUser(ctx context.Context, user *entity.User) (err error)
 	AddUsers(ctx context.Context, users []*entity.User) (err error)
+	ImportUsers(ctx context.Context, users []*entity.User, roleIDs []int) (err error)
 	UpdateUserPassword(ctx context.Context, userID string, password string) (err error)
+	SetImportJob(ctx context.Context, jobID, content string) (err error)
+	GetImportJob(ctx context.Context, jobID string) (content string, exist bool, err error)
 }
 
 // UserAdminServicer user service
@@ -63,6 +65,7 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if userInfo.Status == entity.UserStatusDeleted {
 		return nil
 	}
+	before := &schema.UserStatusSnapshot{Status: userInfo.Status, MailStatus: userInfo.MailStatus}
 
 	if req.IsInactive() {
 		userInfo.MailStatus = entity.EmailStatusToBeVerified
@@ -83,6 +86,22 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditActionUserUpdateStatus,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+		Before:     before,
+		After: &schema.UserStatusSnapshot{Status: userInfo.Status, MailStatus: userInfo.MailStatus,
+			RemoveAllContent: req.RemoveAllContent},
+	})
+
+	// the suspended or deleted user is logged out from all the devices
+	if req.IsSuspended() || req.IsDeleted() {
+		if err = AuthServicer.RemoveUserAllTokens(ctx, userInfo.ID); err != nil {
+			return err
+		}
+	}
 
 	// remove all content that user created, such as question, answer, comment, etc.
 	if req.RemoveAllContent {
@@ -115,11 +134,26 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 	if req.UserID == req.LoginUserID {
 		return errors.BadRequest(reason.UserCannotUpdateYourRole)
 	}
+	if err = RoleServicer.CheckRoleExist(ctx, req.RoleID); err != nil {
+		return err
+	}
+	oldRoleID, err := UserRoleRelServicer.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
 
 	err = UserRoleRelServicer.SaveUserRole(ctx, req.UserID, req.RoleID)
 	if err != nil {
 		return err
 	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditActionUserUpdateRole,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		Before:     &schema.UserRoleSnapshot{RoleID: oldRoleID},
+		After:      &schema.UserRoleSnapshot{RoleID: req.RoleID},
+	})
 
 	//AuthServicer.RemoveUserAllTokens(ctx, req.UserID)
 	return
@@ -183,6 +217,15 @@ func (us *UserAdminService) AddUsers(ctx context.Context, req *schema.AddUsersRe
 
 func (us *UserAdminService) checkUserDuplicateInner(ctx context.Context, users []*schema.AddUserReq) (
 	errorData *schema.AddUsersErrorData) {
+	if errorDataList := us.checkUserDuplicateInnerAll(ctx, users); len(errorDataList) > 0 {
+		return errorDataList[0]
+	}
+	return nil
+}
+
+// checkUserDuplicateInnerAll check all the users, the invalid user is reported once and skipped
+func (us *UserAdminService) checkUserDuplicateInnerAll(ctx context.Context, users []*schema.AddUserReq) (
+	errorDataList []*schema.AddUsersErrorData) {
 	lang := utils.GetLangByCtx(ctx)
 	val := validator.GetValidatorByLang(lang)
 
@@ -190,35 +233,38 @@ func (us *UserAdminService) checkUserDuplicateInner(ctx context.Context, users [
 	displayNames := make(map[string]bool)
 	for line, user := range users {
 		if errFields, e := val.Check(user); e != nil {
-			errorData = &schema.AddUsersErrorData{}
+			errorData := &schema.AddUsersErrorData{}
 			if len(errFields) > 0 {
 				errorData.Field = errFields[0].ErrorField
 				errorData.ExtraMessage = errFields[0].ErrorMsg
 			}
 			errorData.Line = line + 1
 			errorData.Content = fmt.Sprintf("%s, %s, %s", user.DisplayName, user.Email, user.Password)
-			return errorData
+			errorDataList = append(errorDataList, errorData)
+			continue
 		}
 		if emails[user.Email] {
-			return &schema.AddUsersErrorData{
+			errorDataList = append(errorDataList, &schema.AddUsersErrorData{
 				Field:        "email",
 				Line:         line + 1,
 				Content:      user.Email,
 				ExtraMessage: translator.Tr(lang, reason.EmailDuplicate),
-			}
+			})
+			continue
 		}
 		if displayNames[user.DisplayName] {
-			return &schema.AddUsersErrorData{
+			errorDataList = append(errorDataList, &schema.AddUsersErrorData{
 				Field:        "name",
 				Line:         line + 1,
 				Content:      user.DisplayName,
 				ExtraMessage: translator.Tr(lang, reason.UsernameDuplicate),
-			}
+			})
+			continue
 		}
 		emails[user.Email] = true
 		displayNames[user.DisplayName] = true
 	}
-	return nil
+	return errorDataList
 }
 
 func (us *UserAdminService) formatBulkAddUsers(ctx context.Context, req *schema.AddUsersReq) (
@@ -283,8 +329,28 @@ func (us *UserAdminService) UpdateUserPassword(ctx context.Context, req *schema.
 		return err
 	}
 	// logout this user
-	//AuthServicer.RemoveUserAllTokens(ctx, req.UserID)
-	return
+	return AuthServicer.RemoveUserAllTokens(ctx, req.UserID)
+}
+
+// ForceLogout log out the user from all the devices, such as the suspended user or the user whose account is stolen
+func (us *UserAdminService) ForceLogout(ctx context.Context, req *schema.ForceLogoutUserReq) (err error) {
+	_, exist, err := repo.UserAdminRepo.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = AuthServicer.RemoveUserAllTokens(ctx, req.UserID); err != nil {
+		return err
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditActionUserForceLogout,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+	})
+	return nil
 }
 
 // GetUserInfo get user one
@@ -357,6 +423,8 @@ func (us *UserAdminService) GetUserPage(ctx context.Context, req *schema.GetUser
 			Rank:        u.Rank,
 			DisplayName: u.DisplayName,
 			Avatar:      avatarMapping[u.ID].GetURL(),
+			Location:    u.Location,
+			Website:     u.Website,
 		}
 		if u.Status == entity.UserStatusDeleted {
 			t.Status = constant.UserDeleted
@@ -407,7 +475,10 @@ func (us *UserAdminService) GetUserActivation(ctx context.Context, req *schema.G
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
 
-	general := site.Config.GetSiteGeneral()
+	general, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	data := &schema.EmailCodeContent{
 		Email:  user.EMail,
@@ -431,7 +502,10 @@ func (us *UserAdminService) SendUserActivation(ctx context.Context, req *schema.
 		return errors.BadRequest(reason.UserNotFound)
 	}
 
-	general := site.Config.GetSiteGeneral()
+	general, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return err
+	}
 	data := &schema.EmailCodeContent{
 		Email:  user.EMail,
 		UserID: user.ID,
@@ -8,7 +8,6 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	"github.com/lawyer/commons/entity"
 	glog "github.com/lawyer/commons/logger"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/commons/utils/checker"
 	"time"
@@ -54,8 +53,11 @@ func (us *UserCenterLoginService) ExternalLogin(
 
 	if len(basicUserInfo.Email) > 0 {
 		// check whether site allow register or not
-		site := site.Config.GetSiteLogin()
-		if !checker.EmailInAllowEmailDomain(basicUserInfo.Email, site.AllowEmailDomains) {
+		siteInfo, err := SiteInfoServicer.GetSiteLogin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !checker.EmailInAllowEmailDomain(basicUserInfo.Email, siteInfo.AllowEmailDomains) {
 			glog.Slog.Debugf("email domain not allowed: %s", basicUserInfo.Email)
 			return &schema.UserExternalLoginResp{
 				ErrTitle: translator.Tr(utils.GetLangByCtx(ctx), reason.UserAccessDenied),
@@ -86,9 +88,9 @@ func (us *UserCenterLoginService) ExternalLogin(
 			if err := us.userRepo.UpdateLastLoginDate(ctx, oldUserInfo.ID); err != nil {
 				glog.Slog.Errorf("update user last glog.Slogin date failed: %v", err)
 			}
-			accessToken, _, err := us.userCommonService.CacheLoginUserInfo(
+			accessToken, refreshToken, _, err := us.userCommonService.CacheLoginUserInfo(
 				ctx, oldUserInfo.ID, oldUserInfo.MailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
-			return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
+			return &schema.UserExternalLoginResp{AccessToken: accessToken, RefreshToken: refreshToken}, err
 		}
 	}
 
@@ -104,9 +106,9 @@ func (us *UserCenterLoginService) ExternalLogin(
 
 	us.activeUser(ctx, oldUserInfo)
 
-	accessToken, _, err := us.userCommonService.CacheLoginUserInfo(
+	accessToken, refreshToken, _, err := us.userCommonService.CacheLoginUserInfo(
 		ctx, oldUserInfo.ID, oldUserInfo.MailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
-	return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
+	return &schema.UserExternalLoginResp{AccessToken: accessToken, RefreshToken: refreshToken}, err
 }
 
 func (us *UserCenterLoginService) registerNewUser(ctx context.Context, provider string,
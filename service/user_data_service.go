@@ -0,0 +1,448 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/repo"
+	"github.com/lawyer/service/permission"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// userDeletionBatchSize the max deletions completed by the cron at once
+const userDeletionBatchSize = 100
+
+// userDataVoteTypeKeys the activity types of the votes of the user
+var userDataVoteTypeKeys = []string{
+	constant.QuestionVoteUp,
+	constant.QuestionVoteDown,
+	constant.AnswerVoteUp,
+	constant.AnswerVoteDown,
+	constant.CommentVoteUp,
+}
+
+// UserDataRepo user personal data repository
+type UserDataRepo interface {
+	GetUserQuestions(ctx context.Context, userID string) (questions []*entity.Question, err error)
+	GetUserAnswers(ctx context.Context, userID string) (answers []*entity.Answer, err error)
+	GetUserComments(ctx context.Context, userID string) (comments []*entity.Comment, err error)
+	GetUserVotes(ctx context.Context, userID string, activityTypes []int) (votes []*entity.Activity, err error)
+	GetUserCollections(ctx context.Context, userID string) (collections []*entity.Collection, err error)
+	GetUserNotifications(ctx context.Context, userID string) (notifications []*entity.Notification, err error)
+	GetDeletion(ctx context.Context, userID string) (deletion *entity.UserDeletion, exist bool, err error)
+	SaveDeletion(ctx context.Context, userID string, scheduledAt time.Time) (err error)
+	CancelDeletion(ctx context.Context, userID string) (ok bool, err error)
+	GetDueDeletions(ctx context.Context, now time.Time, limit int) (deletions []*entity.UserDeletion, err error)
+	AnonymizeUser(ctx context.Context, userInfo *entity.User) (ok bool, err error)
+}
+
+// UserDataService the self-service export of the personal data and the account deletion. The deleted account is
+// anonymized instead of removed, the questions, answers and comments stay in the threads without the author.
+type UserDataService struct {
+}
+
+// NewUserDataService new user data service
+func NewUserDataService() *UserDataService {
+	return &UserDataService{}
+}
+
+// Export write the personal data of the user to w as a zip archive, every part is a json file and the content
+// written by the user is also rendered as markdown
+func (ds *UserDataService) Export(ctx context.Context, userID string, w io.Writer) (err error) {
+	data, err := ds.collect(ctx, userID)
+	if err != nil {
+		return err
+	}
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", data.Profile},
+		{"questions.json", data.Questions},
+		{"answers.json", data.Answers},
+		{"comments.json", data.Comments},
+		{"votes.json", data.Votes},
+		{"collections.json", data.Collections},
+		{"notifications.json", data.Notifications},
+		{"external_logins.json", data.ExternalLogins},
+		{"README.md", ds.profileMarkdown(data)},
+		{"questions.md", ds.questionsMarkdown(data.Questions)},
+		{"answers.md", ds.answersMarkdown(data.Answers)},
+		{"comments.md", ds.commentsMarkdown(data.Comments)},
+	}
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		content, ok := file.content.(string)
+		if !ok {
+			encoded, err := json.MarshalIndent(file.content, "", "  ")
+			if err != nil {
+				return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+			}
+			content = string(encoded)
+		}
+		if err = ds.writeFile(archive, file.name, content); err != nil {
+			return err
+		}
+	}
+	if err = archive.Close(); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// ExportToBuffer export the personal data to the memory, so the error can still be returned before the download
+func (ds *UserDataService) ExportToBuffer(ctx context.Context, userID string) (buf *bytes.Buffer, err error) {
+	buf = &bytes.Buffer{}
+	if err = ds.Export(ctx, userID, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// GetDeletion get the account deletion status of the user
+func (ds *UserDataService) GetDeletion(ctx context.Context, userID string) (resp *schema.GetUserDeletionResp, err error) {
+	resp = &schema.GetUserDeletionResp{}
+	deletion, exist, err := repo.UserDataRepo.GetDeletion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && deletion.Status == entity.UserDeletionStatusPending {
+		resp.Pending = true
+		resp.ScheduledAt = deletion.ScheduledAt.Unix()
+	}
+	return resp, nil
+}
+
+// RequestDeletion schedule the deletion of the own account after the grace period, the admin can not delete
+// their own account
+func (ds *UserDataService) RequestDeletion(ctx context.Context, req *schema.RequestUserDeletionReq) (
+	resp *schema.GetUserDeletionResp, err error) {
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if len(userInfo.Pass) > 0 && !UserServicer.verifyPassword(ctx, req.Pass, userInfo.Pass) {
+		return nil, errors.BadRequest(reason.UserPasswordWrong)
+	}
+	// the custom roles with the admin access can not delete their own account either
+	if RankServicer.CheckUserPower(ctx, req.UserID, permission.AdminAccess) {
+		return nil, errors.Forbidden(reason.UserDeletionForbidden)
+	}
+
+	resp, err = ds.GetDeletion(ctx, req.UserID)
+	if err != nil || resp.Pending {
+		return resp, err
+	}
+	scheduledAt := time.Now().Add(constant.UserDeletionGracePeriod)
+	if err = repo.UserDataRepo.SaveDeletion(ctx, req.UserID, scheduledAt); err != nil {
+		return nil, err
+	}
+	return &schema.GetUserDeletionResp{Pending: true, ScheduledAt: scheduledAt.Unix()}, nil
+}
+
+// CancelDeletion cancel the pending account deletion in the grace period
+func (ds *UserDataService) CancelDeletion(ctx context.Context, userID string) (err error) {
+	ok, err := repo.UserDataRepo.CancelDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.BadRequest(reason.UserDeletionNotFound)
+	}
+	return nil
+}
+
+// DeletionCron complete the account deletions whose grace period ended, it is called by the cron
+func (ds *UserDataService) DeletionCron(ctx context.Context) {
+	deletions, err := repo.UserDataRepo.GetDueDeletions(ctx, time.Now(), userDeletionBatchSize)
+	if err != nil {
+		glog.Slog.Error(err)
+		return
+	}
+	for _, deletion := range deletions {
+		if err = ds.deleteUser(ctx, deletion.UserID); err != nil {
+			glog.Slog.Errorf("delete user %s failed: %v", deletion.UserID, err)
+		}
+	}
+}
+
+// deleteUser erase the personal data of the user and log out all the sessions
+func (ds *UserDataService) deleteUser(ctx context.Context, userID string) (err error) {
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// the user is removed from the database, there is nothing to erase
+	if !exist {
+		_, err = repo.UserDataRepo.CancelDeletion(ctx, userID)
+		return err
+	}
+	before := &schema.UserStatusSnapshot{Status: userInfo.Status, MailStatus: userInfo.MailStatus}
+
+	userInfo.Username = "deleted_" + converter.DeleteUserDisplay(userInfo.ID)
+	userInfo.DisplayName = "user" + converter.DeleteUserDisplay(userInfo.ID)
+	userInfo.EMail = fmt.Sprintf("%s@deleted.invalid", userInfo.Username)
+	userInfo.MailStatus = entity.EmailStatusToBeVerified
+	userInfo.Status = entity.UserStatusDeleted
+	userInfo.DeletedAt = time.Now()
+	userInfo.Pass = ""
+	userInfo.Avatar = ""
+	userInfo.Mobile = ""
+	userInfo.Bio = ""
+	userInfo.BioHTML = ""
+	userInfo.Website = ""
+	userInfo.Location = ""
+	userInfo.IPInfo = ""
+	userInfo.Language = ""
+	ok, err := repo.UserDataRepo.AnonymizeUser(ctx, userInfo)
+	if err != nil || !ok {
+		return err
+	}
+	if err = AuthServicer.RemoveUserAllTokens(ctx, userID); err != nil {
+		return err
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     userID,
+		Action:     entity.AuditActionUserDataDelete,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userID,
+		Before:     before,
+		After:      &schema.UserStatusSnapshot{Status: userInfo.Status, MailStatus: userInfo.MailStatus},
+	})
+	return nil
+}
+
+// collect get all the personal data of the user
+func (ds *UserDataService) collect(ctx context.Context, userID string) (data *schema.UserDataExport, err error) {
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	data = &schema.UserDataExport{
+		Profile: &schema.UserDataProfile{
+			ID:            userInfo.ID,
+			Username:      userInfo.Username,
+			DisplayName:   userInfo.DisplayName,
+			EMail:         userInfo.EMail,
+			Bio:           userInfo.Bio,
+			Website:       userInfo.Website,
+			Location:      userInfo.Location,
+			Avatar:        userInfo.Avatar,
+			Language:      userInfo.Language,
+			IPInfo:        userInfo.IPInfo,
+			Rank:          userInfo.Rank,
+			CreatedAt:     userInfo.CreatedAt.Unix(),
+			LastLoginDate: userInfo.LastLoginDate.Unix(),
+		},
+		Questions:      make([]*schema.UserDataQuestion, 0),
+		Answers:        make([]*schema.UserDataAnswer, 0),
+		Comments:       make([]*schema.UserDataComment, 0),
+		Votes:          make([]*schema.UserDataVote, 0),
+		Collections:    make([]*schema.UserDataCollection, 0),
+		Notifications:  make([]*schema.UserDataNotification, 0),
+		ExternalLogins: make([]*schema.UserDataExternalLogin, 0),
+	}
+
+	questions, err := repo.UserDataRepo.GetUserQuestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range questions {
+		data.Questions = append(data.Questions, &schema.UserDataQuestion{
+			ID:        question.ID,
+			Title:     question.Title,
+			Content:   question.OriginalText,
+			Status:    question.Status,
+			CreatedAt: question.CreatedAt.Unix(),
+		})
+	}
+	answers, err := repo.UserDataRepo.GetUserAnswers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answers {
+		data.Answers = append(data.Answers, &schema.UserDataAnswer{
+			ID:         answer.ID,
+			QuestionID: answer.QuestionID,
+			Content:    answer.OriginalText,
+			Status:     answer.Status,
+			CreatedAt:  answer.CreatedAt.Unix(),
+		})
+	}
+	comments, err := repo.UserDataRepo.GetUserComments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		data.Comments = append(data.Comments, &schema.UserDataComment{
+			ID:         comment.ID,
+			ObjectID:   comment.ObjectID,
+			QuestionID: comment.QuestionID,
+			Content:    comment.OriginalText,
+			Status:     comment.Status,
+			CreatedAt:  comment.CreatedAt.Unix(),
+		})
+	}
+
+	activityTypes := make([]int, 0, len(userDataVoteTypeKeys))
+	voteTypeMapping := make(map[int]string, len(userDataVoteTypeKeys))
+	for _, typeKey := range userDataVoteTypeKeys {
+		cfg, err := utils.GetConfigByKey(ctx, typeKey)
+		if err != nil {
+			continue
+		}
+		activityTypes = append(activityTypes, cfg.ID)
+		voteTypeMapping[cfg.ID] = typeKey
+	}
+	votes, err := repo.UserDataRepo.GetUserVotes(ctx, userID, activityTypes)
+	if err != nil {
+		return nil, err
+	}
+	for _, vote := range votes {
+		data.Votes = append(data.Votes, &schema.UserDataVote{
+			ObjectID:  vote.ObjectID,
+			VoteType:  voteTypeMapping[vote.ActivityType],
+			CreatedAt: vote.CreatedAt.Unix(),
+		})
+	}
+
+	collections, err := repo.UserDataRepo.GetUserCollections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, collection := range collections {
+		data.Collections = append(data.Collections, &schema.UserDataCollection{
+			ObjectID:  collection.ObjectID,
+			GroupID:   collection.UserCollectionGroupID,
+			CreatedAt: collection.CreatedAt.Unix(),
+		})
+	}
+	notifications, err := repo.UserDataRepo.GetUserNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, notification := range notifications {
+		data.Notifications = append(data.Notifications, &schema.UserDataNotification{
+			ID:        notification.ID,
+			ObjectID:  notification.ObjectID,
+			Type:      notification.Type,
+			Content:   ds.rawJSON(notification.Content),
+			IsRead:    notification.IsRead == schema.NotificationRead,
+			CreatedAt: notification.CreatedAt.Unix(),
+		})
+	}
+	externalLogins, err := repo.UserExternalLoginRepo.GetUserExternalLoginList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, externalLogin := range externalLogins {
+		data.ExternalLogins = append(data.ExternalLogins, &schema.UserDataExternalLogin{
+			Provider:   externalLogin.Provider,
+			ExternalID: externalLogin.ExternalID,
+			MetaInfo:   ds.rawJSON(externalLogin.MetaInfo),
+			CreatedAt:  externalLogin.CreatedAt.Unix(),
+		})
+	}
+	return data, nil
+}
+
+func (ds *UserDataService) writeFile(archive *zip.Writer, name, content string) (err error) {
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if _, err = io.WriteString(file, content); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// rawJSON keep the json content as it is, the other content is exported as a json string
+func (ds *UserDataService) rawJSON(content string) json.RawMessage {
+	if json.Valid([]byte(content)) {
+		return json.RawMessage(content)
+	}
+	encoded, _ := json.Marshal(content)
+	return encoded
+}
+
+func (ds *UserDataService) profileMarkdown(data *schema.UserDataExport) string {
+	profile := data.Profile
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "# %s (@%s)\n\n", profile.DisplayName, profile.Username)
+	fmt.Fprintf(sb, "- Email: %s\n", profile.EMail)
+	fmt.Fprintf(sb, "- Website: %s\n", profile.Website)
+	fmt.Fprintf(sb, "- Location: %s\n", profile.Location)
+	fmt.Fprintf(sb, "- Reputation: %d\n", profile.Rank)
+	fmt.Fprintf(sb, "- Joined: %s\n", ds.formatTime(profile.CreatedAt))
+	fmt.Fprintf(sb, "- Last login: %s\n\n", ds.formatTime(profile.LastLoginDate))
+	if len(profile.Bio) > 0 {
+		fmt.Fprintf(sb, "## Bio\n\n%s\n\n", profile.Bio)
+	}
+	sb.WriteString("## Contents\n\n")
+	fmt.Fprintf(sb, "- Questions: %d, see questions.md\n", len(data.Questions))
+	fmt.Fprintf(sb, "- Answers: %d, see answers.md\n", len(data.Answers))
+	fmt.Fprintf(sb, "- Comments: %d, see comments.md\n", len(data.Comments))
+	fmt.Fprintf(sb, "- Votes: %d\n", len(data.Votes))
+	fmt.Fprintf(sb, "- Collections: %d\n", len(data.Collections))
+	fmt.Fprintf(sb, "- Notifications: %d\n", len(data.Notifications))
+	fmt.Fprintf(sb, "- External logins: %d\n\n", len(data.ExternalLogins))
+	sb.WriteString("All the data is also included as json files in this archive.\n")
+	return sb.String()
+}
+
+func (ds *UserDataService) questionsMarkdown(questions []*schema.UserDataQuestion) string {
+	sb := &strings.Builder{}
+	sb.WriteString("# Questions\n")
+	for _, question := range questions {
+		fmt.Fprintf(sb, "\n## %s\n\n_%s, id %s_\n\n%s\n\n---\n", question.Title, ds.formatTime(question.CreatedAt),
+			question.ID, question.Content)
+	}
+	return sb.String()
+}
+
+func (ds *UserDataService) answersMarkdown(answers []*schema.UserDataAnswer) string {
+	sb := &strings.Builder{}
+	sb.WriteString("# Answers\n")
+	for _, answer := range answers {
+		fmt.Fprintf(sb, "\n## Answer %s to question %s\n\n_%s_\n\n%s\n\n---\n", answer.ID, answer.QuestionID,
+			ds.formatTime(answer.CreatedAt), answer.Content)
+	}
+	return sb.String()
+}
+
+func (ds *UserDataService) commentsMarkdown(comments []*schema.UserDataComment) string {
+	sb := &strings.Builder{}
+	sb.WriteString("# Comments\n")
+	for _, comment := range comments {
+		fmt.Fprintf(sb, "\n## Comment %s on %s\n\n_%s_\n\n%s\n\n---\n", comment.ID, comment.ObjectID,
+			ds.formatTime(comment.CreatedAt), comment.Content)
+	}
+	return sb.String()
+}
+
+func (ds *UserDataService) formatTime(unix int64) string {
+	if unix <= 0 {
+		return "-"
+	}
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
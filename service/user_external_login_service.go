@@ -9,7 +9,6 @@ import (
 	"github.com/lawyer/commons/constant/reason"
 	entity "github.com/lawyer/commons/entity"
 	glog "github.com/lawyer/commons/logger"
-	"github.com/lawyer/commons/site"
 	"github.com/lawyer/commons/utils"
 	"github.com/lawyer/commons/utils/checker"
 	"github.com/lawyer/repo"
@@ -72,9 +71,7 @@ func (us *UserExternalLoginService) ExternalLogin(
 			if err != nil {
 				glog.Slog.Error(err)
 			}
-			accessToken, _, err := UserCommonServicer.CacheLoginUserInfo(
-				ctx, oldUserInfo.ID, newMailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
-			return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
+			return us.loginOrChallenge(ctx, oldUserInfo, newMailStatus, oldExternalLoginUserInfo.ExternalID)
 		}
 	}
 
@@ -89,7 +86,10 @@ func (us *UserExternalLoginService) ExternalLogin(
 	}
 
 	// check whether site allow register or not
-	siteInfo := site.Config.GetSiteLogin()
+	siteInfo, err := SiteInfoServicer.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if !checker.EmailInAllowEmailDomain(externalUserInfo.Email, siteInfo.AllowEmailDomains) {
 		glog.Slog.Debugf("email domain not allowed: %s", externalUserInfo.Email)
 		return &schema.UserExternalLoginResp{
@@ -126,9 +126,30 @@ func (us *UserExternalLoginService) ExternalLogin(
 		glog.Slog.Errorf("set default user notification config failed, err: %v", err)
 	}
 
-	accessToken, _, err := UserCommonServicer.CacheLoginUserInfo(
-		ctx, oldUserInfo.ID, newMailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
-	return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
+	return us.loginOrChallenge(ctx, oldUserInfo, newMailStatus, externalUserInfo.ExternalID)
+}
+
+// loginOrChallenge issue the access token, or the two-factor challenge when the user must pass the second step,
+// same as the login by the password and by the magic link
+func (us *UserExternalLoginService) loginOrChallenge(ctx context.Context, userInfo *entity.User, mailStatus int,
+	externalID string) (resp *schema.UserExternalLoginResp, err error) {
+	challenge, err := UserServicer.twoFactorChallenge(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &schema.UserExternalLoginResp{
+			TwoFactorRequired:      challenge.TwoFactorRequired,
+			TwoFactorSetupRequired: challenge.TwoFactorSetupRequired,
+			ChallengeToken:         challenge.ChallengeToken,
+		}, nil
+	}
+	accessToken, refreshToken, _, err := UserCommonServicer.CacheLoginUserInfo(
+		ctx, userInfo.ID, mailStatus, userInfo.Status, externalID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.UserExternalLoginResp{AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
 
 func (us *UserExternalLoginService) registerNewUser(ctx context.Context,
@@ -226,7 +247,10 @@ func (us *UserExternalLoginService) activeUser(ctx context.Context, oldUserInfo
 func (us *UserExternalLoginService) ExternalLoginBindingUserSendEmail(
 	ctx context.Context, req *schema.ExternalLoginBindingUserSendEmailReq) (
 	resp *schema.ExternalLoginBindingUserSendEmailResp, err error) {
-	siteGeneral := site.Config.GetSiteGeneral()
+	siteGeneral, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
 	resp = &schema.ExternalLoginBindingUserSendEmailResp{}
 	externalLoginInfo, err := repo.UserExternalLoginRepo.GetCacheUserExternalLoginInfo(ctx, req.BindingKey)
 	if err != nil || externalLoginInfo == nil {
@@ -252,10 +276,15 @@ func (us *UserExternalLoginService) ExternalLoginBindingUserSendEmail(
 		if err != nil {
 			return nil, err
 		}
-		resp.AccessToken, _, err = UserCommonServicer.CacheLoginUserInfo(
-			ctx, userInfo.ID, userInfo.MailStatus, userInfo.Status, externalLoginInfo.ExternalID)
+		loginResp, err := us.loginOrChallenge(ctx, userInfo, userInfo.MailStatus, externalLoginInfo.ExternalID)
 		if err != nil {
 			glog.Slog.Error(err)
+		} else {
+			resp.AccessToken = loginResp.AccessToken
+			resp.RefreshToken = loginResp.RefreshToken
+			resp.TwoFactorRequired = loginResp.TwoFactorRequired
+			resp.TwoFactorSetupRequired = loginResp.TwoFactorSetupRequired
+			resp.ChallengeToken = loginResp.ChallengeToken
 		}
 	}
 	err = repo.UserExternalLoginRepo.SetCacheUserExternalLoginInfo(ctx, req.BindingKey, externalLoginInfo)
@@ -281,15 +310,19 @@ func (us *UserExternalLoginService) ExternalLoginBindingUserSendEmail(
 }
 
 // ExternalLoginBindingUser
-// The user clicks on the email link of the bound account and requests the API to bind the user officially
+// The user clicks on the email link of the bound account and requests the API to bind the user officially,
+// the user is logged in after the binding, or gets the two-factor challenge
 func (us *UserExternalLoginService) ExternalLoginBindingUser(
-	ctx context.Context, bindingKey string, oldUserInfo *entity.User) (err error) {
+	ctx context.Context, bindingKey string, oldUserInfo *entity.User) (resp *schema.UserExternalLoginResp, err error) {
 
 	externalLoginInfo, err := repo.UserExternalLoginRepo.GetCacheUserExternalLoginInfo(ctx, bindingKey)
 	if err != nil || externalLoginInfo == nil {
-		return errors.BadRequest(reason.UserNotFound)
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if err = us.bindOldUser(ctx, externalLoginInfo, oldUserInfo); err != nil {
+		return nil, err
 	}
-	return us.bindOldUser(ctx, externalLoginInfo, oldUserInfo)
+	return us.loginOrChallenge(ctx, oldUserInfo, oldUserInfo.MailStatus, externalLoginInfo.ExternalID)
 }
 
 // GetExternalLoginUserInfoList get external login user info list
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lawyer/commons/base/translator"
+	"github.com/lawyer/commons/base/validator"
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	checker "github.com/lawyer/commons/utils/checker"
+	"github.com/lawyer/pkg/converter"
+	"github.com/lawyer/pkg/random"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// the files with more users are imported in the background, the progress is got by the job id
+	userImportSyncLimit = 100
+	// the progress of the background job is saved every time so many users are processed
+	userImportProgressStep = 50
+	userExportLimit        = 10000
+	userExportPageSize     = 100
+)
+
+// userImportItem the validated user to create
+type userImportItem struct {
+	userInfo *entity.User
+	password string
+	roleID   int
+}
+
+// ImportUsers import the users in the csv file. The report is returned directly for the small file, the large file
+// is imported in the background and the returned job is polled for the progress and the report.
+func (us *UserAdminService) ImportUsers(ctx context.Context, req *schema.ImportUsersReq, file io.Reader) (
+	resp *schema.UserImportJob, err error) {
+	if err = req.ParseUsers(file); err != nil {
+		return nil, err
+	}
+	job := &schema.UserImportJob{
+		JobID:     uuid.NewString(),
+		Status:    schema.UserImportStatusValidating,
+		DryRun:    req.DryRun,
+		Total:     len(req.Users),
+		Errors:    make([]*schema.AddUsersErrorData, 0),
+		CreatedAt: time.Now().Unix(),
+	}
+	if len(req.Users) <= userImportSyncLimit {
+		if err = us.runUserImport(ctx, req, job); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	if err = repo.UserAdminRepo.SetImportJob(ctx, job.JobID, job.ToJSONString()); err != nil {
+		return nil, err
+	}
+	started := *job
+	// the request context is done when the response is sent
+	jobCtx := context.WithValue(context.Background(), constant.AcceptLanguageFlag, utils.GetLangByCtx(ctx))
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				us.failUserImport(jobCtx, job, fmt.Errorf("panic: %v", r))
+			}
+		}()
+		// the failed job is normally saved by runUserImport, this only makes sure the error is not lost
+		if err := us.runUserImport(jobCtx, req, job); err != nil && job.Status != schema.UserImportStatusFailed {
+			us.failUserImport(jobCtx, job, err)
+		}
+	}()
+	return &started, nil
+}
+
+// GetUserImportJob get the progress and the report of the user import
+func (us *UserAdminService) GetUserImportJob(ctx context.Context, req *schema.GetUserImportJobReq) (
+	resp *schema.UserImportJob, err error) {
+	content, exist, err := repo.UserAdminRepo.GetImportJob(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserImportJobNotFound)
+	}
+	resp = &schema.UserImportJob{}
+	if err = resp.FromJSONString(content); err != nil {
+		return nil, errors.BadRequest(reason.UserImportJobNotFound)
+	}
+	return resp, nil
+}
+
+// ExportUsers export the users matching the filter of the user page as csv, the file can be imported again
+func (us *UserAdminService) ExportUsers(ctx context.Context, req *schema.GetUserPageReq) (content []byte, err error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	header := append([]string{}, schema.UserImportColumns...)
+	_ = writer.Write(append(header, "user_id", "status", "rank", "created_at"))
+	for page, exported := 1, 0; exported < userExportLimit; page++ {
+		// the query of the request is rewritten by the user page, so every page starts from the original one
+		pageReq := *req
+		pageReq.Page = page
+		pageReq.PageSize = userExportPageSize
+		pageModel, e := us.GetUserPage(ctx, &pageReq)
+		if e != nil {
+			return nil, e
+		}
+		users, _ := pageModel.List.([]*schema.GetUserPageResp)
+		for _, user := range users {
+			_ = writer.Write(converter.EscapeCSVFormula([]string{
+				user.Username,
+				user.EMail,
+				user.DisplayName,
+				user.RoleName,
+				user.Location,
+				user.Website,
+				user.UserID,
+				user.Status,
+				strconv.Itoa(user.Rank),
+				time.Unix(user.CreatedAt, 0).Format(time.RFC3339),
+			}))
+		}
+		exported += len(users)
+		if len(users) < userExportPageSize {
+			break
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// runUserImport validate all the users first, the users are created only when there is no error and it is not a dry run
+func (us *UserAdminService) runUserImport(ctx context.Context, req *schema.ImportUsersReq,
+	job *schema.UserImportJob) (err error) {
+	items, err := us.checkImportUsers(ctx, req.Users, job)
+	if err != nil {
+		us.failUserImport(ctx, job, err)
+		return err
+	}
+	if len(job.Errors) > 0 || req.DryRun {
+		job.Status = schema.UserImportStatusCompleted
+		us.saveUserImportJob(ctx, job)
+		return nil
+	}
+
+	job.Status = schema.UserImportStatusImporting
+	job.Processed = 0
+	us.saveUserImportJob(ctx, job)
+	if err = us.createImportUsers(ctx, items, req.SendWelcomeEmail, job); err != nil {
+		us.failUserImport(ctx, job, err)
+		return err
+	}
+	job.Created = len(items)
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditActionUserImport,
+		ObjectType: constant.UserObjectType,
+		After: map[string]interface{}{
+			"job_id":             job.JobID,
+			"total":              job.Total,
+			"created":            job.Created,
+			"send_welcome_email": req.SendWelcomeEmail,
+		},
+	})
+	job.Status = schema.UserImportStatusCompleted
+	us.saveUserImportJob(ctx, job)
+	return nil
+}
+
+// checkImportUsers check the users in the file and against the existing users, every invalid row is reported
+func (us *UserAdminService) checkImportUsers(ctx context.Context, users []*schema.ImportUserInfo,
+	job *schema.UserImportJob) (items []*userImportItem, err error) {
+	roleMapping, err := RoleServicer.GetRoleMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the imported users set their own password by the activation or the password reset,
+	// the random one only makes the user pass the same check as the bulk added users
+	addUsers := make([]*schema.AddUserReq, 0, len(users))
+	for _, user := range users {
+		displayName := user.DisplayName
+		if len(displayName) == 0 {
+			displayName = user.Username
+		}
+		addUsers = append(addUsers, &schema.AddUserReq{
+			DisplayName: displayName,
+			Email:       user.Email,
+			Password:    strings.ReplaceAll(uuid.NewString(), "-", ""),
+		})
+	}
+	invalid := make(map[int]bool)
+	for _, errorData := range us.checkUserDuplicateInnerAll(ctx, addUsers) {
+		invalid[errorData.Line-1] = true
+		us.addUserImportError(job, users[errorData.Line-1], errorData)
+	}
+
+	usernames := make(map[string]bool)
+	for i, user := range users {
+		job.Processed = i + 1
+		if job.Processed%userImportProgressStep == 0 {
+			us.saveUserImportJob(ctx, job)
+		}
+		if invalid[i] {
+			continue
+		}
+		item, errorData, err := us.checkImportUser(ctx, user, addUsers[i], roleMapping, usernames)
+		if err != nil {
+			return nil, err
+		}
+		if errorData != nil {
+			us.addUserImportError(job, user, errorData)
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(job.Errors, func(i, j int) bool {
+		return job.Errors[i].Line < job.Errors[j].Line
+	})
+	return items, nil
+}
+
+func (us *UserAdminService) checkImportUser(ctx context.Context, user *schema.ImportUserInfo,
+	addUser *schema.AddUserReq, roleMapping map[int]*entity.Role, usernames map[string]bool) (
+	item *userImportItem, errorData *schema.AddUsersErrorData, err error) {
+	lang := utils.GetLangByCtx(ctx)
+	if errFields, e := validator.GetValidatorByLang(lang).Check(user); e != nil {
+		errorData = &schema.AddUsersErrorData{}
+		if len(errFields) > 0 {
+			errorData.Field = errFields[0].ErrorField
+			errorData.ExtraMessage = errFields[0].ErrorMsg
+		}
+		return nil, errorData, nil
+	}
+
+	_, has, err := repo.UserAdminRepo.GetUserInfoByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, nil, err
+	}
+	if has {
+		return nil, &schema.AddUsersErrorData{
+			Field:        schema.UserImportColumnEmail,
+			ExtraMessage: translator.Tr(lang, reason.EmailDuplicate),
+		}, nil
+	}
+
+	roleID := RoleUserID
+	if len(user.Role) > 0 {
+		roleID = 0
+		for _, role := range roleMapping {
+			if strings.EqualFold(role.Name, user.Role) || strconv.Itoa(role.ID) == user.Role {
+				roleID = role.ID
+				break
+			}
+		}
+		if roleID == 0 {
+			return nil, &schema.AddUsersErrorData{
+				Field:        schema.UserImportColumnRole,
+				ExtraMessage: translator.Tr(lang, reason.RoleNotFound),
+			}, nil
+		}
+	}
+
+	username := user.Username
+	if len(username) > 0 {
+		if checker.IsInvalidUsername(username) || checker.IsReservedUsername(username) ||
+			checker.IsUsersIgnorePath(username) {
+			return nil, &schema.AddUsersErrorData{
+				Field:        schema.UserImportColumnUsername,
+				ExtraMessage: translator.Tr(lang, reason.UsernameInvalid),
+			}, nil
+		}
+		_, exist, err := repo.UserRepo.GetUserInfoByUsername(ctx, username)
+		if err != nil {
+			return nil, nil, err
+		}
+		if exist || usernames[username] {
+			return nil, &schema.AddUsersErrorData{
+				Field:        schema.UserImportColumnUsername,
+				ExtraMessage: translator.Tr(lang, reason.UsernameDuplicate),
+			}, nil
+		}
+	} else {
+		username, err = UserCommonServicer.MakeUsername(ctx, addUser.DisplayName)
+		if err != nil {
+			return nil, &schema.AddUsersErrorData{
+				Field:        schema.UserImportColumnDisplayName,
+				ExtraMessage: translator.Tr(lang, reason.UsernameInvalid),
+			}, nil
+		}
+		// the generated username is only unique in the database, not in the file
+		for base := username; usernames[username]; {
+			username = base + random.UsernameSuffix()
+		}
+	}
+	usernames[username] = true
+
+	userInfo := &entity.User{}
+	userInfo.EMail = user.Email
+	userInfo.DisplayName = addUser.DisplayName
+	userInfo.Username = username
+	userInfo.Location = user.Location
+	userInfo.Website = user.Website
+	userInfo.Status = entity.UserStatusAvailable
+	userInfo.Rank = 1
+	return &userImportItem{userInfo: userInfo, password: addUser.Password, roleID: roleID}, nil, nil
+}
+
+// createImportUsers create all the users in one transaction, no user is created when any of them fails.
+// The email is verified by the activation when the welcome email is sent.
+func (us *UserAdminService) createImportUsers(ctx context.Context, items []*userImportItem, sendWelcomeEmail bool,
+	job *schema.UserImportJob) (err error) {
+	users := make([]*entity.User, 0, len(items))
+	roleIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		hashPwd, err := bcrypt.GenerateFromPassword([]byte(item.password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		item.userInfo.Pass = string(hashPwd)
+		item.userInfo.MailStatus = entity.EmailStatusAvailable
+		if sendWelcomeEmail {
+			// the user gets the initial rank by the activation
+			item.userInfo.MailStatus = entity.EmailStatusToBeVerified
+			item.userInfo.Rank = 0
+		}
+		roleID := 0
+		if item.roleID != RoleUserID {
+			roleID = item.roleID
+		}
+		users = append(users, item.userInfo)
+		roleIDs = append(roleIDs, roleID)
+		job.Processed++
+		if job.Processed%userImportProgressStep == 0 {
+			us.saveUserImportJob(ctx, job)
+		}
+	}
+	if err = repo.UserAdminRepo.ImportUsers(ctx, users, roleIDs); err != nil {
+		return err
+	}
+	if !sendWelcomeEmail {
+		return nil
+	}
+	for _, user := range users {
+		// the user is created anyway, the admin can send the activation again
+		if err = us.SendUserActivation(ctx, &schema.SendUserActivationReq{UserID: user.ID}); err != nil {
+			glog.Slog.Errorf("send the activation to the imported user %s failed: %v", user.ID, err)
+		}
+	}
+	return nil
+}
+
+// addUserImportError report the error with the line and the content in the file
+func (us *UserAdminService) addUserImportError(job *schema.UserImportJob, user *schema.ImportUserInfo,
+	errorData *schema.AddUsersErrorData) {
+	errorData.Line = user.Line
+	errorData.Content = user.Content
+	job.Errors = append(job.Errors, errorData)
+}
+
+func (us *UserAdminService) failUserImport(ctx context.Context, job *schema.UserImportJob, err error) {
+	glog.Slog.Errorf("user import %s failed: %v", job.JobID, err)
+	job.Status = schema.UserImportStatusFailed
+	us.saveUserImportJob(ctx, job)
+}
+
+func (us *UserAdminService) saveUserImportJob(ctx context.Context, job *schema.UserImportJob) {
+	if err := repo.UserAdminRepo.SetImportJob(ctx, job.JobID, job.ToJSONString()); err != nil {
+		glog.Slog.Error(err)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lawyer/commons/constant"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// magicLinkMaxAttempts the login request is dropped after these failed verifications
+const magicLinkMaxAttempts = 5
+
+// CheckPasswordLogin the password login is turned off when the magic link login is required
+func (us *UserService) CheckPasswordLogin(ctx context.Context) (err error) {
+	siteLogin, err := SiteInfoServicer.GetSiteLogin(ctx)
+	if err != nil {
+		return err
+	}
+	if siteLogin.RequireMagicLinkLogin {
+		return errors.Forbidden(reason.PasswordLoginDisabled)
+	}
+	return nil
+}
+
+// SendMagicLink send the login link and the one-time code to the email. The request token is only returned to
+// the device which requested the login, the link or the code must be used together with it, so the login can
+// not be completed on the other devices. The response is the same for the unknown email.
+func (us *UserService) SendMagicLink(ctx context.Context, req *schema.MagicLinkSendReq) (
+	resp *schema.MagicLinkSendResp, err error) {
+	if err = us.checkMagicLinkLogin(ctx); err != nil {
+		return nil, err
+	}
+	resp = &schema.MagicLinkSendResp{
+		RequestToken: strings.ReplaceAll(uuid.NewString(), "-", ""),
+		ExpiresIn:    int64(constant.UserMagicLinkCacheTime.Seconds()),
+	}
+	userInfo, exist, err := repo.UserRepo.GetUserInfoByEmailFromDB(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return resp, nil
+	}
+
+	linkCode := strings.ReplaceAll(uuid.NewString(), "-", "")
+	oneTimeCode, err := us.generateOneTimeCode()
+	if err != nil {
+		return nil, err
+	}
+	data := &schema.MagicLinkCodeContent{
+		UserID:   userInfo.ID,
+		Email:    userInfo.EMail,
+		LinkHash: us.hashMagicLinkCode(linkCode),
+		CodeHash: us.hashMagicLinkCode(oneTimeCode),
+	}
+	magicLinkURL := fmt.Sprintf("%s/users/login/magic?code=%s", us.getSiteUrl(ctx), linkCode)
+	title, body, err := EmailServicer.MagicLinkTemplate(ctx, magicLinkURL, oneTimeCode)
+	if err != nil {
+		return nil, err
+	}
+	go EmailServicer.SendAndSaveCodeWithTime(ctx, userInfo.EMail, title, body,
+		constant.UserMagicLinkCacheKeyPrefix+resp.RequestToken, data.ToJSONString(), constant.UserMagicLinkCacheTime)
+	return resp, nil
+}
+
+// MagicLinkLogin login with the code of the link or the one-time code. The request is removed once it is used,
+// or after too many failed verifications. The users with two-factor authentication still get the challenge.
+func (us *UserService) MagicLinkLogin(ctx context.Context, req *schema.MagicLinkLoginReq) (
+	resp *schema.UserLoginResp, err error) {
+	if err = us.checkMagicLinkLogin(ctx); err != nil {
+		return nil, err
+	}
+	key := constant.UserMagicLinkCacheKeyPrefix + req.RequestToken
+	data := &schema.MagicLinkCodeContent{}
+	content := EmailServicer.VerifyEmailByCode(ctx, key)
+	if len(content) == 0 || data.FromJSONString(content) != nil {
+		return nil, errors.BadRequest(reason.MagicLinkInvalid)
+	}
+
+	hash := us.hashMagicLinkCode(req.Code)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(data.LinkHash)) != 1 &&
+		subtle.ConstantTimeCompare([]byte(hash), []byte(data.CodeHash)) != 1 {
+		attempts, err := repo.EmailRepo.IncrCodeAttempts(ctx, key, constant.UserMagicLinkCacheTime)
+		if err != nil {
+			return nil, err
+		}
+		if attempts >= magicLinkMaxAttempts {
+			if _, err = repo.EmailRepo.UseCode(ctx, key); err != nil {
+				return nil, err
+			}
+		}
+		return nil, errors.BadRequest(reason.MagicLinkCodeWrong)
+	}
+	// only one of the concurrent requests with the same code can use it
+	content, err = repo.EmailRepo.UseCode(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return nil, errors.BadRequest(reason.MagicLinkInvalid)
+	}
+
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// the email is changed after the link was sent
+	if !exist || userInfo.Status == entity.UserStatusDeleted || userInfo.EMail != data.Email {
+		return nil, errors.BadRequest(reason.MagicLinkInvalid)
+	}
+	return us.loginOrChallenge(ctx, userInfo)
+}
+
+func (us *UserService) checkMagicLinkLogin(ctx context.Context) (err error) {
+	siteLogin, err := SiteInfoServicer.GetSiteLogin(ctx)
+	if err != nil {
+		return err
+	}
+	if !siteLogin.AllowMagicLinkLogin && !siteLogin.RequireMagicLinkLogin {
+		return errors.Forbidden(reason.MagicLinkLoginDisabled)
+	}
+	return nil
+}
+
+// generateOneTimeCode generate the six digits code to type on the device which requested the login
+func (us *UserService) generateOneTimeCode() (code string, err error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func (us *UserService) hashMagicLinkCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"github.com/lawyer/commons/constant/reason"
 	entity "github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
 	"github.com/lawyer/repo"
+	"github.com/lawyer/service/permission"
+	"github.com/segmentfault/pacman/errors"
 )
 
 // UserRoleRelRepo userRoleRel repository
@@ -15,6 +20,17 @@ type UserRoleRelRepo interface {
 	GetUserRoleRel(ctx context.Context, userID string) (rolePowerRel *entity.UserRoleRel, exist bool, err error)
 }
 
+// UserRoleScopeRelRepo user role scope rel repository
+type UserRoleScopeRelRepo interface {
+	SaveUserRoleScopeRel(ctx context.Context, rel *entity.UserRoleScopeRel) (err error)
+	RemoveUserRoleScopeRel(ctx context.Context, userID, scopeType, scopeID string) (err error)
+	GetUserRoleScopeRelList(ctx context.Context, userID, scopeType string, scopeIDs []string) (
+		relList []*entity.UserRoleScopeRel, err error)
+	GetUserRoleScopeRelListByScope(ctx context.Context, scopeType, scopeID string) (
+		relList []*entity.UserRoleScopeRel, err error)
+	CountUserRoleScopeRelByRoleID(ctx context.Context, roleID int) (count int64, err error)
+}
+
 type UserRoleRelService struct {
 }
 
@@ -96,3 +112,162 @@ func (us *UserRoleRelService) GetUserByRoleID(ctx context.Context, roleIDs []int
 	}
 	return rolePowerRels, nil
 }
+
+// SaveUserScopedRole appoint the user with a role which only takes effect on the questions with the tag
+func (us *UserRoleRelService) SaveUserScopedRole(ctx context.Context, req *schema.SaveUserScopedRoleReq) (err error) {
+	_, exist, err := UserCommonServicer.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = RoleServicer.CheckRoleExist(ctx, req.RoleID); err != nil {
+		return err
+	}
+	powers, err := RolePowerRelServicer.GetRolePowerList(ctx, req.RoleID)
+	if err != nil {
+		return err
+	}
+	for _, power := range powers {
+		if power == permission.AdminAccess {
+			return errors.BadRequest(reason.RoleCannotBeScoped)
+		}
+	}
+	_, exist, err = repo.TagRepo.GetTagByID(ctx, req.TagID, false)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	return repo.UserRoleScopeRelRepo.SaveUserRoleScopeRel(ctx, &entity.UserRoleScopeRel{
+		UserID:    req.UserID,
+		RoleID:    req.RoleID,
+		ScopeType: entity.UserRoleScopeTypeTag,
+		ScopeID:   req.TagID,
+	})
+}
+
+// RemoveUserScopedRole remove the role of the user on the tag
+func (us *UserRoleRelService) RemoveUserScopedRole(ctx context.Context, req *schema.RemoveUserScopedRoleReq) (err error) {
+	return repo.UserRoleScopeRelRepo.RemoveUserRoleScopeRel(ctx, req.UserID, entity.UserRoleScopeTypeTag, req.TagID)
+}
+
+// GetUserScopedRoleList get the scoped roles of the user, or the moderators of the tag
+func (us *UserRoleRelService) GetUserScopedRoleList(ctx context.Context, req *schema.GetUserScopedRoleReq) (
+	resp []*schema.GetUserScopedRoleResp, err error) {
+	resp = make([]*schema.GetUserScopedRoleResp, 0)
+	var relList []*entity.UserRoleScopeRel
+	if len(req.UserID) > 0 {
+		relList, err = repo.UserRoleScopeRelRepo.GetUserRoleScopeRelList(ctx, req.UserID, entity.UserRoleScopeTypeTag, nil)
+	} else if len(req.TagID) > 0 {
+		relList, err = repo.UserRoleScopeRelRepo.GetUserRoleScopeRelListByScope(ctx, entity.UserRoleScopeTypeTag, req.TagID)
+	}
+	if err != nil || len(relList) == 0 {
+		return resp, err
+	}
+
+	roleMapping, err := RoleServicer.GetRoleMapping(ctx)
+	if err != nil {
+		return resp, err
+	}
+	tagIDs := make([]string, 0, len(relList))
+	for _, rel := range relList {
+		tagIDs = append(tagIDs, rel.ScopeID)
+	}
+	tagList, err := repo.TagRepo.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return resp, err
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tagList))
+	for _, tag := range tagList {
+		tagMapping[tag.ID] = tag
+	}
+
+	for _, rel := range relList {
+		item := &schema.GetUserScopedRoleResp{
+			UserID:    rel.UserID,
+			RoleID:    rel.RoleID,
+			ScopeType: rel.ScopeType,
+			TagID:     rel.ScopeID,
+		}
+		if role := roleMapping[rel.RoleID]; role != nil {
+			item.RoleName = role.Name
+		}
+		if tag := tagMapping[rel.ScopeID]; tag != nil {
+			item.SlugName = tag.SlugName
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// GetUserScopedPowerMapping get the powers that the user has on the object through the tag scoped roles
+func (us *UserRoleRelService) GetUserScopedPowerMapping(ctx context.Context, userID, objectID string) (
+	powerMapping map[string]bool) {
+	powerMapping = make(map[string]bool, 0)
+	if len(userID) == 0 || len(objectID) == 0 {
+		return powerMapping
+	}
+	tagIDs, err := ObjServicer.GetTagIDs(ctx, objectID)
+	if err != nil {
+		glog.Slog.Error(err)
+		return powerMapping
+	}
+	if len(tagIDs) == 0 {
+		return powerMapping
+	}
+	relList, err := repo.UserRoleScopeRelRepo.GetUserRoleScopeRelList(ctx, userID, entity.UserRoleScopeTypeTag, tagIDs)
+	if err != nil {
+		glog.Slog.Error(err)
+		return powerMapping
+	}
+	for _, rel := range relList {
+		powers, err := RolePowerRelServicer.GetRolePowerList(ctx, rel.RoleID)
+		if err != nil {
+			glog.Slog.Error(err)
+			continue
+		}
+		for _, power := range powers {
+			powerMapping[power] = true
+		}
+	}
+	// the admin access can never be granted by tag
+	delete(powerMapping, permission.AdminAccess)
+	return powerMapping
+}
+
+// GetUserScopedTagIDs get the tags on which the user has the power through the tag scoped roles
+func (us *UserRoleRelService) GetUserScopedTagIDs(ctx context.Context, userID, power string) (
+	tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	if len(userID) == 0 {
+		return tagIDs, nil
+	}
+	relList, err := repo.UserRoleScopeRelRepo.GetUserRoleScopeRelList(ctx, userID, entity.UserRoleScopeTypeTag, nil)
+	if err != nil {
+		return tagIDs, err
+	}
+	rolePowerMapping := make(map[int]bool, 0)
+	for _, rel := range relList {
+		has, ok := rolePowerMapping[rel.RoleID]
+		if !ok {
+			powers, err := RolePowerRelServicer.GetRolePowerList(ctx, rel.RoleID)
+			if err != nil {
+				return tagIDs, err
+			}
+			for _, p := range powers {
+				if p == power {
+					has = true
+					break
+				}
+			}
+			rolePowerMapping[rel.RoleID] = has
+		}
+		if has {
+			tagIDs = append(tagIDs, rel.ScopeID)
+		}
+	}
+	return tagIDs, nil
+}
@@ -12,6 +12,7 @@ import (
 	checker "github.com/lawyer/commons/utils/checker"
 	"github.com/lawyer/repo"
 	"github.com/lawyer/repoCommon"
+	"github.com/lawyer/service/permission"
 	"time"
 
 	"errors"
@@ -80,6 +81,58 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	if !us.verifyPassword(ctx, req.Pass, userInfo.Pass) {
 		return nil, errors.New(reason.EmailOrPasswordWrong)
 	}
+	return us.loginOrChallenge(ctx, userInfo)
+}
+
+// loginOrChallenge issue the access token, or the two-factor challenge when the user must pass the second step
+func (us *UserService) loginOrChallenge(ctx context.Context, userInfo *entity.User) (
+	resp *schema.UserLoginResp, err error) {
+	resp, err = us.twoFactorChallenge(ctx, userInfo.ID)
+	if err != nil || resp != nil {
+		return resp, err
+	}
+	return us.loginResp(ctx, userInfo)
+}
+
+// twoFactorChallenge start the two-factor challenge of the login, nil if the user does not need the second step
+func (us *UserService) twoFactorChallenge(ctx context.Context, userID string) (
+	resp *schema.UserLoginResp, err error) {
+	challengeRequired, setupRequired, err := UserTwoFactorServicer.CheckLogin(ctx, userID)
+	if err != nil || !challengeRequired {
+		return nil, err
+	}
+	resp = &schema.UserLoginResp{TwoFactorRequired: true, TwoFactorSetupRequired: setupRequired}
+	resp.ChallengeToken, err = UserTwoFactorServicer.NewChallenge(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TwoFactorLogin the second step of the login for the users with two-factor authentication
+func (us *UserService) TwoFactorLogin(ctx context.Context, req *schema.TwoFactorLoginReq) (
+	resp *schema.UserLoginResp, err error) {
+	userID, recoveryCodes, err := UserTwoFactorServicer.VerifyLogin(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.New(reason.UserNotFound)
+	}
+	resp, err = us.loginResp(ctx, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// loginResp issue the access token for the user who passed the verification
+func (us *UserService) loginResp(ctx context.Context, userInfo *entity.User) (resp *schema.UserLoginResp, err error) {
 	//更新最近登陆时间
 	err = repo.UserRepo.UpdateLastLoginDate(ctx, userInfo.ID)
 	if err != nil {
@@ -104,7 +157,7 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	//	//ExternalID:  externalID,
 	//}
 	//resp.AccessToken, err = AuthServicer.SetUserCacheInfo(ctx, userCacheInfo)
-	resp.AccessToken, err = utils.CreateToken(userInfo.Username, userInfo.ID, roleID)
+	resp.AccessToken, resp.RefreshToken, err = AuthServicer.CreateSession(ctx, userInfo.ID, userInfo.Username, roleID)
 	if err != nil {
 		return nil, err
 	}
@@ -164,9 +217,7 @@ func (us *UserService) UpdatePasswordWhenForgot(ctx context.Context, req *schema
 		return err
 	}
 	// When the user changes the password, all the current user's tokens are invalid.
-	//todo 确实需要删除旧的token，那么服务端如何删除这个token？这个也涉及到如何refresh token
-	//AuthServicer.RemoveUserAllTokens(ctx, userInfo.ID)
-	return nil
+	return AuthServicer.RemoveUserAllTokens(ctx, userInfo.ID)
 }
 
 func (us *UserService) UserPassWordVerification(ctx context.Context, uid, oldPass string) bool {
@@ -210,9 +261,8 @@ func (us *UserService) UserModifyPassword(ctx context.Context, req *schema.UserM
 	if err != nil {
 		return err
 	}
-	//todo
-	//AuthServicer.RemoveTokensExceptCurrentUser(ctx, userInfo.ID, req.AccessToken)
-	return nil
+	// log out everywhere except the current device
+	return AuthServicer.RemoveTokensExceptCurrentUser(ctx, userInfo.ID, req.SessionID)
 }
 
 // update user info
@@ -354,7 +404,7 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 	//}
 	//acctoken指向userCacheInfo
 	//resp.AccessToken, err = AuthServicer.SetUserCacheInfo(ctx, userCacheInfo)
-	resp.AccessToken, err = utils.CreateToken(userInfo.Username, userInfo.ID, roleID)
+	resp.AccessToken, resp.RefreshToken, err = AuthServicer.CreateSession(ctx, userInfo.ID, userInfo.Username, roleID)
 	if err != nil {
 		return nil, err
 	}
@@ -430,15 +480,8 @@ func (us *UserService) UserVerifyEmail(ctx context.Context, req *schema.UserVeri
 	//	UserName:    userInfo.Username,
 	//}
 	//resp.AccessToken, err = AuthServicer.SetUserCacheInfo(ctx, userCacheInfo)
-	resp.AccessToken, err = utils.CreateToken(userInfo.Username, userInfo.ID, 1)
-	if err != nil {
-		return nil, err
-	}
-
-	resp = &schema.UserLoginResp{}
-	resp.ConvertFromUserEntity(userInfo)
-	//resp.Avatar = SiteInfoCommonServicer.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
-	return resp, nil
+	// the verification link only proves the email, the user with two-factor must still pass the second step
+	return us.loginOrChallenge(ctx, userInfo)
 }
 
 // verifyPassword
@@ -536,39 +579,20 @@ func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string
 	//if err != nil {
 	//	glog.Slog.Error(err)
 	//}
-	roleID := 1
-	resp = &schema.UserLoginResp{}
-	resp.ConvertFromUserEntity(userInfo)
-	//resp.Avatar = SiteInfoCommonServicer.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
-	//userCacheInfo := &entity.UserCacheInfo{
-	//	UserID:      userInfo.ID,
-	//	EmailStatus: entity.EmailStatusAvailable,
-	//	UserStatus:  userInfo.Status,
-	//	RoleID:      roleID,
-	//}
-	//todo 如何作废之前的token？是个问题
-	//resp.AccessToken, err = AuthServicer.SetUserCacheInfo(ctx, userCacheInfo)
-	resp.AccessToken, err = utils.CreateToken(resp.Username, resp.ID, roleID)
-	if err != nil {
-		return nil, err
-	}
-	// User verified email will update user email status. So user status cache should be updated.
-	//if err = AuthServicer.SetUserCacheInfoByUid(ctx, userCacheInfo); err != nil {
-	//	return nil, err
-	//}
-	resp.RoleID = roleID
-	return resp, nil
+	userInfo.EMail = data.Email
+	userInfo.MailStatus = entity.EmailStatusAvailable
+	// the change email link only proves the new email, the user with two-factor must still pass the second step
+	return us.loginOrChallenge(ctx, userInfo)
 }
 
-// getSiteUrl get site url todo 改成读配置文件
+// getSiteUrl get site url
 func (us *UserService) getSiteUrl(ctx context.Context) string {
-	//siteGeneral, err := SiteInfoCommonServicer.GetSiteGeneral(ctx)
-	//if err != nil {
-	//	glog.Slog.Errorf("get site general failed: %s", err)
-	//	return ""
-	//}
-	//return siteGeneral.SiteUrl
-	return ""
+	siteGeneral, err := SiteInfoServicer.GetSiteGeneral(ctx)
+	if err != nil {
+		glog.Slog.Errorf("get site general failed: %s", err)
+		return ""
+	}
+	return siteGeneral.SiteUrl
 }
 
 // UserRanking get user ranking
@@ -687,7 +711,15 @@ func (us *UserService) getActivityUserVoteStat(ctx context.Context, startTime, e
 
 func (us *UserService) getStaff(ctx context.Context, userIDExist map[string]bool) (
 	userRoleRels []*entity.UserRoleRel, userIDs []string, err error) {
-	userRoleRels, err = UserRoleRelServicer.GetUserByRoleID(ctx, []int{RoleAdminID, RoleModeratorID})
+	// the staff are the users whose role can access admin or review the posts
+	staffRoleIDs, err := RolePowerRelServicer.GetRoleIDListByPowerType(ctx, permission.AdminAccess, permission.QuestionAudit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(staffRoleIDs) == 0 {
+		return nil, nil, nil
+	}
+	userRoleRels, err = UserRoleRelServicer.GetUserByRoleID(ctx, staffRoleIDs)
 	if err != nil {
 		return nil, nil, err
 	}
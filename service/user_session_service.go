@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	glog "github.com/lawyer/commons/logger"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/useragent"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// userSessionTouchInterval the last seen time of the session is updated at most once in the interval
+const userSessionTouchInterval = time.Minute
+
+// CreateSession create the login session of the current device and issue its access token and refresh token.
+// The device, user agent and ip are taken from the request of the context.
+func (as *AuthService) CreateSession(ctx context.Context, userID, userName string, roleID int) (
+	accessToken, refreshToken string, err error) {
+	userAgent := utils.GetUserAgentByCtx(ctx)
+	now := time.Now().Unix()
+	session := &entity.UserSession{
+		ID:         strings.ReplaceAll(uuid.NewString(), "-", ""),
+		UserID:     userID,
+		Device:     useragent.Device(userAgent),
+		UserAgent:  userAgent,
+		IP:         utils.GetClientIPByCtx(ctx),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	refreshToken, session.RefreshTokenHash, err = as.newRefreshToken(session.ID)
+	if err != nil {
+		return "", "", err
+	}
+	accessToken, err = utils.CreateToken(userName, userID, roleID, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if err = as.r.SetUserSession(ctx, session); err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// CheckSession whether the session of the access token is still alive, the last seen time is updated as well
+func (as *AuthService) CheckSession(ctx context.Context, claims *utils.CustomClaim) bool {
+	if len(claims.ID) == 0 {
+		return false
+	}
+	session, exist, err := as.r.GetUserSession(ctx, claims.ID)
+	if err != nil {
+		glog.Slog.Errorf("get user session failed: %s", err)
+		return false
+	}
+	if !exist || session.UserID != claims.Uid {
+		return false
+	}
+
+	now := time.Now()
+	ip := utils.GetClientIPByCtx(ctx)
+	if now.Unix()-session.LastSeenAt >= int64(userSessionTouchInterval.Seconds()) || (len(ip) > 0 && ip != session.IP) {
+		if err = as.r.TouchUserSession(ctx, session.ID, ip, now.Unix()); err != nil {
+			glog.Slog.Errorf("touch user session failed: %s", err)
+		}
+	}
+	return true
+}
+
+// RefreshSession issue a new access token by the refresh token, the refresh token is rotated at the same time.
+// Reusing a rotated refresh token means it is leaked, so the whole session is revoked.
+func (as *AuthService) RefreshSession(ctx context.Context, req *schema.RefreshTokenReq) (
+	resp *schema.RefreshTokenResp, err error) {
+	sessionID, _, found := strings.Cut(req.RefreshToken, ".")
+	if !found {
+		return nil, errors.Unauthorized(reason.RefreshTokenInvalid)
+	}
+	session, exist, err := as.r.GetUserSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.Unauthorized(reason.RefreshTokenInvalid)
+	}
+	tokenHash := as.hashRefreshToken(req.RefreshToken)
+	if len(session.PrevRefreshTokenHash) > 0 && tokenHash == session.PrevRefreshTokenHash {
+		glog.Slog.Warnf("rotated refresh token of session %s of user %s is reused, the session is revoked",
+			session.ID, session.UserID)
+		if err = as.r.RemoveUserSession(ctx, session.UserID, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, errors.Unauthorized(reason.RefreshTokenInvalid)
+	}
+	if tokenHash != session.RefreshTokenHash {
+		return nil, errors.Unauthorized(reason.RefreshTokenInvalid)
+	}
+
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable {
+		if err = as.r.RemoveUserSessions(ctx, session.UserID, ""); err != nil {
+			return nil, err
+		}
+		return nil, errors.Unauthorized(reason.RefreshTokenInvalid)
+	}
+	roleID, err := UserRoleRelServicer.GetUserRole(ctx, userInfo.ID)
+	if err != nil {
+		glog.Slog.Error(err)
+	}
+
+	resp = &schema.RefreshTokenResp{}
+	var newHash string
+	resp.RefreshToken, newHash, err = as.newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, ok, err := as.r.RotateUserSessionRefreshToken(ctx, session.ID, tokenHash, newHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Unauthorized(reason.RefreshTokenInvalid)
+	}
+	resp.AccessToken, err = utils.CreateToken(userInfo.Username, userInfo.ID, roleID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NewLoginExchange keep the tokens of the login which is redirected to the frontend, only the returned single-use
+// code goes into the url, so the tokens do not leak into the browser history, the referer or the access logs.
+func (as *AuthService) NewLoginExchange(ctx context.Context, accessToken, refreshToken string) (
+	code string, err error) {
+	content, _ := json.Marshal(&schema.RefreshTokenResp{AccessToken: accessToken, RefreshToken: refreshToken})
+	code = strings.ReplaceAll(uuid.NewString(), "-", "")
+	if err = as.r.SetLoginExchange(ctx, code, string(content)); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// ExchangeLogin get the tokens of the login by the code, the code can only be used once
+func (as *AuthService) ExchangeLogin(ctx context.Context, req *schema.LoginExchangeReq) (
+	resp *schema.RefreshTokenResp, err error) {
+	content, err := as.r.UseLoginExchange(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.RefreshTokenResp{}
+	if len(content) == 0 || json.Unmarshal([]byte(content), resp) != nil {
+		return nil, errors.Unauthorized(reason.LoginExchangeCodeInvalid)
+	}
+	return resp, nil
+}
+
+// GetSessionList get the login sessions of the user, the latest active one is the first
+func (as *AuthService) GetSessionList(ctx context.Context, userID, currentSessionID string) (
+	resp []*schema.UserSessionResp, err error) {
+	sessionList, err := as.r.GetUserSessionList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sessionList, func(i, j int) bool {
+		return sessionList[i].LastSeenAt > sessionList[j].LastSeenAt
+	})
+	resp = make([]*schema.UserSessionResp, 0, len(sessionList))
+	for _, session := range sessionList {
+		resp = append(resp, &schema.UserSessionResp{
+			ID:         session.ID,
+			Device:     session.Device,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession log out the session of the user
+func (as *AuthService) RevokeSession(ctx context.Context, req *schema.RevokeUserSessionReq) (err error) {
+	session, exist, err := as.r.GetUserSession(ctx, req.SessionID)
+	if err != nil {
+		return err
+	}
+	if !exist || session.UserID != req.UserID {
+		return errors.NotFound(reason.UserSessionNotFound)
+	}
+	return as.r.RemoveUserSession(ctx, session.UserID, session.ID)
+}
+
+// RemoveUserAllTokens log out all the sessions of the user, the issued access tokens are rejected immediately
+func (as *AuthService) RemoveUserAllTokens(ctx context.Context, userID string) (err error) {
+	return as.r.RemoveUserSessions(ctx, userID, "")
+}
+
+// RemoveTokensExceptCurrentUser log out all the sessions of the user except the current one
+func (as *AuthService) RemoveTokensExceptCurrentUser(ctx context.Context, userID, sessionID string) (err error) {
+	return as.r.RemoveUserSessions(ctx, userID, sessionID)
+}
+
+// newRefreshToken generate the refresh token of the session, only its hash is kept
+func (as *AuthService) newRefreshToken(sessionID string) (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	token = sessionID + "." + hex.EncodeToString(buf)
+	return token, as.hashRefreshToken(token), nil
+}
+
+func (as *AuthService) hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
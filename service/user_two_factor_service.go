@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lawyer/commons/constant/reason"
+	"github.com/lawyer/commons/entity"
+	"github.com/lawyer/commons/schema"
+	"github.com/lawyer/commons/utils"
+	"github.com/lawyer/pkg/totp"
+	"github.com/lawyer/repo"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// twoFactorRecoveryCodeCount the number of the recovery codes generated at once
+	twoFactorRecoveryCodeCount = 10
+	// twoFactorChallengeMaxAttempts the login challenge is dropped after these failed verifications
+	twoFactorChallengeMaxAttempts = 5
+)
+
+// UserTwoFactorRepo user two-factor authentication repository
+type UserTwoFactorRepo interface {
+	GetByUserID(ctx context.Context, userID string) (twoFactor *entity.UserTwoFactor, exist bool, err error)
+	SavePendingSecret(ctx context.Context, userID, secret string) (err error)
+	Enable(ctx context.Context, userID, recoveryCodes string, step int64) (err error)
+	UseStep(ctx context.Context, userID string, step int64) (ok bool, err error)
+	ReplaceRecoveryCodes(ctx context.Context, userID, oldCodes, newCodes string) (ok bool, err error)
+	RemoveByUserID(ctx context.Context, userID string) (err error)
+	SetChallenge(ctx context.Context, token string, challenge *entity.TwoFactorChallenge) (err error)
+	IncrChallengeAttempts(ctx context.Context, token string) (attempts int64, err error)
+	GetChallenge(ctx context.Context, token string) (challenge *entity.TwoFactorChallenge, exist bool, err error)
+	RemoveChallenge(ctx context.Context, token string) (err error)
+}
+
+// UserTwoFactorService the TOTP two-factor authentication of the users, the recovery codes are stored as hashes
+type UserTwoFactorService struct {
+}
+
+// NewUserTwoFactorService new user two-factor authentication service
+func NewUserTwoFactorService() *UserTwoFactorService {
+	return &UserTwoFactorService{}
+}
+
+// GetTwoFactorPolicy get the two-factor authentication policy
+func (ts *UserTwoFactorService) GetTwoFactorPolicy(ctx context.Context) (policy *schema.TwoFactorPolicy, err error) {
+	policy = &schema.TwoFactorPolicy{RequiredRoleIDs: make([]int, 0)}
+	cfg, err := utils.GetConfigByKey(ctx, entity.TwoFactorPolicyConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Value) == 0 {
+		return policy, nil
+	}
+	if err = json.Unmarshal([]byte(cfg.Value), policy); err != nil {
+		return nil, fmt.Errorf("[%s] config value is not json format", cfg.Key)
+	}
+	return policy, nil
+}
+
+// UpdateTwoFactorPolicy update the two-factor authentication policy
+func (ts *UserTwoFactorService) UpdateTwoFactorPolicy(ctx context.Context, req *schema.UpdateTwoFactorPolicyReq) (
+	err error) {
+	for _, roleID := range req.RequiredRoleIDs {
+		if err = RoleServicer.CheckRoleExist(ctx, roleID); err != nil {
+			return err
+		}
+	}
+	oldPolicy, err := ts.GetTwoFactorPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &req.TwoFactorPolicy
+	if policy.RequiredRoleIDs == nil {
+		policy.RequiredRoleIDs = make([]int, 0)
+	}
+	content, _ := json.Marshal(policy)
+	if err = utils.UpdateConfig(ctx, entity.TwoFactorPolicyConfigKey, string(content)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.UserID,
+		Action:     entity.AuditActionTwoFactorPolicy,
+		ObjectType: "two_factor_policy",
+		ObjectID:   entity.TwoFactorPolicyConfigKey,
+		Before:     oldPolicy,
+		After:      policy,
+	})
+	return nil
+}
+
+// IsRequired whether the two-factor authentication is enforced for the role of the user
+func (ts *UserTwoFactorService) IsRequired(ctx context.Context, userID string) (required bool, err error) {
+	policy, err := ts.GetTwoFactorPolicy(ctx)
+	if err != nil || len(policy.RequiredRoleIDs) == 0 {
+		return false, err
+	}
+	roleID, err := UserRoleRelServicer.GetUserRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, requiredRoleID := range policy.RequiredRoleIDs {
+		if requiredRoleID == roleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetStatus get the two-factor authentication status of the user
+func (ts *UserTwoFactorService) GetStatus(ctx context.Context, userID string) (
+	resp *schema.GetTwoFactorStatusResp, err error) {
+	resp = &schema.GetTwoFactorStatusResp{}
+	resp.Required, err = ts.IsRequired(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	twoFactor, exist, err := repo.UserTwoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && twoFactor.Status == entity.TwoFactorStatusEnabled {
+		resp.Enabled = true
+		resp.RecoveryCodesLeft = len(ts.parseRecoveryCodes(twoFactor.RecoveryCodes))
+	}
+	return resp, nil
+}
+
+// StartEnroll generate a new secret for the user, it takes effect after it is confirmed by a code
+func (ts *UserTwoFactorService) StartEnroll(ctx context.Context, userID string) (
+	resp *schema.TwoFactorEnrollResp, err error) {
+	twoFactor, exist, err := repo.UserTwoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && twoFactor.Status == entity.TwoFactorStatusEnabled {
+		return nil, errors.BadRequest(reason.TwoFactorAlreadyEnabled)
+	}
+	userInfo, exist, err := repo.UserRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	issuer := "Lawyer"
+	if general, err := SiteInfoServicer.GetSiteGeneral(ctx); err == nil && len(general.Name) > 0 {
+		issuer = general.Name
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = repo.UserTwoFactorRepo.SavePendingSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+	return &schema.TwoFactorEnrollResp{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(issuer, userInfo.EMail, secret),
+	}, nil
+}
+
+// ConfirmEnroll enable the two-factor authentication when the code matches the pending secret,
+// the recovery codes are returned in plain text only this time
+func (ts *UserTwoFactorService) ConfirmEnroll(ctx context.Context, req *schema.TwoFactorConfirmReq) (
+	resp *schema.TwoFactorRecoveryCodesResp, err error) {
+	twoFactor, exist, err := repo.UserTwoFactorRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TwoFactorNotEnabled)
+	}
+	if twoFactor.Status == entity.TwoFactorStatusEnabled {
+		return nil, errors.BadRequest(reason.TwoFactorAlreadyEnabled)
+	}
+	step, ok := totp.Validate(twoFactor.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, errors.BadRequest(reason.TwoFactorCodeWrong)
+	}
+	codes, hashes, err := ts.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.UserTwoFactorRepo.Enable(ctx, req.UserID, hashes, step); err != nil {
+		return nil, err
+	}
+	return &schema.TwoFactorRecoveryCodesResp{RecoveryCodes: codes}, nil
+}
+
+// Disable disable the two-factor authentication, it can not be disabled when it is required for the role
+func (ts *UserTwoFactorService) Disable(ctx context.Context, req *schema.TwoFactorVerifyReq) (err error) {
+	required, err := ts.IsRequired(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.Forbidden(reason.TwoFactorRequired)
+	}
+	twoFactor, err := ts.getEnabled(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	ok, err := ts.verify(ctx, twoFactor, req.Code, req.RecoveryCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.BadRequest(reason.TwoFactorCodeWrong)
+	}
+	return repo.UserTwoFactorRepo.RemoveByUserID(ctx, req.UserID)
+}
+
+// RegenerateRecoveryCodes replace all the recovery codes with the new ones
+func (ts *UserTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, req *schema.TwoFactorVerifyReq) (
+	resp *schema.TwoFactorRecoveryCodesResp, err error) {
+	twoFactor, err := ts.getEnabled(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := ts.verify(ctx, twoFactor, req.Code, "")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.BadRequest(reason.TwoFactorCodeWrong)
+	}
+	codes, hashes, err := ts.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	ok, err = repo.UserTwoFactorRepo.ReplaceRecoveryCodes(ctx, req.UserID, twoFactor.RecoveryCodes, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.BadRequest(reason.TwoFactorCodeWrong)
+	}
+	return &schema.TwoFactorRecoveryCodesResp{RecoveryCodes: codes}, nil
+}
+
+// Reset remove the two-factor authentication of the user, it is used by the admin when the user lost the
+// authenticator and the recovery codes
+func (ts *UserTwoFactorService) Reset(ctx context.Context, req *schema.ResetTwoFactorReq) (err error) {
+	if _, err = ts.getEnabled(ctx, req.UserID); err != nil {
+		return err
+	}
+	if err = repo.UserTwoFactorRepo.RemoveByUserID(ctx, req.UserID); err != nil {
+		return err
+	}
+	AuditLogServicer.Record(ctx, &schema.AuditLogMsg{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditActionTwoFactorReset,
+		ObjectType: "user",
+		ObjectID:   req.UserID,
+	})
+	return nil
+}
+
+// CheckLogin check whether the user must pass the two-factor verification after the password is verified,
+// setupRequired means the user must enroll before the login
+func (ts *UserTwoFactorService) CheckLogin(ctx context.Context, userID string) (
+	challengeRequired, setupRequired bool, err error) {
+	twoFactor, exist, err := repo.UserTwoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, false, err
+	}
+	if exist && twoFactor.Status == entity.TwoFactorStatusEnabled {
+		return true, false, nil
+	}
+	required, err := ts.IsRequired(ctx, userID)
+	if err != nil {
+		return false, false, err
+	}
+	return required, required, nil
+}
+
+// NewChallenge create a short-lived challenge token for the second step of the login
+func (ts *UserTwoFactorService) NewChallenge(ctx context.Context, userID string) (token string, err error) {
+	token = strings.ReplaceAll(uuid.NewString(), "-", "")
+	err = repo.UserTwoFactorRepo.SetChallenge(ctx, token, &entity.TwoFactorChallenge{UserID: userID})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// LoginSetup generate the secret for the user who must enroll during the login
+func (ts *UserTwoFactorService) LoginSetup(ctx context.Context, req *schema.TwoFactorLoginSetupReq) (
+	resp *schema.TwoFactorEnrollResp, err error) {
+	challenge, exist, err := repo.UserTwoFactorRepo.GetChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TwoFactorChallengeInvalid)
+	}
+	_, setupRequired, err := ts.CheckLogin(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !setupRequired {
+		return nil, errors.BadRequest(reason.TwoFactorAlreadyEnabled)
+	}
+	return ts.StartEnroll(ctx, challenge.UserID)
+}
+
+// VerifyLogin verify the code of the login challenge, the user who must enroll confirms the enrollment with the
+// code and gets the recovery codes. The challenge is dropped after too many failed verifications.
+func (ts *UserTwoFactorService) VerifyLogin(ctx context.Context, req *schema.TwoFactorLoginReq) (
+	userID string, recoveryCodes []string, err error) {
+	challenge, exist, err := repo.UserTwoFactorRepo.GetChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return "", nil, err
+	}
+	if !exist {
+		return "", nil, errors.BadRequest(reason.TwoFactorChallengeInvalid)
+	}
+	// the attempt is counted before the verification, so the concurrent verifications can not exceed the limit
+	attempts, err := repo.UserTwoFactorRepo.IncrChallengeAttempts(ctx, req.ChallengeToken)
+	if err != nil {
+		return "", nil, err
+	}
+	if attempts > twoFactorChallengeMaxAttempts {
+		if err = repo.UserTwoFactorRepo.RemoveChallenge(ctx, req.ChallengeToken); err != nil {
+			return "", nil, err
+		}
+		return "", nil, errors.BadRequest(reason.TwoFactorChallengeInvalid)
+	}
+	twoFactor, exist, err := repo.UserTwoFactorRepo.GetByUserID(ctx, challenge.UserID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ok := false
+	switch {
+	case exist && twoFactor.Status == entity.TwoFactorStatusEnabled:
+		ok, err = ts.verify(ctx, twoFactor, req.Code, req.RecoveryCode)
+	case exist && len(req.Code) > 0:
+		step, valid := totp.Validate(twoFactor.Secret, req.Code, time.Now())
+		if !valid {
+			break
+		}
+		var hashes string
+		recoveryCodes, hashes, err = ts.generateRecoveryCodes()
+		if err == nil {
+			err = repo.UserTwoFactorRepo.Enable(ctx, challenge.UserID, hashes, step)
+		}
+		ok = err == nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if !ok {
+		if attempts >= twoFactorChallengeMaxAttempts {
+			if err = repo.UserTwoFactorRepo.RemoveChallenge(ctx, req.ChallengeToken); err != nil {
+				return "", nil, err
+			}
+		}
+		return "", nil, errors.BadRequest(reason.TwoFactorCodeWrong)
+	}
+	if err = repo.UserTwoFactorRepo.RemoveChallenge(ctx, req.ChallengeToken); err != nil {
+		return "", nil, err
+	}
+	return challenge.UserID, recoveryCodes, nil
+}
+
+func (ts *UserTwoFactorService) getEnabled(ctx context.Context, userID string) (
+	twoFactor *entity.UserTwoFactor, err error) {
+	twoFactor, exist, err := repo.UserTwoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || twoFactor.Status != entity.TwoFactorStatusEnabled {
+		return nil, errors.BadRequest(reason.TwoFactorNotEnabled)
+	}
+	return twoFactor, nil
+}
+
+// verify check the code or the recovery code, each code can only be used once
+func (ts *UserTwoFactorService) verify(ctx context.Context, twoFactor *entity.UserTwoFactor,
+	code, recoveryCode string) (ok bool, err error) {
+	if len(code) > 0 {
+		step, valid := totp.Validate(twoFactor.Secret, code, time.Now())
+		if !valid {
+			return false, nil
+		}
+		return repo.UserTwoFactorRepo.UseStep(ctx, twoFactor.UserID, step)
+	}
+	if len(recoveryCode) == 0 {
+		return false, nil
+	}
+	hashes := ts.parseRecoveryCodes(twoFactor.RecoveryCodes)
+	hash := ts.hashRecoveryCode(recoveryCode)
+	left := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		if h != hash {
+			left = append(left, h)
+		}
+	}
+	if len(left) == len(hashes) {
+		return false, nil
+	}
+	content, _ := json.Marshal(left)
+	return repo.UserTwoFactorRepo.ReplaceRecoveryCodes(ctx, twoFactor.UserID, twoFactor.RecoveryCodes, string(content))
+}
+
+// generateRecoveryCodes generate the recovery codes like "a1b2c-d3e4f" and their hashes as json
+func (ts *UserTwoFactorService) generateRecoveryCodes() (codes []string, hashes string, err error) {
+	codes = make([]string, 0, twoFactorRecoveryCodeCount)
+	hashList := make([]string, 0, twoFactorRecoveryCodeCount)
+	buf := make([]byte, 5)
+	for i := 0; i < twoFactorRecoveryCodeCount; i++ {
+		if _, err = rand.Read(buf); err != nil {
+			return nil, "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		code := hex.EncodeToString(buf)
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashList = append(hashList, ts.hashRecoveryCode(code))
+	}
+	content, _ := json.Marshal(hashList)
+	return codes, string(content), nil
+}
+
+func (ts *UserTwoFactorService) hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func (ts *UserTwoFactorService) parseRecoveryCodes(content string) (hashes []string) {
+	hashes = make([]string, 0)
+	_ = json.Unmarshal([]byte(content), &hashes)
+	return hashes
+}
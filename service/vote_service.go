@@ -14,6 +14,7 @@ import (
 
 	"github.com/lawyer/commons/schema"
 	"github.com/lawyer/commons/utils/pager"
+	"github.com/lawyer/pkg/converter"
 	"github.com/lawyer/pkg/htmltext"
 	"github.com/segmentfault/pacman/errors"
 )
@@ -83,10 +84,31 @@ func (vs *VoteService) VoteUp(ctx context.Context, req *schema.VoteReq) (resp *s
 	resp.Votes = resp.UpVotes - resp.DownVotes
 	if !req.IsCancel {
 		resp.VoteStatus = constant.ActVoteUp
+		vs.sendVotedUpActivity(ctx, req, objectInfo)
 	}
 	return resp, nil
 }
 
+// sendVotedUpActivity the vote activity is saved by the vote repo, only notify the other activity handlers such as badges
+func (vs *VoteService) sendVotedUpActivity(ctx context.Context, req *schema.VoteReq, objectInfo *schema.SimpleObjectInfo) {
+	var activityTypeKey constant.ActivityTypeKey
+	switch objectInfo.ObjectType {
+	case constant.QuestionObjectType:
+		activityTypeKey = constant.ActQuestionVotedUp
+	case constant.AnswerObjectType:
+		activityTypeKey = constant.ActAnswerVotedUp
+	default:
+		return
+	}
+	ActivityQueueServicer.Send(ctx, &schema.ActivityMsg{
+		UserID:          objectInfo.ObjectCreatorUserID,
+		TriggerUserID:   converter.StringToInt64(req.UserID),
+		ObjectID:        req.ObjectID,
+		ActivityTypeKey: activityTypeKey,
+		HasRecorded:     true,
+	})
+}
+
 // VoteDown vote down
 func (vs *VoteService) VoteDown(ctx context.Context, req *schema.VoteReq) (resp *schema.VoteResp, err error) {
 	objectInfo, err := ObjServicer.GetInfo(ctx, req.ObjectID)
@@ -224,6 +246,11 @@ func (vs *VoteService) getActivities(ctx context.Context, op *schema.VoteOperati
 		actions = []string{constant.CommentVoteUp}
 	}
 
+	policy, err := ReputationPolicyServicer.GetReputationPolicy(ctx)
+	if err != nil {
+		glog.Slog.Warnf("get reputation policy error: %v", err)
+		policy = &schema.ReputationPolicy{}
+	}
 	for _, action := range actions {
 		t := &schema.VoteActivity{}
 		cfg, err := (&config.ConfigService{}).GetConfigByKey(ctx, action)
@@ -231,7 +258,8 @@ func (vs *VoteService) getActivities(ctx context.Context, op *schema.VoteOperati
 			glog.Slog.Warnf("get config by key error: %v", err)
 			continue
 		}
-		t.ActivityType, t.Rank = cfg.ID, cfg.GetIntValue()
+		t.ActivityType, t.RuleVersion = cfg.ID, policy.Version
+		t.Rank = ReputationPolicyServicer.GetActivityRank(ctx, policy, action, cfg.GetIntValue(), op.ObjectID)
 
 		if strings.Contains(action, "voted") {
 			t.ActivityUserID = op.ObjectCreatorUserID
@@ -0,0 +1,6 @@
+package ui
+
+import "embed"
+
+//go:embed template/*.html
+var Template embed.FS